@@ -23,9 +23,21 @@ import (
 
 	"context"
 
+	cosiv1alpha1 "github.com/cosi-project/runtime/api/v1alpha1"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/siderolabs/talos/pkg/machinery/meta"
+	"github.com/siderolabs/talos/pkg/machinery/resources/config"
+	"github.com/siderolabs/talos/pkg/machinery/resources/k8s"
+	"github.com/siderolabs/talos/pkg/machinery/resources/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -60,12 +72,29 @@ type mockSrv struct {
 	serviceListErr  error
 	etcdStatusResp  *machine.EtcdStatusResponse
 	etcdStatusErr   error
+	etcdStatusNodes map[string]*machine.EtcdMemberStatus // per-node replies to a "nodes" request
 	etcdMemberResp  *machine.EtcdMemberListResponse
 	etcdMemberErr   error
 	etcdAlarmResp   *machine.EtcdAlarmListResponse
 	etcdAlarmErr    error
 	loadAvgResp     *machine.LoadAvgResponse
 	loadAvgErr      error
+	netStatsResp    *machine.NetworkDeviceStatsResponse
+	netStatsSamples []*machine.NetworkDeviceStatsResponse
+	netStatsErr     error
+	diskStatsResp   *machine.DiskStatsResponse
+	diskStatsErr    error
+	readData        map[string]string
+	readErr         error
+	processesResp   *machine.ProcessesResponse
+	processesErr    error
+	versionResp     *machine.VersionResponse
+	versionErr      error
+	containersResp  map[string]*machine.ContainersResponse // keyed by namespace
+	containersErr   error
+	dmesgData       string
+	dmesgErr        error
+	resources       []resource.Resource // COSI state served by stateSrv
 }
 
 func (s *mockSrv) reset() {
@@ -81,12 +110,29 @@ func (s *mockSrv) reset() {
 	s.serviceListErr = nil
 	s.etcdStatusResp = nil
 	s.etcdStatusErr = nil
+	s.etcdStatusNodes = nil
 	s.etcdMemberResp = nil
 	s.etcdMemberErr = nil
 	s.etcdAlarmResp = nil
 	s.etcdAlarmErr = nil
 	s.loadAvgResp = nil
 	s.loadAvgErr = nil
+	s.netStatsResp = nil
+	s.netStatsSamples = nil
+	s.netStatsErr = nil
+	s.diskStatsResp = nil
+	s.diskStatsErr = nil
+	s.readData = nil
+	s.readErr = nil
+	s.processesResp = nil
+	s.processesErr = nil
+	s.versionResp = nil
+	s.versionErr = nil
+	s.containersResp = nil
+	s.containersErr = nil
+	s.dmesgData = ""
+	s.dmesgErr = nil
+	s.resources = nil
 }
 
 func (s *mockSrv) SystemStat(_ context.Context, _ *emptypb.Empty) (*machine.SystemStatResponse, error) {
@@ -113,9 +159,26 @@ func (s *mockSrv) ServiceList(_ context.Context, _ *emptypb.Empty) (*machine.Ser
 	return s.serviceListResp, s.serviceListErr
 }
 
-func (s *mockSrv) EtcdStatus(_ context.Context, _ *emptypb.Empty) (*machine.EtcdStatusResponse, error) {
+func (s *mockSrv) EtcdStatus(ctx context.Context, _ *emptypb.Empty) (*machine.EtcdStatusResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	// Answer a multi-node request the way apid does: one message per node,
+	// with an error in the metadata of the nodes that are unknown.
+	md, _ := metadata.FromIncomingContext(ctx)
+	if nodes := md.Get("nodes"); len(nodes) > 0 && s.etcdStatusNodes != nil {
+		resp := &machine.EtcdStatusResponse{}
+		for _, node := range nodes {
+			msg := &machine.EtcdStatus{Metadata: &common.Metadata{Hostname: node}}
+			if st, ok := s.etcdStatusNodes[node]; ok {
+				msg.MemberStatus = st
+			} else {
+				msg.Metadata.Error = "connection refused"
+			}
+			resp.Messages = append(resp.Messages, msg)
+		}
+		return resp, nil
+	}
 	return s.etcdStatusResp, s.etcdStatusErr
 }
 
@@ -137,6 +200,99 @@ func (s *mockSrv) LoadAvg(_ context.Context, _ *emptypb.Empty) (*machine.LoadAvg
 	return s.loadAvgResp, s.loadAvgErr
 }
 
+func (s *mockSrv) NetworkDeviceStats(_ context.Context, _ *emptypb.Empty) (*machine.NetworkDeviceStatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Successive calls consume netStatsSamples; the last one repeats.
+	if len(s.netStatsSamples) > 0 {
+		resp := s.netStatsSamples[0]
+		if len(s.netStatsSamples) > 1 {
+			s.netStatsSamples = s.netStatsSamples[1:]
+		}
+		return resp, s.netStatsErr
+	}
+	return s.netStatsResp, s.netStatsErr
+}
+
+func (s *mockSrv) DiskStats(_ context.Context, _ *emptypb.Empty) (*machine.DiskStatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.diskStatsResp, s.diskStatsErr
+}
+
+func (s *mockSrv) Read(req *machine.ReadRequest, srv machine.MachineService_ReadServer) error {
+	s.mu.Lock()
+	data, err := s.readData[req.GetPath()], s.readErr
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return srv.Send(&common.Data{Bytes: []byte(data)})
+}
+
+func (s *mockSrv) Processes(_ context.Context, _ *emptypb.Empty) (*machine.ProcessesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.processesResp, s.processesErr
+}
+
+func (s *mockSrv) Version(_ context.Context, _ *emptypb.Empty) (*machine.VersionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.versionResp, s.versionErr
+}
+
+func (s *mockSrv) Containers(_ context.Context, req *machine.ContainersRequest) (*machine.ContainersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.containersResp[req.GetNamespace()], s.containersErr
+}
+
+func (s *mockSrv) Dmesg(_ *machine.DmesgRequest, srv machine.MachineService_DmesgServer) error {
+	s.mu.Lock()
+	data, err := s.dmesgData, s.dmesgErr
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// Talos sends one message per Data frame.
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		if err := srv.Send(&common.Data{Bytes: []byte(line)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stateSrv serves mockSrv.resources over the COSI State API.
+type stateSrv struct {
+	cosiv1alpha1.UnimplementedStateServer
+	mock *mockSrv
+}
+
+func (s *stateSrv) Get(_ context.Context, req *cosiv1alpha1.GetRequest) (*cosiv1alpha1.GetResponse, error) {
+	s.mock.mu.Lock()
+	defer s.mock.mu.Unlock()
+	for _, r := range s.mock.resources {
+		md := r.Metadata()
+		if md.Namespace() == req.GetNamespace() && md.Type() == req.GetType() && md.ID() == req.GetId() {
+			pr, err := protobuf.FromResource(r)
+			if err != nil {
+				return nil, err
+			}
+			m, err := pr.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			return &cosiv1alpha1.GetResponse{Resource: m}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "resource %s(%s/%s) doesn't exist", req.GetType(), req.GetNamespace(), req.GetId())
+}
+
 // ---------------------------------------------------------------------------
 // TestMain — build binary, generate certs, start mock gRPC server
 // ---------------------------------------------------------------------------
@@ -190,6 +346,7 @@ func TestMain(m *testing.M) {
 	creds := credentials.NewTLS(serverTLS)
 	grpcServer := grpc.NewServer(grpc.Creds(creds))
 	machine.RegisterMachineServiceServer(grpcServer, mock)
+	cosiv1alpha1.RegisterStateServer(grpcServer, &stateSrv{mock: mock})
 	go grpcServer.Serve(lis) //nolint:errcheck
 
 	code := m.Run()
@@ -334,8 +491,17 @@ type runResult struct {
 }
 
 func run(t *testing.T, args ...string) runResult {
+	t.Helper()
+	return runEnv(t, nil, args...)
+}
+
+// runEnv runs the binary with env added to the test's environment.
+func runEnv(t *testing.T, env []string, args ...string) runResult {
 	t.Helper()
 	cmd := exec.Command(binaryPath, args...)
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -391,6 +557,19 @@ func assertNotContains(t *testing.T, res runResult, notWant ...string) {
 	}
 }
 
+// machineConfigResource returns cfg as a MachineConfig resource with the
+// given ID (config.ActiveID, config.PersistentID), served through COSI.
+func machineConfigResource(t *testing.T, id resource.ID, cfg string, updated time.Time) resource.Resource {
+	t.Helper()
+	p, err := configloader.NewFromBytes([]byte(cfg))
+	if err != nil {
+		t.Fatalf("configloader.NewFromBytes: %v", err)
+	}
+	r := config.NewMachineConfigWithID(p, id)
+	r.Metadata().SetUpdated(updated)
+	return r
+}
+
 // ---------------------------------------------------------------------------
 // Test: --help
 // ---------------------------------------------------------------------------
@@ -485,6 +664,24 @@ func TestE2E_Validation(t *testing.T) {
 		assertResult(t, res, 3, "TALOS MEMORY UNKNOWN", "Invalid critical threshold")
 	})
 
+	t.Run("V7 - cpu rejects duration suffix", func(t *testing.T) {
+		args := append(authArgs(), "cpu", "-w", "10m")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CPU UNKNOWN", `Invalid warning threshold "10m": expected Nagios range format`)
+	})
+
+	t.Run("V7 - processes rejects size suffix", func(t *testing.T) {
+		args := append(authArgs(), "processes", "-w", "1kB", "-c", "2kB")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS PROCESSES UNKNOWN", `Invalid warning threshold "1kB"`)
+	})
+
+	t.Run("V7 - uptime rejects size suffix", func(t *testing.T) {
+		args := append(authArgs(), "uptime", "-w", "@0:1GB")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS UPTIME UNKNOWN", "with optional duration suffix")
+	})
+
 	t.Run("V9 - services include and exclude", func(t *testing.T) {
 		args := append(authArgs(), "services", "--include", "apid", "--exclude", "etcd")
 		res := run(t, args...)
@@ -502,6 +699,186 @@ func TestE2E_Validation(t *testing.T) {
 		res := run(t, args...)
 		assertResult(t, res, 3, "TALOS DISK UNKNOWN", "Invalid --mount", "must be an absolute path")
 	})
+
+	t.Run("V12 - invalid exclude-mount not absolute", func(t *testing.T) {
+		args := append(authArgs(), "disk", "--all", "--exclude-mount", "run")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK UNKNOWN", "Invalid --exclude-mount", "must be an absolute path")
+	})
+
+	t.Run("V15 - disk all and mount", func(t *testing.T) {
+		args := append(authArgs(), "disk", "--all", "-m", "/var")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK UNKNOWN", "Cannot use both --all and --mount")
+	})
+
+	t.Run("V16 - disk unknown units", func(t *testing.T) {
+		args := append(authArgs(), "disk", "--units", "inodes")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK UNKNOWN", `Invalid --units "inodes"`)
+	})
+
+	t.Run("V16 - disk byte units without thresholds", func(t *testing.T) {
+		args := append(authArgs(), "disk", "--units", "bytes-free")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK UNKNOWN", "--units bytes-free requires both --warning and --critical")
+	})
+
+	t.Run("V7 - disk invalid size suffix", func(t *testing.T) {
+		args := append(authArgs(), "disk", "--units", "bytes-free", "-w", "10XB:", "-c", "5GiB:")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK UNKNOWN", `Invalid warning threshold "10XB:"`)
+	})
+
+	t.Run("V13 - sample duration exceeds timeout", func(t *testing.T) {
+		args := append(authArgs(), "-t", "5s", "cpu", "--sample-duration", "5s")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CPU UNKNOWN", "Invalid --sample-duration")
+	})
+
+	t.Run("V13 - disk-io sample duration exceeds timeout", func(t *testing.T) {
+		args := append(authArgs(), "-t", "2s", "disk-io", "--sample-duration", "3s")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DISK-IO UNKNOWN", "Invalid --sample-duration")
+	})
+
+	t.Run("V13 - network sample duration exceeds timeout", func(t *testing.T) {
+		args := append(authArgs(), "-t", "2s", "network", "--sample-duration", "3s")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS NETWORK UNKNOWN", "Invalid --sample-duration")
+	})
+
+	t.Run("V14 - network interface and exclude", func(t *testing.T) {
+		args := append(authArgs(), "network", "--interface", "eth0", "--exclude", "lo")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS NETWORK UNKNOWN", "Cannot use both --interface and --exclude")
+	})
+
+	t.Run("V7 - memory invalid swap threshold", func(t *testing.T) {
+		args := append(authArgs(), "memory", "--swap-warning", "abc")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS MEMORY UNKNOWN", `Invalid warning threshold "abc"`)
+	})
+
+	t.Run("V17 - mounts relative path", func(t *testing.T) {
+		args := append(authArgs(), "mounts", "--mount", "var")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS MOUNTS UNKNOWN", `Invalid --mount "var": must be an absolute path`)
+	})
+
+	t.Run("V17 - mounts glob pattern", func(t *testing.T) {
+		args := append(authArgs(), "mounts", "--mount", "/var/*")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS MOUNTS UNKNOWN", "glob patterns are not supported")
+	})
+
+	t.Run("V18 - pressure invalid kind", func(t *testing.T) {
+		args := append(authArgs(), "pressure", "--kind", "all")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS PRESSURE UNKNOWN", `Invalid --kind "all": must be one of some, full`)
+	})
+
+	t.Run("V18 - pressure invalid resource", func(t *testing.T) {
+		args := append(authArgs(), "pressure", "--resource", "irq")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS PRESSURE UNKNOWN", `Invalid --resource "irq": must be one of cpu, memory, io`)
+	})
+
+	t.Run("V7 - processes invalid D-state threshold", func(t *testing.T) {
+		args := append(authArgs(), "processes", "--dstate-warning", "abc")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS PROCESSES UNKNOWN", `Invalid warning threshold "abc"`)
+	})
+
+	t.Run("V19 - version invalid constraint", func(t *testing.T) {
+		args := append(authArgs(), "version", "--expect", ">=1.x")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS VERSION UNKNOWN", `Invalid --expect ">=1.x": expected a version constraint`)
+	})
+
+	t.Run("V19 - version invalid Kubernetes constraint", func(t *testing.T) {
+		args := append(authArgs(), "version", "--expect-kubernetes", "1.34.x")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS VERSION UNKNOWN", `Invalid --expect-kubernetes "1.34.x"`)
+	})
+
+	t.Run("V19 - version invalid minimum", func(t *testing.T) {
+		args := append(authArgs(), "version", "--min", ">=1.10")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS VERSION UNKNOWN", `Invalid --min ">=1.10": expected a version such as 1.11.0`)
+	})
+
+	t.Run("V20 - config-drift missing reference", func(t *testing.T) {
+		args := append(authArgs(), "config-drift")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONFIG-DRIFT UNKNOWN", "--reference is required")
+	})
+
+	t.Run("V20 - config-drift reference not found", func(t *testing.T) {
+		args := append(authArgs(), "config-drift", "--reference", "/nonexistent/controlplane.yaml")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONFIG-DRIFT UNKNOWN", "Cannot read --reference")
+	})
+
+	t.Run("V20 - config-drift empty ignore segment", func(t *testing.T) {
+		args := append(authArgs(), "config-drift", "--reference", caPath, "--ignore", "machine..token")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONFIG-DRIFT UNKNOWN", `Invalid --ignore "machine..token": expected a dotted config path`)
+	})
+
+	t.Run("V21 - containers malformed pod pattern", func(t *testing.T) {
+		args := append(authArgs(), "containers", "--require-pod", "kube-system/[coredns")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONTAINERS UNKNOWN", `Invalid --require-pod "kube-system/[coredns": malformed glob pattern`)
+	})
+
+	t.Run("V22 - dmesg unknown severity", func(t *testing.T) {
+		args := append(authArgs(), "dmesg", "--rule", "oom:warn:Killed process")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DMESG UNKNOWN", `Invalid --rule "oom:warn:Killed process": severity "warn" must be warning or critical`)
+	})
+
+	t.Run("V22 - dmesg no rules", func(t *testing.T) {
+		args := append(authArgs(), "dmesg", "--no-default-rules")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS DMESG UNKNOWN", "--no-default-rules requires at least one --rule")
+	})
+
+	t.Run("V11 - etcd cluster size zero", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--cluster-size", "0")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid --cluster-size "0": must be >= 1`)
+	})
+
+	t.Run("V11 - etcd deprecated min-members zero", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--min-members", "0")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid --min-members "0": must be >= 1`)
+	})
+
+	t.Run("V23 - etcd unknown units", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--units", "ratio")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid --units "ratio": must be one of bytes, percent`)
+	})
+
+	t.Run("V23 - etcd invalid quota", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--quota", "0")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid --quota "0": must be a positive size`)
+	})
+
+	t.Run("V7 - etcd term threshold with suffix", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--term-warning", "1h")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid warning threshold "1h"`)
+	})
+
+	t.Run("V7 - etcd size suffix with percent units", func(t *testing.T) {
+		args := append(authArgs(), "etcd", "--units", "percent", "-w", "~:1GB")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS ETCD UNKNOWN", `Invalid warning threshold "~:1GB"`)
+	})
 }
 
 // ---------------------------------------------------------------------------
@@ -661,6 +1038,29 @@ func TestE2E_Memory(t *testing.T) {
 		res := run(t, args...)
 		assertResult(t, res, 2, "TALOS MEMORY CRITICAL", "Memory usage 94.1%", "'memory_usage'=94.1;80;90;0;100")
 	})
+
+	t.Run("WARNING - swap usage", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.memoryResp = &machine.MemoryResponse{
+			Messages: []*machine.Memory{{
+				Meminfo: &machine.MemInfo{
+					Memtotal:     8388608,
+					Memavailable: 4194304, // kB; 50% used
+					Anonpages:    3145728,
+					Cached:       1048576,
+					Swaptotal:    2097152,
+					Swapfree:     786432, // kB; 62.5% swap used
+				},
+			}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "memory", "--swap-warning", "50", "--swap-critical", "90")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS MEMORY WARNING", "swap 62.5% (1.25 GB / 2.00 GB)",
+			"'swap_usage'=62.5;50;90;0;100", "anon 3.00 GB, cached 1.00 GB")
+	})
 }
 
 // ---------------------------------------------------------------------------
@@ -668,6 +1068,53 @@ func TestE2E_Memory(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestE2E_Disk(t *testing.T) {
+	t.Run("CRITICAL - all mounts, worst in summary", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.mountsResp = &machine.MountsResponse{
+			Messages: []*machine.Mounts{{
+				Stats: []*machine.MountStat{
+					{Filesystem: "/dev/sda4", MountedOn: "/", Size: 2000, Available: 1000},
+					{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 1000, Available: 50},
+					{Filesystem: "none", MountedOn: "/run", Size: 1000, Available: 0},
+					{Filesystem: "proc", MountedOn: "/proc"},
+				},
+			}},
+		}
+		mock.readData = map[string]string{
+			"/proc/mounts": "/dev/sda4 / xfs ro 0 0\n/dev/sda6 /var xfs rw 0 0\nnone /run tmpfs rw 0 0\nproc /proc proc rw 0 0\n",
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "disk", "--all", "--exclude-fstype", "tmpfs")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS DISK CRITICAL",
+			"1/2 mounts above threshold, worst: /var usage 95.0%",
+			"'disk_usage_root'=50;80;90;0;100", "'disk_usage_var'=95;80;90;0;100",
+			"/var: 95.0% (950 B / 1000 B), status=CRITICAL")
+		assertNotContains(t, res, "disk_usage_run", "disk_usage_proc")
+	})
+
+	t.Run("WARNING - bytes-free thresholds", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.mountsResp = &machine.MountsResponse{
+			Messages: []*machine.Mounts{{
+				Stats: []*machine.MountStat{
+					{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 20 << 30, Available: 8 << 30},
+				},
+			}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "disk", "--units", "bytes-free", "-w", "10GiB:", "-c", "5GiB:")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS DISK WARNING",
+			"/var 8.00 GB free, usage 60.0% (12.00 GB / 20.00 GB)",
+			"'disk_usage'=60;;;0;100",
+			"'disk_free'=8589934592B;10737418240:;5368709120:;0;21474836480")
+	})
+
 	t.Run("OK - var mount default", func(t *testing.T) {
 		mock.reset()
 		mock.mu.Lock()
@@ -925,7 +1372,7 @@ func TestE2E_Etcd(t *testing.T) {
 			"'etcd_dbsize'=45000000B")
 	})
 
-	t.Run("CRITICAL - member count below minimum", func(t *testing.T) {
+	t.Run("WARNING - voting member missing", func(t *testing.T) {
 		mock.reset()
 		mock.mu.Lock()
 		mock.etcdStatusResp = &machine.EtcdStatusResponse{
@@ -951,7 +1398,8 @@ func TestE2E_Etcd(t *testing.T) {
 
 		args := append(authArgs(), "etcd")
 		res := run(t, args...)
-		assertResult(t, res, 2, "TALOS ETCD CRITICAL", "Member count 2 below minimum 3")
+		assertResult(t, res, 1, "TALOS ETCD WARNING", "2/3 members, fault tolerance 0",
+			"'etcd_members'=2;3:;2:;0;")
 	})
 
 	t.Run("CRITICAL - NOSPACE alarm", func(t *testing.T) {
@@ -988,7 +1436,52 @@ func TestE2E_Etcd(t *testing.T) {
 		assertResult(t, res, 2, "TALOS ETCD CRITICAL", "Active alarm: NOSPACE")
 	})
 
-	t.Run("custom min-members", func(t *testing.T) {
+	t.Run("member errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			errors []string
+			code   int
+			want   string
+		}{
+			{"transient error is WARNING", []string{"etcdserver: request timed out"}, 1,
+				"TALOS ETCD WARNING - Leader, 3/3 members, DB 12.50 MB, member errors: etcdserver: request timed out"},
+			{"alarm error is CRITICAL", []string{"memberID:1234 alarm:NOSPACE"}, 2,
+				"TALOS ETCD CRITICAL - Member errors: memberID:1234 alarm:NOSPACE"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				mock.reset()
+				mock.mu.Lock()
+				mock.etcdStatusResp = &machine.EtcdStatusResponse{
+					Messages: []*machine.EtcdStatus{{
+						MemberStatus: &machine.EtcdMemberStatus{
+							MemberId: 1234, Leader: 1234,
+							DbSize: 13107200, DbSizeInUse: 8388608,
+							Errors: tc.errors,
+						},
+					}},
+				}
+				mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+					Messages: []*machine.EtcdMembers{{
+						Members: []*machine.EtcdMember{
+							{Id: 1, Hostname: "cp-1"},
+							{Id: 2, Hostname: "cp-2"},
+							{Id: 3, Hostname: "cp-3"},
+						},
+					}},
+				}
+				mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+					Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+				}
+				mock.mu.Unlock()
+
+				args := append(authArgs(), "etcd")
+				res := run(t, args...)
+				assertResult(t, res, tc.code, tc.want, "'etcd_errors'=1;;;0;")
+			})
+		}
+	})
+
+	t.Run("deprecated min-members sets the cluster size", func(t *testing.T) {
 		mock.reset()
 		mock.mu.Lock()
 		mock.etcdStatusResp = &machine.EtcdStatusResponse{
@@ -1018,40 +1511,317 @@ func TestE2E_Etcd(t *testing.T) {
 		args := append(authArgs(), "etcd", "--min-members", "5")
 		res := run(t, args...)
 		assertResult(t, res, 0, "TALOS ETCD OK", "5/5 members")
+		if !strings.Contains(res.stderr, "--min-members is deprecated") {
+			t.Errorf("stderr %q does not mention the deprecation", res.stderr)
+		}
 	})
-}
-
-// ---------------------------------------------------------------------------
-// Test: Load check via mock gRPC server
-// ---------------------------------------------------------------------------
 
-func TestE2E_Load(t *testing.T) {
-	t.Run("OK - explicit thresholds", func(t *testing.T) {
+	t.Run("learners and quorum", func(t *testing.T) {
 		mock.reset()
 		mock.mu.Lock()
-		mock.loadAvgResp = &machine.LoadAvgResponse{
-			Messages: []*machine.LoadAvg{{
-				Load1: 0.98, Load5: 1.23, Load15: 1.45,
+		mock.etcdStatusResp = &machine.EtcdStatusResponse{
+			Messages: []*machine.EtcdStatus{{
+				MemberStatus: &machine.EtcdMemberStatus{
+					MemberId: 1, Leader: 1,
+					DbSize: 13107200, DbSizeInUse: 8388608,
+				},
+			}},
+		}
+		mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+			Messages: []*machine.EtcdMembers{{
+				Members: []*machine.EtcdMember{
+					{Id: 1, Hostname: "cp-1", PeerUrls: []string{"https://10.0.0.1:2380"}},
+					{Id: 2, Hostname: "cp-2", PeerUrls: []string{"https://10.0.0.2:2380"}},
+					{Id: 3, Hostname: "cp-3", PeerUrls: []string{"https://10.0.0.3:2380"}},
+					{Id: 4, Hostname: "cp-4", PeerUrls: []string{"https://10.0.0.4:2380"}, IsLearner: true},
+				},
 			}},
 		}
+		mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+			Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+		}
 		mock.mu.Unlock()
 
-		args := append(authArgs(), "load", "-w", "4", "-c", "8")
-		res := run(t, args...)
-		assertResult(t, res, 0, "TALOS LOAD OK", "Load average (5m) 1.23",
-			"'load5'=1.23;4;8;0;")
+		res := run(t, append(authArgs(), "etcd")...)
+		assertResult(t, res, 0, "TALOS ETCD OK", "3/3 members (+1 learner)",
+			"cp-1 (id 1): leader, peer https://10.0.0.1:2380",
+			"cp-4 (id 4): learner, peer https://10.0.0.4:2380",
+			"'etcd_members'=3;3:;2:;0;", "'etcd_learners'=1;;;0;")
+
+		res = run(t, append(authArgs(), "etcd", "--cluster-size", "5")...)
+		assertResult(t, res, 1, "TALOS ETCD WARNING", "3/5 members (+1 learner), fault tolerance 0")
+
+		res = run(t, append(authArgs(), "etcd", "--cluster-size", "7")...)
+		assertResult(t, res, 2, "TALOS ETCD CRITICAL - Voting members 3 below quorum 4 of cluster size 7")
 	})
 
-	t.Run("WARNING - explicit thresholds", func(t *testing.T) {
+	t.Run("CRITICAL - quota percent caused by fragmentation", func(t *testing.T) {
 		mock.reset()
 		mock.mu.Lock()
-		mock.loadAvgResp = &machine.LoadAvgResponse{
-			Messages: []*machine.LoadAvg{{
-				Load1: 5.12, Load5: 4.56, Load15: 3.21,
-			}},
-		}
-		mock.mu.Unlock()
-
+		mock.etcdStatusResp = &machine.EtcdStatusResponse{
+			Messages: []*machine.EtcdStatus{{
+				MemberStatus: &machine.EtcdMemberStatus{
+					MemberId: 1234, Leader: 1234,
+					DbSize: 2040109465, DbSizeInUse: 536870912,
+				},
+			}},
+		}
+		mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+			Messages: []*machine.EtcdMembers{{
+				Members: []*machine.EtcdMember{
+					{Id: 1, Hostname: "cp-1"},
+					{Id: 2, Hostname: "cp-2"},
+					{Id: 3, Hostname: "cp-3"},
+				},
+			}},
+		}
+		mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+			Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "etcd", "--units", "percent")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS ETCD CRITICAL", "DB 1.90 GB (95.0% of 2.00 GB quota), critical threshold 90",
+			"defragment to reclaim 1.40 GB (talosctl etcd defrag)",
+			"'etcd_dbsize'=2040109465B;;;0;",
+			"'etcd_quota_usage'=95;80;90;0;100",
+			"'etcd_fragmentation'=73.7;;;0;100")
+	})
+
+	t.Run("WARNING - fragmentation threshold", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.etcdStatusResp = &machine.EtcdStatusResponse{
+			Messages: []*machine.EtcdStatus{{
+				MemberStatus: &machine.EtcdMemberStatus{
+					MemberId: 1234, Leader: 1234,
+					DbSize: 80000000, DbSizeInUse: 20000000,
+				},
+			}},
+		}
+		mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+			Messages: []*machine.EtcdMembers{{
+				Members: []*machine.EtcdMember{
+					{Id: 1, Hostname: "cp-1"},
+					{Id: 2, Hostname: "cp-2"},
+					{Id: 3, Hostname: "cp-3"},
+				},
+			}},
+		}
+		mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+			Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "etcd", "--frag-warning", "50", "--quota", "8GiB")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS ETCD WARNING", "75.0% fragmented, warning threshold 50",
+			"defragment to reclaim 57.22 MB",
+			"'etcd_quota_usage'=0.9;;;0;100",
+			"'etcd_fragmentation'=75;50;;0;100")
+	})
+
+	t.Run("raft lag and term changes", func(t *testing.T) {
+		stateFile := filepath.Join(t.TempDir(), "etcd.json")
+		setStatus := func(term, index, applied uint64) {
+			mock.reset()
+			mock.mu.Lock()
+			mock.etcdStatusResp = &machine.EtcdStatusResponse{
+				Messages: []*machine.EtcdStatus{{
+					MemberStatus: &machine.EtcdMemberStatus{
+						MemberId: 1234, Leader: 1234,
+						DbSize: 13107200, DbSizeInUse: 8388608,
+						RaftTerm: term, RaftIndex: index, RaftAppliedIndex: applied,
+					},
+				}},
+			}
+			mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+				Messages: []*machine.EtcdMembers{{
+					Members: []*machine.EtcdMember{
+						{Id: 1, Hostname: "cp-1"},
+						{Id: 2, Hostname: "cp-2"},
+						{Id: 3, Hostname: "cp-3"},
+					},
+				}},
+			}
+			mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+				Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+			}
+			mock.mu.Unlock()
+		}
+		args := append(authArgs(), "etcd", "--term-warning", "1", "--state-file", stateFile)
+
+		setStatus(4, 1200, 1200)
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS ETCD OK",
+			"'etcd_raft_lag'=0;1000;5000;0;",
+			"'etcd_raft_term'=4c;;;0;")
+
+		setStatus(7, 9000, 2500)
+		res = run(t, args...)
+		assertResult(t, res, 2, "TALOS ETCD CRITICAL",
+			"6500 raft entries not applied, critical threshold 5000",
+			"3 leader elections since last check (term 7), warning threshold 1",
+			"'etcd_raft_lag'=6500;1000;5000;0;",
+			"'etcd_raft_term'=7c;;;0;")
+	})
+
+	t.Run("unusable temp directory", func(t *testing.T) {
+		// A regular file as TMPDIR: the default state file cannot be created.
+		blocker := filepath.Join(t.TempDir(), "blocker")
+		if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		env := []string{"TMPDIR=" + blocker}
+
+		mock.reset()
+		mock.mu.Lock()
+		mock.etcdStatusResp = &machine.EtcdStatusResponse{
+			Messages: []*machine.EtcdStatus{{
+				MemberStatus: &machine.EtcdMemberStatus{
+					MemberId: 1234, Leader: 1234,
+					DbSize: 13107200, DbSizeInUse: 8388608, RaftTerm: 4,
+				},
+			}},
+		}
+		mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+			Messages: []*machine.EtcdMembers{{
+				Members: []*machine.EtcdMember{
+					{Id: 1, Hostname: "cp-1"},
+					{Id: 2, Hostname: "cp-2"},
+					{Id: 3, Hostname: "cp-3"},
+				},
+			}},
+		}
+		mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+			Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+		}
+		mock.mu.Unlock()
+
+		// Without --term-* no state file is used at all.
+		res := runEnv(t, env, append(authArgs(), "etcd")...)
+		assertResult(t, res, 0, "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB \n")
+
+		// With them the term check is skipped with a note.
+		res = runEnv(t, env, append(authArgs(), "etcd", "--term-warning", "1")...)
+		assertResult(t, res, 0, "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB, leader elections not tracked (cannot ",
+			"'etcd_raft_term'=4c;;;0;")
+	})
+
+	t.Run("cluster mode", func(t *testing.T) {
+		setCluster := func(nodes map[string]*machine.EtcdMemberStatus) {
+			mock.reset()
+			mock.mu.Lock()
+			mock.etcdStatusResp = &machine.EtcdStatusResponse{
+				Messages: []*machine.EtcdStatus{{
+					MemberStatus: &machine.EtcdMemberStatus{
+						MemberId: 1, Leader: 1,
+						DbSize: 13107200, DbSizeInUse: 8388608,
+					},
+				}},
+			}
+			mock.etcdStatusNodes = nodes
+			mock.etcdMemberResp = &machine.EtcdMemberListResponse{
+				Messages: []*machine.EtcdMembers{{
+					Members: []*machine.EtcdMember{
+						{Id: 1, Hostname: "cp-1", ClientUrls: []string{"https://10.0.0.1:2379"}},
+						{Id: 2, Hostname: "cp-2", ClientUrls: []string{"https://10.0.0.2:2379"}},
+						{Id: 3, Hostname: "cp-3", ClientUrls: []string{"https://10.0.0.3:2379"}},
+					},
+				}},
+			}
+			mock.etcdAlarmResp = &machine.EtcdAlarmListResponse{
+				Messages: []*machine.EtcdAlarm{{MemberAlarms: nil}},
+			}
+			mock.mu.Unlock()
+		}
+		member := func(id, leader uint64, inUse int64) *machine.EtcdMemberStatus {
+			return &machine.EtcdMemberStatus{
+				MemberId: id, Leader: leader,
+				DbSize: 13107200, DbSizeInUse: inUse,
+				RaftIndex: 800, RaftAppliedIndex: 800,
+			}
+		}
+		args := append(authArgs(), "etcd", "--cluster")
+
+		setCluster(map[string]*machine.EtcdMemberStatus{
+			"10.0.0.1": member(1, 1, 8388608),
+			"10.0.0.2": member(2, 1, 8388608),
+			"10.0.0.3": member(3, 1, 8388608),
+		})
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS ETCD OK", "3/3 members agree",
+			"'etcd_cluster_answering'=3;;;0;3",
+			"'etcd_cluster_size_spread'=0;20;;0;100",
+			"cp-2 (10.0.0.2): member 2, leader 1")
+
+		setCluster(map[string]*machine.EtcdMemberStatus{
+			"10.0.0.1": member(1, 1, 8388608),
+			"10.0.0.2": member(2, 1, 8388608),
+			"10.0.0.3": member(3, 3, 8388608),
+		})
+		res = run(t, args...)
+		assertResult(t, res, 2, "TALOS ETCD CRITICAL - Cluster inconsistent: cp-3 sees leader 3")
+
+		setCluster(map[string]*machine.EtcdMemberStatus{
+			"10.0.0.1": member(1, 1, 8388608),
+			"10.0.0.2": member(2, 1, 8388608),
+		})
+		res = run(t, args...)
+		assertResult(t, res, 1, "TALOS ETCD WARNING", "2/3 members agree, cp-3: connection refused",
+			"'etcd_cluster_answering'=2;;;0;3")
+
+		setCluster(map[string]*machine.EtcdMemberStatus{
+			"10.0.0.1": member(1, 1, 8388608),
+		})
+		res = run(t, args...)
+		assertResult(t, res, 2, "Cluster inconsistent: 1/3 voting members answering, below quorum 2",
+			"cp-2: connection refused, cp-3: connection refused",
+			"'etcd_cluster_answering'=1;;;0;3")
+
+		setCluster(map[string]*machine.EtcdMemberStatus{
+			"10.0.0.1": member(1, 1, 8388608),
+			"10.0.0.2": member(2, 1, 8388608),
+			"10.0.0.3": member(3, 1, 1048576),
+		})
+		res = run(t, args...)
+		assertResult(t, res, 1, "TALOS ETCD WARNING",
+			"in-use DB sizes differ by 87.5%, tolerance 20")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Load check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Load(t *testing.T) {
+	t.Run("OK - explicit thresholds", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.loadAvgResp = &machine.LoadAvgResponse{
+			Messages: []*machine.LoadAvg{{
+				Load1: 0.98, Load5: 1.23, Load15: 1.45,
+			}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "load", "-w", "4", "-c", "8")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS LOAD OK", "Load average (5m) 1.23",
+			"'load5'=1.23;4;8;0;")
+	})
+
+	t.Run("WARNING - explicit thresholds", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.loadAvgResp = &machine.LoadAvgResponse{
+			Messages: []*machine.LoadAvg{{
+				Load1: 5.12, Load5: 4.56, Load15: 3.21,
+			}},
+		}
+		mock.mu.Unlock()
+
 		args := append(authArgs(), "load", "-w", "4", "-c", "8")
 		res := run(t, args...)
 		assertResult(t, res, 1, "TALOS LOAD WARNING", "Load average (5m) 4.56",
@@ -1134,6 +1904,186 @@ func TestE2E_Load(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// Test: Uptime check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Uptime(t *testing.T) {
+	bootedAgo := func(d time.Duration) *machine.SystemStatResponse {
+		return &machine.SystemStatResponse{
+			Messages: []*machine.SystemStat{{
+				BootTime: uint64(time.Now().Add(-d).Unix()),
+			}},
+		}
+	}
+
+	t.Run("OK - default thresholds", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.systemStatResp = bootedAgo(50 * time.Hour)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "uptime")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS UPTIME OK", "Uptime 2d 2h", ";@0:3600;@0:600;0;")
+	})
+
+	t.Run("WARNING - rebooted within the hour", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.systemStatResp = bootedAgo(30 * time.Minute)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "uptime")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS UPTIME WARNING", "Uptime 30m", "'uptime'=")
+	})
+
+	t.Run("CRITICAL - custom threshold", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.systemStatResp = bootedAgo(20 * time.Minute)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "uptime", "-w", "@0:7200", "-c", "@0:1800")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS UPTIME CRITICAL", "Uptime 20m", ";@0:7200;@0:1800;0;")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Network check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Network(t *testing.T) {
+	netStats := func(devices ...*machine.NetDev) *machine.NetworkDeviceStatsResponse {
+		return &machine.NetworkDeviceStatsResponse{
+			Messages: []*machine.NetworkDeviceStats{{Devices: devices}},
+		}
+	}
+
+	t.Run("OK - counters only", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.netStatsResp = netStats(
+			&machine.NetDev{Name: "eth0", RxBytes: 1048576, TxBytes: 524288, RxPackets: 1000, TxPackets: 800, RxErrors: 250},
+			&machine.NetDev{Name: "lo", RxBytes: 4096, TxBytes: 4096},
+		)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "network")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS NETWORK OK", "2 interfaces, cumulative counters only",
+			"'eth0_rx_bytes'=1048576c;;;0;", "'eth0_rx_errors'=250c;;;0;")
+		assertNotContains(t, res, "_delta")
+	})
+
+	t.Run("OK - old errors over a sample", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.netStatsResp = netStats(
+			&machine.NetDev{Name: "eth0", RxErrors: 250},
+			&machine.NetDev{Name: "lo"},
+		)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "network", "--sample-duration", "100ms")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS NETWORK OK", "2/2 interfaces OK over 100ms",
+			"'eth0_rx_errors'=250c;;;0;", "'eth0_rx_errors_delta'=0;10;100;0;")
+	})
+
+	t.Run("CRITICAL - new errors above threshold", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.netStatsSamples = []*machine.NetworkDeviceStatsResponse{
+			netStats(&machine.NetDev{Name: "eth0", RxErrors: 50}, &machine.NetDev{Name: "eth1"}),
+			netStats(&machine.NetDev{Name: "eth0", RxErrors: 300}, &machine.NetDev{Name: "eth1"}),
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "network", "--sample-duration", "100ms")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS NETWORK CRITICAL", "1/2 interfaces with errors or drops: eth0 over 100ms",
+			"eth0: rx_errors=+250, tx_errors=+0, rx_dropped=+0, tx_dropped=+0, status=CRITICAL")
+	})
+
+	t.Run("WARNING - new drops with drop thresholds", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.netStatsSamples = []*machine.NetworkDeviceStatsResponse{
+			netStats(&machine.NetDev{Name: "eth0"}),
+			netStats(&machine.NetDev{Name: "eth0", TxDropped: 1500}),
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "network", "--sample-duration", "100ms",
+			"--drops-warning", "1000", "--drops-critical", "10000")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS NETWORK WARNING", "eth0", "'eth0_tx_dropped_delta'=1500;1000;10000;0;")
+	})
+
+	t.Run("OK - excluded interface ignored", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.netStatsSamples = []*machine.NetworkDeviceStatsResponse{
+			netStats(&machine.NetDev{Name: "eth0"}, &machine.NetDev{Name: "cni0"}),
+			netStats(&machine.NetDev{Name: "eth0"}, &machine.NetDev{Name: "cni0", RxErrors: 5000}),
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "network", "--sample-duration", "100ms", "--exclude", "cni0")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS NETWORK OK", "1/1 interfaces OK")
+		assertNotContains(t, res, "cni0")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Disk I/O check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_DiskIO(t *testing.T) {
+	t.Run("OK - counters only", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.diskStatsResp = &machine.DiskStatsResponse{
+			Messages: []*machine.DiskStats{{
+				Devices: []*machine.DiskStat{
+					{Name: "loop0", ReadCompleted: 10},
+					{Name: "sda", ReadCompleted: 1200, WriteCompleted: 3400, ReadSectors: 56000, WriteSectors: 78000, IoTimeMs: 9000},
+				},
+			}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "disk-io")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS DISK-IO OK", "1 devices, cumulative counters only",
+			"'sda_read_ops'=1200c;;;0;", "'sda_io_time_ms'=9000c;;;0;")
+		assertNotContains(t, res, "loop0")
+	})
+
+	t.Run("OK - two samples of an idle device", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.diskStatsResp = &machine.DiskStatsResponse{
+			Messages: []*machine.DiskStats{{
+				Devices: []*machine.DiskStat{
+					{Name: "sda", ReadCompleted: 1200, IoTimeMs: 9000},
+					{Name: "sdb", ReadCompleted: 10},
+				},
+			}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "disk-io", "--device", "sda", "--sample-duration", "100ms")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS DISK-IO OK", "1/1 devices OK, busiest: sda 0.0% over 100ms",
+			"'sda_util'=0;80;90;0;100")
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Test: Perfdata always present for successful checks
 // ---------------------------------------------------------------------------
@@ -1317,6 +2267,7 @@ func TestE2E_OutputFormat(t *testing.T) {
 		checkName string
 		setup     func()
 		args      []string
+		longText  bool // perfdata follows the long text instead of the summary
 	}{
 		{
 			name:      "cpu",
@@ -1405,7 +2356,8 @@ func TestE2E_OutputFormat(t *testing.T) {
 				}
 				mock.mu.Unlock()
 			},
-			args: []string{"etcd"},
+			args:     []string{"etcd"},
+			longText: true, // member roster
 		},
 		{
 			name:      "load",
@@ -1436,9 +2388,641 @@ func TestE2E_OutputFormat(t *testing.T) {
 			}
 
 			// Must contain pipe separator for perfdata.
-			if !strings.Contains(firstLine, " | ") {
-				t.Errorf("output missing perfdata pipe separator\ngot: %q", firstLine)
+			perfLine := firstLine
+			if tc.longText {
+				perfLine = res.stdout
+			}
+			if !strings.Contains(perfLine, " | ") {
+				t.Errorf("output missing perfdata pipe separator\ngot: %q", perfLine)
 			}
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Test: Mounts check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Mounts(t *testing.T) {
+	mountsResp := &machine.MountsResponse{
+		Messages: []*machine.Mounts{{
+			Stats: []*machine.MountStat{
+				{Filesystem: "/dev/sda5", MountedOn: "/system/state", Size: 1000, Available: 900},
+				{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 1000, Available: 500},
+			},
+		}},
+	}
+
+	t.Run("OK - default mounts read-write", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.mountsResp = mountsResp
+		mock.readData = map[string]string{
+			"/proc/mounts": "/dev/sda5 /system/state xfs rw,relatime 0 0\n/dev/sda6 /var xfs rw,relatime 0 0\n",
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "mounts")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS MOUNTS OK", "2/2 required mounts present and read-write",
+			"'mounts_readonly'=0;;;0;2")
+	})
+
+	t.Run("CRITICAL - var remounted read-only", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.mountsResp = mountsResp
+		mock.readData = map[string]string{
+			"/proc/mounts": "/dev/sda5 /system/state xfs rw,relatime 0 0\n/dev/sda6 /var xfs ro,relatime 0 0\n",
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "mounts", "--mount", "/var", "--mount", "/var/lib/etcd")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS MOUNTS CRITICAL",
+			"2/2 required mounts failing: /var read-only, /var/lib/etcd not mounted",
+			"/var: read-only (xfs on /dev/sda6, options ro,relatime), status=CRITICAL")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Pressure check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Pressure(t *testing.T) {
+	psi := func(io string) map[string]string {
+		return map[string]string{
+			"/proc/pressure/cpu":    "some avg10=1.50 avg60=1.20 avg300=0.90 total=1000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			"/proc/pressure/memory": "some avg10=0.00 avg60=0.00 avg300=0.00 total=0\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			"/proc/pressure/io":     io,
+		}
+	}
+
+	t.Run("OK - low pressure", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.readData = psi("some avg10=4.00 avg60=3.40 avg300=2.00 total=1000\nfull avg10=1.00 avg60=0.50 avg300=0.20 total=500\n")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "pressure")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS PRESSURE OK", "PSI some avg60: cpu 1.20%, memory 0.00%, io 3.40%",
+			"'io_some_avg60'=3.4;10;25;0;100")
+	})
+
+	t.Run("CRITICAL - io stalled", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.readData = psi("some avg10=55.00 avg60=32.10 avg300=12.00 total=1000\nfull avg10=40.00 avg60=20.00 avg300=8.00 total=500\n")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "pressure")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS PRESSURE CRITICAL", "1/3 resources under pressure (some avg60): io 32.10%",
+			"io: some avg10=55.00 avg60=32.10 avg300=12.00, full avg10=40.00 avg60=20.00 avg300=8.00, status=CRITICAL")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Processes check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Processes(t *testing.T) {
+	statResp := &machine.SystemStatResponse{
+		Messages: []*machine.SystemStat{{ProcessRunning: 2, ProcessBlocked: 0, ProcessCreated: 54321}},
+	}
+	procs := []*machine.ProcessInfo{
+		{Pid: 1, State: "S", Command: "init"},
+		{Pid: 200, Ppid: 1, State: "S", Command: "containerd-shim"},
+		{Pid: 300, Ppid: 1, State: "R", Command: "kubelet"},
+	}
+
+	t.Run("OK - no zombies", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.systemStatResp = statResp
+		mock.processesResp = &machine.ProcessesResponse{
+			Messages: []*machine.Process{{Processes: procs}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "processes")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS PROCESSES OK", "3 processes, 0 zombie, 0 in D state",
+			"'processes_zombie'=0;5;20;0;", "'processes_created'=54321c;;;0;")
+	})
+
+	t.Run("CRITICAL - zombie children of a shim", func(t *testing.T) {
+		zombies := append([]*machine.ProcessInfo{}, procs...)
+		for i := int32(0); i < 3; i++ {
+			zombies = append(zombies, &machine.ProcessInfo{Pid: 1000 + i, Ppid: 200, State: "Z", Command: "runc"})
+		}
+		mock.reset()
+		mock.mu.Lock()
+		mock.systemStatResp = statResp
+		mock.processesResp = &machine.ProcessesResponse{
+			Messages: []*machine.Process{{Processes: zombies}},
+		}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "processes", "-w", "1", "-c", "2")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS PROCESSES CRITICAL", "Process counts above threshold: 3 zombie (runc 3)",
+			"zombie runc: 3 processes, parents containerd-shim, status=CRITICAL")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Version check via mock gRPC server
+// ---------------------------------------------------------------------------
+
+func TestE2E_Version(t *testing.T) {
+	versionResp := func(tag string) *machine.VersionResponse {
+		return &machine.VersionResponse{
+			Messages: []*machine.Version{{
+				Version:  &machine.VersionInfo{Tag: tag, Sha: "4f6d4b2", GoVersion: "go1.24.9", Os: "linux", Arch: "amd64"},
+				Platform: &machine.PlatformInfo{Name: "metal", Mode: "metal"},
+			}},
+		}
+	}
+
+	t.Run("OK - within constraint", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.versionResp = versionResp("v1.11.6")
+		mock.readData = map[string]string{"/proc/sys/kernel/osrelease": "6.12.57-talos\n"}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "version", "--expect", ">=1.11.0 <1.12", "--expect-kernel", ">=6.12")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS VERSION OK",
+			"Talos v1.11.6, kernel 6.12.57-talos (expect >=1.11.0 <1.12, kernel >=6.12)")
+	})
+
+	t.Run("WARNING - node skipped by upgrade", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.versionResp = versionResp("v1.10.7")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "version", "--expect", ">=1.11.0 <1.12", "--min", "1.10")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS VERSION WARNING", "Talos v1.10.7 does not satisfy >=1.11.0 <1.12")
+	})
+
+	t.Run("CRITICAL - below floor", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.versionResp = versionResp("v1.9.5")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "version", "--expect", ">=1.11.0 <1.12", "--min", "1.10")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS VERSION CRITICAL", "Talos v1.9.5 below minimum 1.10.0",
+			"v1.9.5: sha 4f6d4b2")
+	})
+
+	t.Run("WARNING - API server behind kubelet", func(t *testing.T) {
+		kubelet := k8s.NewKubeletSpec(k8s.NamespaceName, k8s.KubeletID)
+		kubelet.TypedSpec().Image = "ghcr.io/siderolabs/kubelet:v1.34.1"
+		apiServer := k8s.NewStaticPod(k8s.NamespaceName, k8s.APIServerID)
+		apiServer.TypedSpec().Pod = map[string]any{
+			"spec": map[string]any{
+				"containers": []any{map[string]any{"name": "kube-apiserver", "image": "registry.k8s.io/kube-apiserver:v1.33.4"}},
+			},
+		}
+
+		mock.reset()
+		mock.mu.Lock()
+		mock.versionResp = versionResp("v1.11.6")
+		mock.resources = []resource.Resource{kubelet, apiServer}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "version", "--expect-kubernetes", ">=1.34.0 <1.35")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS VERSION WARNING", "kube-apiserver v1.33.4 does not satisfy >=1.34.0 <1.35",
+			"kubelet: ghcr.io/siderolabs/kubelet:v1.34.1")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Config drift check
+// ---------------------------------------------------------------------------
+
+func TestE2E_ConfigDrift(t *testing.T) {
+	const reference = `version: v1alpha1
+machine:
+  type: worker
+  token: template-token
+  network:
+    hostname: worker-template
+  install:
+    disk: /dev/sda
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.1
+cluster:
+  controlPlane:
+    endpoint: https://10.0.0.10:6443
+`
+	refPath := filepath.Join(t.TempDir(), "worker.yaml")
+	if err := os.WriteFile(refPath, []byte(reference), 0o600); err != nil {
+		t.Fatalf("write reference: %v", err)
+	}
+
+	nodeConfig := func(old, new string) []resource.Resource {
+		cfg := strings.Replace(reference, "template-token", "abcdef.0123456789abcdef", 1)
+		cfg = strings.Replace(cfg, "worker-template", "worker-1", 1)
+		return []resource.Resource{machineConfigResource(t, config.ActiveID, strings.Replace(cfg, old, new, 1), time.Now())}
+	}
+
+	t.Run("OK - secrets and hostname ignored", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = nodeConfig("", "")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "config-drift", "--reference", refPath)
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS CONFIG-DRIFT OK",
+			"Machine config matches reference (5 keys compared, 2 ignored)",
+			"'config_drift_keys'=0;0;;0;")
+	})
+
+	t.Run("WARNING - kubelet image drifted", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = nodeConfig("kubelet:v1.34.1", "kubelet:v1.33.4")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "config-drift", "--reference", refPath)
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS CONFIG-DRIFT WARNING",
+			"1 keys differ from reference: machine.kubelet.image", "machine.kubelet.image: changed")
+	})
+
+	t.Run("OK - drift under --ignore", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = nodeConfig("kubelet:v1.34.1", "kubelet:v1.33.4")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "config-drift", "--reference", refPath, "--ignore", "machine.kubelet")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS CONFIG-DRIFT OK", "4 keys compared, 3 ignored")
+	})
+
+	t.Run("UNKNOWN - maintenance mode", func(t *testing.T) {
+		mock.reset()
+
+		args := append(authArgs(), "config-drift", "--reference", refPath)
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONFIG-DRIFT UNKNOWN", "No active machine config")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Pending reboot check
+// ---------------------------------------------------------------------------
+
+func TestE2E_PendingReboot(t *testing.T) {
+	const applied = `version: v1alpha1
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.1
+cluster:
+  controlPlane:
+    endpoint: https://10.0.0.10:6443
+`
+	active := machineConfigResource(t, config.ActiveID, applied, time.Now().Add(-72*time.Hour))
+
+	t.Run("OK - nothing staged", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{active, machineConfigResource(t, config.PersistentID, applied, time.Now().Add(-72*time.Hour))}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "pending-reboot")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS PENDING-REBOOT OK", "No staged config or upgrade pending reboot",
+			"'pending_reboot_age'=0s;;;0;")
+	})
+
+	t.Run("WARNING - staged config", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{active, machineConfigResource(t, config.PersistentID,
+			strings.Replace(applied, "v1.34.1", "v1.34.2", 1), time.Now().Add(-3*time.Hour))}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "pending-reboot")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS PENDING-REBOOT WARNING", "Reboot pending for 3h 0m: machine config (1 keys differ)",
+			"machine.kubelet.image: changed")
+	})
+
+	t.Run("CRITICAL - staged upgrade past critical", func(t *testing.T) {
+		key := runtime.NewMetaKey(runtime.NamespaceName, runtime.MetaKeyTagToID(meta.StagedUpgradeImageRef))
+		key.TypedSpec().Value = "ghcr.io/siderolabs/installer:v1.12.0"
+		key.Metadata().SetUpdated(time.Now().Add(-50 * time.Hour))
+
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{active, key}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "pending-reboot", "-c", "1d")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS PENDING-REBOOT CRITICAL",
+			"Reboot pending for 2d 2h: upgrade to ghcr.io/siderolabs/installer:v1.12.0")
+	})
+
+	t.Run("UNKNOWN - maintenance mode", func(t *testing.T) {
+		mock.reset()
+
+		args := append(authArgs(), "pending-reboot")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS PENDING-REBOOT UNKNOWN", "No active machine config")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Machine status check via mock COSI state
+// ---------------------------------------------------------------------------
+
+func TestE2E_Machine(t *testing.T) {
+	status := func(stage runtime.MachineStage, ready bool, unmet ...runtime.UnmetCondition) resource.Resource {
+		ms := runtime.NewMachineStatus()
+		ms.TypedSpec().Stage = stage
+		ms.TypedSpec().Status.Ready = ready
+		ms.TypedSpec().Status.UnmetConditions = unmet
+		return ms
+	}
+
+	t.Run("OK - running and ready", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{status(runtime.MachineStageRunning, true)}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "machine")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS MACHINE OK", "Machine running and ready",
+			"'machine_ready'=1;;;0;1", "'machine_unmet_conditions'=0;;;0;")
+	})
+
+	t.Run("CRITICAL - running but not ready", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{status(runtime.MachineStageRunning, false,
+			runtime.UnmetCondition{Name: "services", Reason: "some services are not healthy: etcd"})}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "machine")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS MACHINE CRITICAL",
+			"Machine running but not ready: 1 unmet conditions (services)",
+			"services: some services are not healthy: etcd")
+	})
+
+	t.Run("CRITICAL - booting", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.resources = []resource.Resource{status(runtime.MachineStageBooting, false)}
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "machine")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS MACHINE CRITICAL", "Machine booting, not ready")
+	})
+
+	t.Run("UNKNOWN - no machine status", func(t *testing.T) {
+		mock.reset()
+
+		args := append(authArgs(), "machine")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS MACHINE UNKNOWN", "No machine status resource")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Kubernetes check
+// ---------------------------------------------------------------------------
+
+func TestE2E_Kubernetes(t *testing.T) {
+	services := func(kubeletState string, healthy bool) *machine.ServiceListResponse {
+		return &machine.ServiceListResponse{
+			Messages: []*machine.ServiceList{{
+				Services: []*machine.ServiceInfo{
+					{Id: "kubelet", State: kubeletState, Health: &machine.ServiceHealth{Healthy: healthy}},
+				},
+			}},
+		}
+	}
+	controlPlane := func(apiServerReady string) []resource.Resource {
+		nodename := k8s.NewNodename(k8s.NamespaceName, k8s.NodenameID)
+		nodename.TypedSpec().Nodename = "cp-1"
+		rs := []resource.Resource{nodename}
+		for _, id := range []string{k8s.APIServerID, k8s.ControllerManagerID, k8s.SchedulerID} {
+			pod := k8s.NewStaticPod(k8s.NamespaceName, id)
+			pod.TypedSpec().Pod = map[string]any{
+				"metadata": map[string]any{"name": id, "namespace": "kube-system"},
+			}
+
+			ready, restarts := "True", 0.0
+			if id == k8s.APIServerID {
+				ready = apiServerReady
+				if ready != "True" {
+					restarts = 7
+				}
+			}
+			status := k8s.NewStaticPodStatus(k8s.NamespaceName, "kube-system/"+id+"-cp-1")
+			status.TypedSpec().PodStatus = map[string]any{
+				"phase":             "Running",
+				"conditions":        []any{map[string]any{"type": "Ready", "status": ready}},
+				"containerStatuses": []any{map[string]any{"name": id, "restartCount": restarts}},
+			}
+			rs = append(rs, pod, status)
+		}
+		return rs
+	}
+
+	t.Run("OK - control plane node", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.serviceListResp = services("Running", true)
+		mock.resources = controlPlane("True")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "kubernetes")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS KUBERNETES OK", "kubelet healthy, 3/3 control plane pods ready",
+			"kube-scheduler: phase=Running, ready=True, restarts=0, status=OK",
+			"'kubernetes_components'=4;;;0;", "'kube_apiserver_restarts'=0c;;;0;")
+	})
+
+	t.Run("OK - worker node", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.serviceListResp = services("Running", true)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "kubernetes")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS KUBERNETES OK", "kubelet healthy (no control plane static pods)")
+	})
+
+	t.Run("CRITICAL - API server not ready", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.serviceListResp = services("Running", true)
+		mock.resources = controlPlane("False")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "kubernetes")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS KUBERNETES CRITICAL", "1/4 Kubernetes components unhealthy: kube-apiserver",
+			"kube-apiserver: phase=Running, ready=False, restarts=7, status=CRITICAL",
+			"'kube_apiserver_restarts'=7c;;;0;")
+	})
+
+	t.Run("CRITICAL - kubelet down", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.serviceListResp = services("Finished", false)
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "kubernetes")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS KUBERNETES CRITICAL", "1/1 Kubernetes components unhealthy: kubelet")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Containers check
+// ---------------------------------------------------------------------------
+
+func TestE2E_Containers(t *testing.T) {
+	node := func(etcdStatus, proxyStatus string) map[string]*machine.ContainersResponse {
+		return map[string]*machine.ContainersResponse{
+			"system": {Messages: []*machine.Container{{Containers: []*machine.ContainerInfo{
+				{Namespace: "system", Id: "apid", PodId: "apid", Name: "apid", Status: "RUNNING"},
+				{Namespace: "system", Id: "etcd", PodId: "etcd", Name: "etcd", Status: etcdStatus},
+			}}}},
+			"k8s.io": {Messages: []*machine.Container{{Containers: []*machine.ContainerInfo{
+				{Namespace: "k8s.io", Id: "kube-system/kube-proxy-7hq4z", PodId: "kube-system/kube-proxy-7hq4z", Status: "SANDBOX_READY"},
+				{Namespace: "k8s.io", Id: "kube-system/kube-proxy-7hq4z:kube-proxy:7a8b", PodId: "kube-system/kube-proxy-7hq4z",
+					Name: "kube-proxy", Image: "registry.k8s.io/kube-proxy:v1.34.1", Status: proxyStatus},
+			}}}},
+		}
+	}
+
+	t.Run("OK - all running", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.containersResp = node("RUNNING", "CONTAINER_RUNNING")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "containers", "--require", "etcd", "--require-pod", "kube-system/kube-proxy-*")
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS CONTAINERS OK", "3 containers running (2 system, 1 k8s.io)",
+			"'containers_running'=3;;;0;", "'containers_flagged'=0;0;;0;")
+	})
+
+	t.Run("WARNING - exited container", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.containersResp = node("RUNNING", "CONTAINER_EXITED")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "containers")
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS CONTAINERS WARNING",
+			"2 containers running (2 system, 0 k8s.io), 1 exited or unknown: kube-system/kube-proxy-7hq4z:kube-proxy:7a8b",
+			"k8s.io kube-system/kube-proxy-7hq4z:kube-proxy:7a8b: status=EXITED, image=registry.k8s.io/kube-proxy:v1.34.1")
+	})
+
+	t.Run("CRITICAL - required container stopped", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.containersResp = node("STOPPED", "CONTAINER_RUNNING")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "containers", "--require", "etcd")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS CONTAINERS CRITICAL", "required not running: etcd",
+			"etcd: required, not running")
+	})
+
+	t.Run("UNKNOWN - permission denied", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.containersErr = status.Error(codes.PermissionDenied, "not authorized")
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "containers")
+		res := run(t, args...)
+		assertResult(t, res, 3, "TALOS CONTAINERS UNKNOWN")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Test: Dmesg check
+// ---------------------------------------------------------------------------
+
+func TestE2E_Dmesg(t *testing.T) {
+	const boot = `kern:    info: [2026-10-16T08:00:00.100000Z]: Linux version 6.12.57-talos
+kern:    info: [2026-10-16T08:00:05.300000Z]: eth0: link up
+`
+	const oom = boot + `kern:     err: [2026-10-16T09:15:00.000000Z]: Out of memory: Killed process 4242 (java) total-vm:4194304kB
+`
+	stateFile := filepath.Join(t.TempDir(), "dmesg.json")
+
+	t.Run("OK - nothing matches", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.dmesgData = boot
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "dmesg", "--state-file", stateFile)
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS DMESG OK", "2 new kernel messages, none matched",
+			"'dmesg_new_messages'=2;;;0;", "'dmesg_oom'=0;;;0;")
+	})
+
+	t.Run("WARNING - OOM kill since last run", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.dmesgData = oom
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "dmesg", "--state-file", stateFile)
+		res := run(t, args...)
+		assertResult(t, res, 1, "TALOS DMESG WARNING", "1 of 1 new kernel messages matched: oom=1",
+			"[2026-10-16T09:15:00Z] oom: Out of memory: Killed process 4242 (java)", "'dmesg_oom'=1;;;0;")
+	})
+
+	t.Run("OK - OOM kill already reported", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.dmesgData = oom
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "dmesg", "--state-file", stateFile)
+		res := run(t, args...)
+		assertResult(t, res, 0, "TALOS DMESG OK", "0 new kernel messages, none matched")
+	})
+
+	t.Run("CRITICAL - custom rule", func(t *testing.T) {
+		mock.reset()
+		mock.mu.Lock()
+		mock.dmesgData = boot
+		mock.mu.Unlock()
+
+		args := append(authArgs(), "dmesg", "--state-file", filepath.Join(t.TempDir(), "dmesg.json"),
+			"--no-default-rules", "--rule", "link:critical:eth0: link (up|down)")
+		res := run(t, args...)
+		assertResult(t, res, 2, "TALOS DMESG CRITICAL", "1 of 2 new kernel messages matched: link=1", "'dmesg_link'=1;;;0;")
+		assertNotContains(t, res, "dmesg_oom")
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/DLAKE-IO/check-talos/internal/check"
 	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/semver"
 	"github.com/DLAKE-IO/check-talos/internal/talos"
 	"github.com/DLAKE-IO/check-talos/internal/threshold"
 	arg "github.com/alexflint/go-arg"
@@ -21,23 +24,53 @@ import (
 
 // CpuCmd defines flags for the cpu subcommand.
 type CpuCmd struct {
-	Warning  string `arg:"-w,--warning" default:"80" help:"Warning threshold (Nagios range, %)"`
-	Critical string `arg:"-c,--critical" default:"90" help:"Critical threshold (Nagios range, %)"`
+	Warning        string        `arg:"-w,--warning" default:"80" help:"Warning threshold (Nagios range, %)"`
+	Critical       string        `arg:"-c,--critical" default:"90" help:"Critical threshold (Nagios range, %)"`
+	SampleDuration time.Duration `arg:"--sample-duration" default:"0s" help:"Interval between two samples for delta-based usage (0 = single sample since boot)"`
+	PerCore        bool          `arg:"--per-core" help:"Evaluate thresholds against every core instead of the aggregate"`
 }
 
 // MemCmd defines flags for the memory subcommand.
 type MemCmd struct {
-	Warning  string `arg:"-w,--warning" default:"80" help:"Warning threshold (Nagios range, %)"`
-	Critical string `arg:"-c,--critical" default:"90" help:"Critical threshold (Nagios range, %)"`
+	Warning           string `arg:"-w,--warning" default:"80" help:"Warning threshold (Nagios range, %)"`
+	Critical          string `arg:"-c,--critical" default:"90" help:"Critical threshold (Nagios range, %)"`
+	SwapWarning       string `arg:"--swap-warning" help:"Warning threshold for swap usage (%, unset = not evaluated)"`
+	SwapCritical      string `arg:"--swap-critical" help:"Critical threshold for swap usage (%, unset = not evaluated)"`
+	CommitWarning     string `arg:"--commit-warning" help:"Warning threshold for Committed_AS as % of CommitLimit (unset = not evaluated)"`
+	CommitCritical    string `arg:"--commit-critical" help:"Critical threshold for Committed_AS as % of CommitLimit (unset = not evaluated)"`
+	DirtyWarning      string `arg:"--dirty-warning" help:"Warning threshold for dirty + writeback bytes (size suffixes allowed, unset = not evaluated)"`
+	DirtyCritical     string `arg:"--dirty-critical" help:"Critical threshold for dirty + writeback bytes (size suffixes allowed, unset = not evaluated)"`
+	HugepagesWarning  string `arg:"--hugepages-warning" help:"Warning threshold for hugepage usage (%, unset = not evaluated)"`
+	HugepagesCritical string `arg:"--hugepages-critical" help:"Critical threshold for hugepage usage (%, unset = not evaluated)"`
 }
 
 // DiskCmd defines flags for the disk subcommand.
 type DiskCmd struct {
-	Warning  string `arg:"-w,--warning" default:"80" help:"Warning threshold (Nagios range, %)"`
-	Critical string `arg:"-c,--critical" default:"90" help:"Critical threshold (Nagios range, %)"`
-	Mount    string `arg:"-m,--mount" default:"/var" help:"Mount point to check"`
+	Warning       string   `arg:"-w,--warning" help:"Warning threshold (Nagios range in --units, default 80 for percent)"`
+	Critical      string   `arg:"-c,--critical" help:"Critical threshold (Nagios range in --units, default 90 for percent)"`
+	Units         string   `arg:"--units" default:"percent" help:"Threshold units: percent, bytes-free or bytes-used (sizes accept suffixes such as 10GiB)"`
+	Mount         []string `arg:"-m,--mount,separate" help:"Mount point or glob pattern to check (repeatable, default /var)"`
+	All           bool     `arg:"--all" help:"Check every mount point with a non-zero size"`
+	ExcludeFSType []string `arg:"--exclude-fstype,separate" help:"Filesystem types to ignore, e.g. tmpfs, overlay (repeatable)"`
+	ExcludeMount  []string `arg:"--exclude-mount,separate" help:"Mount points or glob patterns to ignore (repeatable)"`
 }
 
+// Disk defaults applied in dispatch: the mount checked when neither --mount
+// nor --all is given, and the thresholds used with --units percent.
+const (
+	defaultDiskMount    = "/var"
+	defaultDiskWarning  = "80"
+	defaultDiskCritical = "90"
+)
+
+// Default etcd thresholds per --units.
+const (
+	defaultEtcdWarning         = "~:100MB"
+	defaultEtcdCritical        = "~:200MB"
+	defaultEtcdPercentWarning  = "80"
+	defaultEtcdPercentCritical = "90"
+)
+
 // ServicesCmd defines flags for the services subcommand.
 type ServicesCmd struct {
 	Exclude []string `arg:"--exclude,separate" help:"Service IDs to ignore (repeatable)"`
@@ -46,9 +79,21 @@ type ServicesCmd struct {
 
 // EtcdCmd defines flags for the etcd subcommand.
 type EtcdCmd struct {
-	Warning    string `arg:"-w,--warning" default:"~:100000000" help:"Warning threshold for DB size in bytes"`
-	Critical   string `arg:"-c,--critical" default:"~:200000000" help:"Critical threshold for DB size in bytes"`
-	MinMembers int    `arg:"--min-members" default:"3" help:"Minimum expected etcd member count"`
+	Warning       string `arg:"-w,--warning" help:"Warning threshold for DB size (bytes with size suffixes, or percent of --quota) [default: ~:100MB or 80]"`
+	Critical      string `arg:"-c,--critical" help:"Critical threshold for DB size (bytes with size suffixes, or percent of --quota) [default: ~:200MB or 90]"`
+	ClusterSize   int    `arg:"--cluster-size" default:"3" help:"Voting members the cluster is built for; below its quorum is CRITICAL, below the size WARNING"`
+	MinMembers    *int   `arg:"--min-members" help:"Deprecated alias of --cluster-size"`
+	Units         string `arg:"--units" default:"bytes" help:"Threshold units: bytes or percent (of --quota)"`
+	Quota         string `arg:"--quota" default:"2GiB" help:"etcd backend quota (quota-backend-bytes), size suffixes allowed"`
+	FragWarning   string `arg:"--frag-warning" help:"Warning threshold for DB fragmentation (percent)"`
+	FragCritical  string `arg:"--frag-critical" help:"Critical threshold for DB fragmentation (percent)"`
+	LagWarning    string `arg:"--lag-warning" default:"1000" help:"Warning threshold for raft entries committed but not applied"`
+	LagCritical   string `arg:"--lag-critical" default:"5000" help:"Critical threshold for raft entries committed but not applied"`
+	TermWarning   string `arg:"--term-warning" help:"Warning threshold for raft term changes (leader elections) since the last run"`
+	TermCritical  string `arg:"--term-critical" help:"Critical threshold for raft term changes (leader elections) since the last run"`
+	StateFile     string `arg:"--state-file" help:"File storing the raft term seen on the last run (default with --term-*: check-talos/etcd-<node>.json in the temp directory)"`
+	Cluster       bool   `arg:"--cluster" help:"Query every etcd member through apid and verify they agree on the leader"`
+	SizeTolerance string `arg:"--size-tolerance" default:"20" help:"Warning threshold for the spread of in-use DB sizes across members (percent, with --cluster)"`
 }
 
 // LoadCmd defines flags for the load subcommand.
@@ -58,15 +103,124 @@ type LoadCmd struct {
 	Period   string `arg:"--period" default:"5" help:"Load average period: 1, 5, or 15 (minutes)"`
 }
 
+// UptimeCmd defines flags for the uptime subcommand.
+type UptimeCmd struct {
+	Warning  string `arg:"-w,--warning" default:"@0:1h" help:"Warning threshold (Nagios range, uptime in seconds or with s/m/h/d suffix)"`
+	Critical string `arg:"-c,--critical" default:"@0:10m" help:"Critical threshold (Nagios range, uptime in seconds or with s/m/h/d suffix)"`
+}
+
+// NetworkCmd defines flags for the network subcommand.
+type NetworkCmd struct {
+	Warning        string        `arg:"-w,--warning" default:"10" help:"Warning threshold for new rx/tx errors per interface during --sample-duration"`
+	Critical       string        `arg:"-c,--critical" default:"100" help:"Critical threshold for new rx/tx errors per interface during --sample-duration"`
+	DropsWarning   string        `arg:"--drops-warning" help:"Warning threshold for new rx/tx drops per interface (unset = not evaluated)"`
+	DropsCritical  string        `arg:"--drops-critical" help:"Critical threshold for new rx/tx drops per interface (unset = not evaluated)"`
+	Interface      []string      `arg:"--interface,separate" help:"Only check these interfaces (repeatable)"`
+	Exclude        []string      `arg:"--exclude,separate" help:"Interfaces to ignore (repeatable)"`
+	SampleDuration time.Duration `arg:"--sample-duration" default:"0s" help:"Interval between two samples for error/drop evaluation (0 = counters only)"`
+}
+
+// DiskIOCmd defines flags for the disk-io subcommand.
+type DiskIOCmd struct {
+	Warning        string        `arg:"-w,--warning" default:"80" help:"Warning threshold for device utilization (Nagios range, %)"`
+	Critical       string        `arg:"-c,--critical" default:"90" help:"Critical threshold for device utilization (Nagios range, %)"`
+	Device         []string      `arg:"--device,separate" help:"Only check these block devices (repeatable)"`
+	SampleDuration time.Duration `arg:"--sample-duration" default:"0s" help:"Interval between two samples for IOPS/throughput/utilization (0 = counters only)"`
+}
+
+// MountsCmd defines flags for the mounts subcommand.
+type MountsCmd struct {
+	Mount []string `arg:"-m,--mount,separate" help:"Mount point that must be present and read-write (repeatable, default /var and /system/state)"`
+}
+
+// defaultRequiredMounts are the mount points checked by the mounts
+// subcommand when no --mount is given: EPHEMERAL and STATE.
+var defaultRequiredMounts = []string{"/var", "/system/state"}
+
+// PressureCmd defines flags for the pressure subcommand.
+type PressureCmd struct {
+	Warning  string   `arg:"-w,--warning" default:"10" help:"Warning threshold (Nagios range, % of time stalled)"`
+	Critical string   `arg:"-c,--critical" default:"25" help:"Critical threshold (Nagios range, % of time stalled)"`
+	Resource []string `arg:"--resource,separate" help:"PSI resource to check: cpu, memory or io (repeatable, default all)"`
+	Kind     string   `arg:"--kind" default:"some" help:"PSI line the thresholds apply to: some or full"`
+	Window   string   `arg:"--window" default:"avg60" help:"PSI average the thresholds apply to: avg10, avg60 or avg300"`
+}
+
+// ProcessesCmd defines flags for the processes subcommand.
+type ProcessesCmd struct {
+	Warning        string `arg:"-w,--warning" default:"5" help:"Warning threshold for zombie processes"`
+	Critical       string `arg:"-c,--critical" default:"20" help:"Critical threshold for zombie processes"`
+	DStateWarning  string `arg:"--dstate-warning" help:"Warning threshold for processes in D state (unset = not evaluated)"`
+	DStateCritical string `arg:"--dstate-critical" help:"Critical threshold for processes in D state (unset = not evaluated)"`
+	TotalWarning   string `arg:"--total-warning" help:"Warning threshold for the total process count (unset = not evaluated)"`
+	TotalCritical  string `arg:"--total-critical" help:"Critical threshold for the total process count (unset = not evaluated)"`
+}
+
+// VersionCmd defines flags for the version subcommand.
+type VersionCmd struct {
+	Expect           string `arg:"--expect" help:"Expected Talos version or constraint, e.g. \">=1.11.0 <1.12\" (WARNING when not satisfied)"`
+	Min              string `arg:"--min" help:"Minimum Talos version (CRITICAL when lower)"`
+	ExpectKernel     string `arg:"--expect-kernel" help:"Expected kernel release constraint, e.g. \">=6.12\" (WARNING when not satisfied)"`
+	ExpectKubernetes string `arg:"--expect-kubernetes" help:"Expected kubelet and control plane version constraint, e.g. \">=1.34.0 <1.35\" (WARNING when not satisfied)"`
+}
+
+// ConfigDriftCmd defines flags for the config-drift subcommand.
+type ConfigDriftCmd struct {
+	Warning   string   `arg:"-w,--warning" default:"0" help:"Warning threshold for the number of differing config keys"`
+	Critical  string   `arg:"-c,--critical" help:"Critical threshold for the number of differing config keys (unset = never CRITICAL)"`
+	Reference string   `arg:"--reference" help:"Path to the reference machine config YAML (required)"`
+	Ignore    []string `arg:"--ignore,separate" help:"Dotted config path to ignore, '*' matches one segment (repeatable)"`
+}
+
+// PendingRebootCmd defines flags for the pending-reboot subcommand.
+type PendingRebootCmd struct {
+	Warning  string `arg:"-w,--warning" help:"Warning threshold for how long a change has been pending (seconds or s/m/h/d suffix; unset = WARNING as soon as anything is pending)"`
+	Critical string `arg:"-c,--critical" help:"Critical threshold for how long a change has been pending (seconds or s/m/h/d suffix; unset = never CRITICAL)"`
+}
+
+// MachineCmd defines flags for the machine subcommand. It has none.
+type MachineCmd struct{}
+
+// KubernetesCmd defines flags for the kubernetes subcommand. It has none.
+type KubernetesCmd struct{}
+
+// ContainersCmd defines flags for the containers subcommand.
+type ContainersCmd struct {
+	Warning    string   `arg:"-w,--warning" default:"0" help:"Warning threshold for exited or unknown containers"`
+	Critical   string   `arg:"-c,--critical" help:"Critical threshold for exited or unknown containers (unset = never CRITICAL)"`
+	Require    []string `arg:"--require,separate" help:"Container ID that must be running, e.g. etcd (repeatable)"`
+	RequirePod []string `arg:"--require-pod,separate" help:"Pod ID pattern (namespace/name, glob) that must have a running container (repeatable)"`
+}
+
+// DmesgCmd defines flags for the dmesg subcommand.
+type DmesgCmd struct {
+	Rule           []string `arg:"--rule,separate" help:"Rule as name:warning|critical:regex; replaces a built-in rule of the same name (repeatable)"`
+	NoDefaultRules bool     `arg:"--no-default-rules" help:"Drop the built-in rules (oom, io_error, fs_error, hung_task, mce)"`
+	StateFile      string   `arg:"--state-file" help:"File storing the last kernel message seen (default: check-talos/dmesg-<node>.json in the temp directory)"`
+}
+
 // Args holds all CLI flags and subcommand pointers for check-talos.
 // When a subcommand pointer is non-nil, that check was selected.
 type Args struct {
-	Cpu      *CpuCmd      `arg:"subcommand:cpu" help:"Check CPU usage"`
-	Mem      *MemCmd      `arg:"subcommand:memory" help:"Check memory usage"`
-	Disk     *DiskCmd     `arg:"subcommand:disk" help:"Check disk usage"`
-	Services *ServicesCmd `arg:"subcommand:services" help:"Check Talos system service health"`
-	Etcd     *EtcdCmd     `arg:"subcommand:etcd" help:"Check etcd cluster health"`
-	Load     *LoadCmd     `arg:"subcommand:load" help:"Check load average"`
+	Cpu           *CpuCmd           `arg:"subcommand:cpu" help:"Check CPU usage"`
+	Mem           *MemCmd           `arg:"subcommand:memory" help:"Check memory usage"`
+	Disk          *DiskCmd          `arg:"subcommand:disk" help:"Check disk usage"`
+	Services      *ServicesCmd      `arg:"subcommand:services" help:"Check Talos system service health"`
+	Etcd          *EtcdCmd          `arg:"subcommand:etcd" help:"Check etcd cluster health"`
+	Load          *LoadCmd          `arg:"subcommand:load" help:"Check load average"`
+	Uptime        *UptimeCmd        `arg:"subcommand:uptime" help:"Check uptime (detect recent reboots)"`
+	Network       *NetworkCmd       `arg:"subcommand:network" help:"Check network interface errors and drops"`
+	DiskIO        *DiskIOCmd        `arg:"subcommand:disk-io" help:"Check block device I/O utilization"`
+	Mounts        *MountsCmd        `arg:"subcommand:mounts" help:"Check that required mounts are present and read-write"`
+	Pressure      *PressureCmd      `arg:"subcommand:pressure" help:"Check CPU, memory and I/O pressure stall information"`
+	Processes     *ProcessesCmd     `arg:"subcommand:processes" help:"Check zombie, D-state and total process counts"`
+	Version       *VersionCmd       `arg:"subcommand:version" help:"Check the Talos version against an expected version"`
+	ConfigDrift   *ConfigDriftCmd   `arg:"subcommand:config-drift" help:"Check the machine config against a reference config"`
+	PendingReboot *PendingRebootCmd `arg:"subcommand:pending-reboot" help:"Check for a staged config or upgrade waiting for a reboot"`
+	Machine       *MachineCmd       `arg:"subcommand:machine" help:"Check the Talos machine stage and readiness"`
+	Kubernetes    *KubernetesCmd    `arg:"subcommand:kubernetes" help:"Check the kubelet and control plane static pods"`
+	Containers    *ContainersCmd    `arg:"subcommand:containers" help:"Check system and Kubernetes containers"`
+	Dmesg         *DmesgCmd         `arg:"subcommand:dmesg" help:"Check kernel messages for OOM kills, I/O and filesystem errors"`
 
 	Endpoint string        `arg:"-e,--talos-endpoint" help:"Talos API endpoint (host:port)"`
 	CA       string        `arg:"--talos-ca" help:"Path to Talos CA certificate"`
@@ -112,7 +266,7 @@ func main() {
 
 	// V1: Exactly one subcommand must be specified.
 	if parser.Subcommand() == nil {
-		plugin.ServiceOutput = "TALOS UNKNOWN - No check specified. Usage: check-talos <cpu|memory|disk|services|etcd|load> [flags]"
+		plugin.ServiceOutput = "TALOS UNKNOWN - No check specified. Usage: check-talos <cpu|memory|disk|services|etcd|load|uptime|network|disk-io|mounts|pressure|processes|version|config-drift|pending-reboot|machine|kubernetes|containers|dmesg> [flags]"
 		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 		return
 	}
@@ -151,17 +305,125 @@ func main() {
 	var chk check.Check
 	switch {
 	case args.Cpu != nil:
-		chk, err = check.NewCPUCheck(args.Cpu.Warning, args.Cpu.Critical)
+		chk, err = check.NewCPUCheck(args.Cpu.Warning, args.Cpu.Critical, args.Cpu.SampleDuration, args.Cpu.PerCore)
 	case args.Mem != nil:
-		chk, err = check.NewMemoryCheck(args.Mem.Warning, args.Mem.Critical)
+		chk, err = check.NewMemoryCheck(args.Mem.Warning, args.Mem.Critical, check.MemoryOptions{
+			SwapWarning:       args.Mem.SwapWarning,
+			SwapCritical:      args.Mem.SwapCritical,
+			CommitWarning:     args.Mem.CommitWarning,
+			CommitCritical:    args.Mem.CommitCritical,
+			DirtyWarning:      args.Mem.DirtyWarning,
+			DirtyCritical:     args.Mem.DirtyCritical,
+			HugepagesWarning:  args.Mem.HugepagesWarning,
+			HugepagesCritical: args.Mem.HugepagesCritical,
+		})
 	case args.Disk != nil:
-		chk, err = check.NewDiskCheck(args.Disk.Warning, args.Disk.Critical, args.Disk.Mount)
+		mounts := args.Disk.Mount
+		if !args.Disk.All && len(mounts) == 0 {
+			mounts = []string{defaultDiskMount}
+		}
+		warn, crit := args.Disk.Warning, args.Disk.Critical
+		if args.Disk.Units == check.DiskUnitsPercent {
+			if warn == "" {
+				warn = defaultDiskWarning
+			}
+			if crit == "" {
+				crit = defaultDiskCritical
+			}
+		}
+		chk, err = check.NewDiskCheck(warn, crit, args.Disk.Units, mounts,
+			args.Disk.All, args.Disk.ExcludeFSType, args.Disk.ExcludeMount)
 	case args.Services != nil:
 		chk, err = check.NewServicesCheck(args.Services.Include, args.Services.Exclude)
 	case args.Etcd != nil:
-		chk, err = check.NewEtcdCheck(args.Etcd.Warning, args.Etcd.Critical, args.Etcd.MinMembers)
+		warn, crit := args.Etcd.Warning, args.Etcd.Critical
+		defWarn, defCrit := defaultEtcdWarning, defaultEtcdCritical
+		if args.Etcd.Units == check.EtcdUnitsPercent {
+			defWarn, defCrit = defaultEtcdPercentWarning, defaultEtcdPercentCritical
+		}
+		if warn == "" {
+			warn = defWarn
+		}
+		if crit == "" {
+			crit = defCrit
+		}
+		// The raft term is only kept when elections are evaluated.
+		stateFile := args.Etcd.StateFile
+		if stateFile == "" && (args.Etcd.TermWarning != "" || args.Etcd.TermCritical != "") {
+			stateFile = defaultStateFile(&args, "etcd")
+		}
+		clusterSize := args.Etcd.ClusterSize
+		if args.Etcd.MinMembers != nil {
+			clusterSize = *args.Etcd.MinMembers
+		}
+		chk, err = check.NewEtcdCheck(warn, crit, clusterSize, check.EtcdOptions{
+			Units:         args.Etcd.Units,
+			Quota:         args.Etcd.Quota,
+			FragWarning:   args.Etcd.FragWarning,
+			FragCritical:  args.Etcd.FragCritical,
+			LagWarning:    args.Etcd.LagWarning,
+			LagCritical:   args.Etcd.LagCritical,
+			TermWarning:   args.Etcd.TermWarning,
+			TermCritical:  args.Etcd.TermCritical,
+			StateFile:     stateFile,
+			Cluster:       args.Etcd.Cluster,
+			SizeTolerance: args.Etcd.SizeTolerance,
+		})
 	case args.Load != nil:
 		chk, err = check.NewLoadCheck(args.Load.Warning, args.Load.Critical, args.Load.Period)
+	case args.Uptime != nil:
+		chk, err = check.NewUptimeCheck(args.Uptime.Warning, args.Uptime.Critical)
+	case args.Network != nil:
+		chk, err = check.NewNetworkCheck(args.Network.Warning, args.Network.Critical,
+			args.Network.DropsWarning, args.Network.DropsCritical,
+			args.Network.Interface, args.Network.Exclude, args.Network.SampleDuration)
+	case args.DiskIO != nil:
+		chk, err = check.NewDiskIOCheck(args.DiskIO.Warning, args.DiskIO.Critical,
+			args.DiskIO.Device, args.DiskIO.SampleDuration)
+	case args.Mounts != nil:
+		mounts := args.Mounts.Mount
+		if len(mounts) == 0 {
+			mounts = defaultRequiredMounts
+		}
+		chk, err = check.NewMountsCheck(mounts)
+	case args.Pressure != nil:
+		resources := args.Pressure.Resource
+		if len(resources) == 0 {
+			resources = check.PressureResources
+		}
+		chk, err = check.NewPressureCheck(args.Pressure.Warning, args.Pressure.Critical,
+			resources, args.Pressure.Kind, args.Pressure.Window)
+	case args.Processes != nil:
+		chk, err = check.NewProcessesCheck(args.Processes.Warning, args.Processes.Critical, check.ProcessesOptions{
+			DStateWarning:  args.Processes.DStateWarning,
+			DStateCritical: args.Processes.DStateCritical,
+			TotalWarning:   args.Processes.TotalWarning,
+			TotalCritical:  args.Processes.TotalCritical,
+		})
+	case args.Version != nil:
+		chk, err = check.NewVersionCheck(args.Version.Expect, args.Version.Min, args.Version.ExpectKernel, args.Version.ExpectKubernetes)
+	case args.ConfigDrift != nil:
+		var reference []byte
+		reference, err = os.ReadFile(args.ConfigDrift.Reference)
+		if err == nil {
+			chk, err = check.NewConfigDriftCheck(args.ConfigDrift.Warning, args.ConfigDrift.Critical,
+				reference, args.ConfigDrift.Ignore)
+		}
+	case args.PendingReboot != nil:
+		chk, err = check.NewPendingRebootCheck(args.PendingReboot.Warning, args.PendingReboot.Critical)
+	case args.Machine != nil:
+		chk, err = check.NewMachineCheck()
+	case args.Kubernetes != nil:
+		chk, err = check.NewKubernetesCheck()
+	case args.Containers != nil:
+		chk, err = check.NewContainersCheck(args.Containers.Warning, args.Containers.Critical,
+			args.Containers.Require, args.Containers.RequirePod)
+	case args.Dmesg != nil:
+		stateFile := args.Dmesg.StateFile
+		if stateFile == "" {
+			stateFile = defaultStateFile(&args, "dmesg")
+		}
+		chk, err = check.NewDmesgCheck(args.Dmesg.Rule, args.Dmesg.NoDefaultRules, stateFile)
 	}
 	if err != nil {
 		plugin.ServiceOutput = fmt.Sprintf("TALOS %s UNKNOWN - %s", checkName, err)
@@ -196,12 +458,38 @@ func resolveCheckName(args *Args) string {
 		return "ETCD"
 	case args.Load != nil:
 		return "LOAD"
+	case args.Uptime != nil:
+		return "UPTIME"
+	case args.Network != nil:
+		return "NETWORK"
+	case args.DiskIO != nil:
+		return "DISK-IO"
+	case args.Mounts != nil:
+		return "MOUNTS"
+	case args.Pressure != nil:
+		return "PRESSURE"
+	case args.Processes != nil:
+		return "PROCESSES"
+	case args.Version != nil:
+		return "VERSION"
+	case args.ConfigDrift != nil:
+		return "CONFIG-DRIFT"
+	case args.PendingReboot != nil:
+		return "PENDING-REBOOT"
+	case args.Machine != nil:
+		return "MACHINE"
+	case args.Kubernetes != nil:
+		return "KUBERNETES"
+	case args.Containers != nil:
+		return "CONTAINERS"
+	case args.Dmesg != nil:
+		return "DMESG"
 	default:
 		return "UNKNOWN"
 	}
 }
 
-// validate implements validation rules V2–V12 from DESIGN.md Section 2.5.
+// validate implements validation rules V2–V23 from DESIGN.md Section 2.5.
 // V1 (subcommand presence) is checked before this function is called.
 // Validation stops at the first failure; errors are not accumulated.
 func validate(args *Args) error {
@@ -250,29 +538,98 @@ func validate(args *Args) error {
 		return fmt.Errorf("Invalid timeout %q: must be between 1s and 120s", args.Timeout)
 	}
 
-	// V7–V12: Subcommand-specific validation.
+	// V7–V23: Subcommand-specific validation.
 	switch {
 	case args.Cpu != nil:
-		return validateThresholds(args.Cpu.Warning, args.Cpu.Critical)
+		if err := validateSampleDuration(args.Cpu.SampleDuration, args.Timeout); err != nil {
+			return err
+		}
+		return validateThresholds(args.Cpu.Warning, args.Cpu.Critical, threshold.UnitNone)
 	case args.Mem != nil:
-		return validateThresholds(args.Mem.Warning, args.Mem.Critical)
+		if err := validateThresholds(args.Mem.Warning, args.Mem.Critical, threshold.UnitNone); err != nil {
+			return err
+		}
+		// Breakdown thresholds are optional (not evaluated when unset).
+		for _, pair := range []struct {
+			warn, crit string
+			unit       threshold.Unit
+		}{
+			{args.Mem.SwapWarning, args.Mem.SwapCritical, threshold.UnitNone},
+			{args.Mem.CommitWarning, args.Mem.CommitCritical, threshold.UnitNone},
+			{args.Mem.DirtyWarning, args.Mem.DirtyCritical, threshold.UnitBytes},
+			{args.Mem.HugepagesWarning, args.Mem.HugepagesCritical, threshold.UnitNone},
+		} {
+			if err := validateOptionalThresholds(pair.warn, pair.crit, pair.unit); err != nil {
+				return err
+			}
+		}
 	case args.Disk != nil:
-		// V12: --mount must be an absolute path.
-		if args.Disk.Mount == "" || args.Disk.Mount[0] != '/' {
-			return fmt.Errorf("Invalid --mount %q: must be an absolute path", args.Disk.Mount)
+		// V15: --all and --mount are mutually exclusive.
+		if args.Disk.All && len(args.Disk.Mount) > 0 {
+			return fmt.Errorf("Cannot use both --all and --mount")
+		}
+		// V12: --mount and --exclude-mount must be absolute paths or glob patterns.
+		if err := validateMountPatterns("--mount", args.Disk.Mount); err != nil {
+			return err
+		}
+		if err := validateMountPatterns("--exclude-mount", args.Disk.ExcludeMount); err != nil {
+			return err
+		}
+		// V16: --units must be known; byte units have no default thresholds.
+		switch args.Disk.Units {
+		case check.DiskUnitsPercent:
+			return validateOptionalThresholds(args.Disk.Warning, args.Disk.Critical, threshold.UnitNone)
+		case check.DiskUnitsBytesFree, check.DiskUnitsBytesUsed:
+			if args.Disk.Warning == "" || args.Disk.Critical == "" {
+				return fmt.Errorf("--units %s requires both --warning and --critical", args.Disk.Units)
+			}
+			return validateThresholds(args.Disk.Warning, args.Disk.Critical, threshold.UnitBytes)
+		default:
+			return fmt.Errorf("Invalid --units %q: must be percent, bytes-free or bytes-used", args.Disk.Units)
 		}
-		return validateThresholds(args.Disk.Warning, args.Disk.Critical)
 	case args.Services != nil:
 		// V9: --include and --exclude are mutually exclusive.
 		if len(args.Services.Include) > 0 && len(args.Services.Exclude) > 0 {
 			return fmt.Errorf("Cannot use both --include and --exclude")
 		}
 	case args.Etcd != nil:
-		// V11: --min-members must be >= 1.
-		if args.Etcd.MinMembers < 1 {
-			return fmt.Errorf("Invalid --min-members %q: must be >= 1", fmt.Sprintf("%d", args.Etcd.MinMembers))
+		// V11: --cluster-size (or its old name --min-members) must be >= 1.
+		if args.Etcd.ClusterSize < 1 {
+			return fmt.Errorf("Invalid --cluster-size %q: must be >= 1", fmt.Sprintf("%d", args.Etcd.ClusterSize))
+		}
+		if args.Etcd.MinMembers != nil {
+			if *args.Etcd.MinMembers < 1 {
+				return fmt.Errorf("Invalid --min-members %q: must be >= 1", fmt.Sprintf("%d", *args.Etcd.MinMembers))
+			}
+			fmt.Fprintln(os.Stderr, "Warning: --min-members is deprecated, use --cluster-size")
+		}
+		// V23: --units must be known and --quota a positive size.
+		if err := validateChoice("--units", args.Etcd.Units,
+			[]string{check.EtcdUnitsBytes, check.EtcdUnitsPercent}); err != nil {
+			return err
+		}
+		if q, err := threshold.ParseValue(args.Etcd.Quota, threshold.UnitBytes); err != nil || q < 1 {
+			return fmt.Errorf("Invalid --quota %q: must be a positive size", args.Etcd.Quota)
+		}
+		unit := threshold.UnitBytes
+		if args.Etcd.Units == check.EtcdUnitsPercent {
+			unit = threshold.UnitNone
+		}
+		if err := validateOptionalThresholds(args.Etcd.Warning, args.Etcd.Critical, unit); err != nil {
+			return err
+		}
+		// Fragmentation, raft and size tolerance thresholds are optional (not
+		// evaluated when unset); the size tolerance is warning-only.
+		for _, pair := range []struct{ warn, crit string }{
+			{args.Etcd.FragWarning, args.Etcd.FragCritical},
+			{args.Etcd.LagWarning, args.Etcd.LagCritical},
+			{args.Etcd.TermWarning, args.Etcd.TermCritical},
+			{args.Etcd.SizeTolerance, ""},
+		} {
+			if err := validateOptionalThresholds(pair.warn, pair.crit, threshold.UnitNone); err != nil {
+				return err
+			}
 		}
-		return validateThresholds(args.Etcd.Warning, args.Etcd.Critical)
 	case args.Load != nil:
 		// V10: --period must be 1, 5, or 15.
 		switch args.Load.Period {
@@ -281,22 +638,183 @@ func validate(args *Args) error {
 			return fmt.Errorf("Invalid --period %q: must be 1, 5, or 15", args.Load.Period)
 		}
 		// Load thresholds are optional (auto-computed at runtime from CPU count).
-		return validateOptionalThresholds(args.Load.Warning, args.Load.Critical)
+		return validateOptionalThresholds(args.Load.Warning, args.Load.Critical, threshold.UnitNone)
+	case args.Uptime != nil:
+		return validateThresholds(args.Uptime.Warning, args.Uptime.Critical, threshold.UnitSeconds)
+	case args.Network != nil:
+		// V14: --interface and --exclude are mutually exclusive.
+		if len(args.Network.Interface) > 0 && len(args.Network.Exclude) > 0 {
+			return fmt.Errorf("Cannot use both --interface and --exclude")
+		}
+		if err := validateSampleDuration(args.Network.SampleDuration, args.Timeout); err != nil {
+			return err
+		}
+		if err := validateThresholds(args.Network.Warning, args.Network.Critical, threshold.UnitNone); err != nil {
+			return err
+		}
+		// Drop thresholds are optional (drops are not evaluated when unset).
+		return validateOptionalThresholds(args.Network.DropsWarning, args.Network.DropsCritical, threshold.UnitNone)
+	case args.DiskIO != nil:
+		if err := validateSampleDuration(args.DiskIO.SampleDuration, args.Timeout); err != nil {
+			return err
+		}
+		return validateThresholds(args.DiskIO.Warning, args.DiskIO.Critical, threshold.UnitNone)
+	case args.Mounts != nil:
+		// V17: required mounts must be literal absolute paths.
+		for _, m := range args.Mounts.Mount {
+			if m == "" || m[0] != '/' {
+				return fmt.Errorf("Invalid --mount %q: must be an absolute path", m)
+			}
+			if strings.ContainsAny(m, `*?[\`) {
+				return fmt.Errorf("Invalid --mount %q: glob patterns are not supported", m)
+			}
+		}
+	case args.Pressure != nil:
+		// V18: --resource, --kind and --window must be known PSI names.
+		for _, r := range args.Pressure.Resource {
+			if err := validateChoice("--resource", r, check.PressureResources); err != nil {
+				return err
+			}
+		}
+		if err := validateChoice("--kind", args.Pressure.Kind, check.PressureKinds); err != nil {
+			return err
+		}
+		if err := validateChoice("--window", args.Pressure.Window, check.PressureWindows); err != nil {
+			return err
+		}
+		return validateThresholds(args.Pressure.Warning, args.Pressure.Critical, threshold.UnitNone)
+	case args.Processes != nil:
+		if err := validateThresholds(args.Processes.Warning, args.Processes.Critical, threshold.UnitNone); err != nil {
+			return err
+		}
+		// D-state and total thresholds are optional (not evaluated when unset).
+		if err := validateOptionalThresholds(args.Processes.DStateWarning, args.Processes.DStateCritical, threshold.UnitNone); err != nil {
+			return err
+		}
+		return validateOptionalThresholds(args.Processes.TotalWarning, args.Processes.TotalCritical, threshold.UnitNone)
+	case args.Version != nil:
+		// V19: --expect, --expect-kernel and --expect-kubernetes must be
+		// version constraints, --min a version.
+		for _, c := range [][2]string{
+			{"--expect", args.Version.Expect},
+			{"--expect-kernel", args.Version.ExpectKernel},
+			{"--expect-kubernetes", args.Version.ExpectKubernetes},
+		} {
+			if c[1] == "" {
+				continue
+			}
+			if _, err := semver.ParseConstraint(c[1]); err != nil {
+				return fmt.Errorf("Invalid %s %q: expected a version constraint such as \">=1.11.0 <1.12\"", c[0], c[1])
+			}
+		}
+		if args.Version.Min != "" {
+			if _, err := semver.Parse(args.Version.Min); err != nil {
+				return fmt.Errorf("Invalid --min %q: expected a version such as 1.11.0", args.Version.Min)
+			}
+		}
+	case args.ConfigDrift != nil:
+		// V20: --reference is required and must be readable; --ignore must
+		// be dotted paths with well-formed glob segments.
+		if args.ConfigDrift.Reference == "" {
+			return fmt.Errorf("--reference is required")
+		}
+		if err := checkFileReadable("--reference", args.ConfigDrift.Reference); err != nil {
+			return err
+		}
+		for _, p := range args.ConfigDrift.Ignore {
+			if err := validateConfigPath(p); err != nil {
+				return err
+			}
+		}
+		// The critical threshold is optional (never CRITICAL when unset).
+		return validateOptionalThresholds(args.ConfigDrift.Warning, args.ConfigDrift.Critical, threshold.UnitNone)
+	case args.PendingReboot != nil:
+		// Both thresholds are optional: without -w any pending change is
+		// WARNING, without -c it is never CRITICAL.
+		return validateOptionalThresholds(args.PendingReboot.Warning, args.PendingReboot.Critical, threshold.UnitSeconds)
+	case args.Containers != nil:
+		// V21: --require-pod must be well-formed glob patterns.
+		for _, p := range args.Containers.RequirePod {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("Invalid --require-pod %q: malformed glob pattern", p)
+			}
+		}
+		// The critical threshold is optional (never CRITICAL when unset).
+		return validateOptionalThresholds(args.Containers.Warning, args.Containers.Critical, threshold.UnitNone)
+	case args.Dmesg != nil:
+		// V22: rules must parse; without the built-in rules at least one
+		// --rule is needed.
+		for _, r := range args.Dmesg.Rule {
+			if _, err := check.ParseDmesgRule(r); err != nil {
+				return fmt.Errorf("Invalid --rule %q: %s", r, err)
+			}
+		}
+		if args.Dmesg.NoDefaultRules && len(args.Dmesg.Rule) == 0 {
+			return fmt.Errorf("--no-default-rules requires at least one --rule")
+		}
+	}
+
+	return nil
+}
+
+// validateMountPatterns checks that every mount pattern is an absolute path
+// and a well-formed glob pattern (V12).
+func validateMountPatterns(flagName string, patterns []string) error {
+	for _, p := range patterns {
+		if p == "" || p[0] != '/' {
+			return fmt.Errorf("Invalid %s %q: must be an absolute path", flagName, p)
+		}
+		if _, err := path.Match(p, "/"); err != nil {
+			return fmt.Errorf("Invalid %s %q: malformed glob pattern", flagName, p)
+		}
+	}
+	return nil
+}
+
+// validateConfigPath checks that an --ignore pattern is a dotted config
+// path whose segments are well-formed glob patterns (V20).
+func validateConfigPath(p string) error {
+	for _, seg := range strings.Split(p, ".") {
+		if seg == "" {
+			return fmt.Errorf("Invalid --ignore %q: expected a dotted config path such as machine.network.hostname", p)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("Invalid --ignore %q: malformed glob pattern", p)
+		}
+	}
+	return nil
+}
+
+// validateChoice checks that value is one of choices (V18).
+func validateChoice(flagName, value string, choices []string) error {
+	for _, c := range choices {
+		if value == c {
+			return nil
+		}
 	}
+	return fmt.Errorf("Invalid %s %q: must be one of %s", flagName, value, strings.Join(choices, ", "))
+}
 
+// validateSampleDuration checks that a two-sample interval fits inside the
+// gRPC timeout (V13), leaving room for the second API call.
+func validateSampleDuration(d, timeout time.Duration) error {
+	if d < 0 || d >= timeout {
+		return fmt.Errorf("Invalid --sample-duration %q: must be between 0s and --timeout (%s)", d, timeout)
+	}
 	return nil
 }
 
-// validateThresholds parses warning and critical thresholds (V7) and checks
-// their ordering (V8). Both thresholds are required.
-func validateThresholds(warnStr, critStr string) error {
-	warnT, err := threshold.Parse(warnStr)
+// validateThresholds parses warning and critical thresholds for a metric
+// measured in unit (V7) and checks their ordering (V8). Both thresholds are
+// required. Suffixes of another kind are rejected; UnitNone rejects all.
+func validateThresholds(warnStr, critStr string, unit threshold.Unit) error {
+	warnT, err := threshold.ParseUnit(warnStr, unit)
 	if err != nil {
-		return fmt.Errorf("Invalid warning threshold %q: expected Nagios range format", warnStr)
+		return fmt.Errorf("Invalid warning threshold %q: %s", warnStr, thresholdFormat(unit))
 	}
-	critT, err := threshold.Parse(critStr)
+	critT, err := threshold.ParseUnit(critStr, unit)
 	if err != nil {
-		return fmt.Errorf("Invalid critical threshold %q: expected Nagios range format", critStr)
+		return fmt.Errorf("Invalid critical threshold %q: %s", critStr, thresholdFormat(unit))
 	}
 	warnThresholdOrdering(warnT, critT)
 	return nil
@@ -305,20 +823,20 @@ func validateThresholds(warnStr, critStr string) error {
 // validateOptionalThresholds parses thresholds that may be empty (V7) and
 // checks ordering if both are provided (V8). Used by load check where
 // thresholds are auto-computed at runtime if not specified.
-func validateOptionalThresholds(warnStr, critStr string) error {
+func validateOptionalThresholds(warnStr, critStr string, unit threshold.Unit) error {
 	var warnT, critT *threshold.Threshold
 
 	if warnStr != "" {
-		t, err := threshold.Parse(warnStr)
+		t, err := threshold.ParseUnit(warnStr, unit)
 		if err != nil {
-			return fmt.Errorf("Invalid warning threshold %q: expected Nagios range format", warnStr)
+			return fmt.Errorf("Invalid warning threshold %q: %s", warnStr, thresholdFormat(unit))
 		}
 		warnT = &t
 	}
 	if critStr != "" {
-		t, err := threshold.Parse(critStr)
+		t, err := threshold.ParseUnit(critStr, unit)
 		if err != nil {
-			return fmt.Errorf("Invalid critical threshold %q: expected Nagios range format", critStr)
+			return fmt.Errorf("Invalid critical threshold %q: %s", critStr, thresholdFormat(unit))
 		}
 		critT = &t
 	}
@@ -329,6 +847,19 @@ func validateOptionalThresholds(warnStr, critStr string) error {
 	return nil
 }
 
+// thresholdFormat describes the threshold syntax accepted for unit, for
+// validation errors. Unitless metrics (percentages, counts) take no suffix.
+func thresholdFormat(unit threshold.Unit) string {
+	switch unit {
+	case threshold.UnitBytes:
+		return "expected Nagios range format with optional size suffix (e.g. 10GiB)"
+	case threshold.UnitSeconds:
+		return "expected Nagios range format with optional duration suffix (e.g. 10m)"
+	default:
+		return "expected Nagios range format"
+	}
+}
+
 // warnThresholdOrdering prints a warning to stderr if the warning range
 // appears wider than the critical range (V8). This is informational only —
 // Nagios convention allows it but it often indicates a configuration mistake.
@@ -345,6 +876,28 @@ func warnThresholdOrdering(warn, crit threshold.Threshold) {
 	}
 }
 
+// defaultStateFile returns where a check keeps its state between runs when
+// no --state-file is given: one file per check and target node under
+// check-talos/ in the temp directory. The node is named by --node, else by
+// the endpoint or talosconfig context, so one Nagios host can monitor
+// several nodes.
+func defaultStateFile(args *Args, checkName string) string {
+	target := "default"
+	for _, s := range []string{args.Node, args.Endpoint, args.Context} {
+		if s != "" {
+			target = s
+			break
+		}
+	}
+	target = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, target)
+	return filepath.Join(os.TempDir(), "check-talos", checkName+"-"+target+".json")
+}
+
 // checkFileReadable verifies that a file exists and is not a directory.
 func checkFileReadable(flagName, path string) error {
 	info, err := os.Stat(path)
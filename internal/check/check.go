@@ -1,6 +1,7 @@
 // Package check defines the Check interface and concrete implementations
-// for Talos Linux monitoring checks (CPU, memory, disk, services, etcd, load).
-// Each check queries the Talos gRPC API and returns a structured Result.
+// for Talos Linux monitoring checks, one per check-talos subcommand (see
+// the registry in registry.go). Each check queries the Talos gRPC API and
+// returns a structured Result.
 package check
 
 import (
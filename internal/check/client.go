@@ -3,6 +3,8 @@ package check
 import (
 	"context"
 
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
@@ -11,7 +13,8 @@ import (
 // checks to be unit-tested with mock implementations.
 type TalosClient interface {
 	// SystemStat returns CPU counters and process statistics.
-	// Used by: CPU check (usage calculation), Load check (CPU count for auto-thresholds).
+	// Used by: CPU check (usage calculation), Load check (CPU count for auto-thresholds),
+	// Uptime check (boot time), Processes check (running/blocked counters).
 	SystemStat(ctx context.Context) (*machine.SystemStatResponse, error)
 
 	// Memory returns /proc/meminfo-equivalent memory statistics.
@@ -23,13 +26,20 @@ type TalosClient interface {
 	Mounts(ctx context.Context) (*machine.MountsResponse, error)
 
 	// ServiceList returns the list of Talos system services with state and health.
-	// Used by: Services check.
+	// Used by: Services check, Kubernetes check (kubelet).
 	ServiceList(ctx context.Context) (*machine.ServiceListResponse, error)
 
 	// EtcdStatus returns etcd member status including leader, DB size, and errors.
 	// Used by: Etcd check.
 	EtcdStatus(ctx context.Context) (*machine.EtcdStatusResponse, error)
 
+	// EtcdStatusNodes returns the etcd member status of each of nodes in
+	// one request, fanned out by apid. Each message names its node in
+	// Metadata.Hostname; a node that failed has Metadata.Error set instead
+	// of a member status.
+	// Used by: Etcd check (--cluster).
+	EtcdStatusNodes(ctx context.Context, nodes []string) (*machine.EtcdStatusResponse, error)
+
 	// EtcdMemberList returns the list of etcd cluster members.
 	// Used by: Etcd check.
 	EtcdMemberList(ctx context.Context) (*machine.EtcdMemberListResponse, error)
@@ -41,4 +51,44 @@ type TalosClient interface {
 	// LoadAvg returns 1/5/15-minute load averages.
 	// Used by: Load check.
 	LoadAvg(ctx context.Context) (*machine.LoadAvgResponse, error)
+
+	// NetworkDeviceStats returns cumulative per-interface network counters.
+	// Used by: Network check.
+	NetworkDeviceStats(ctx context.Context) (*machine.NetworkDeviceStatsResponse, error)
+
+	// DiskStats returns cumulative per-device block I/O counters.
+	// Used by: Disk I/O check.
+	DiskStats(ctx context.Context) (*machine.DiskStatsResponse, error)
+
+	// Read returns the contents of a file on the node (e.g. /proc/mounts).
+	// Used by: Mounts check (mount options), Pressure check (PSI),
+	// Version check (kernel release).
+	Read(ctx context.Context, path string) ([]byte, error)
+
+	// Processes returns the node's process list with state and command.
+	// Used by: Processes check (zombie and D-state counts).
+	Processes(ctx context.Context) (*machine.ProcessesResponse, error)
+
+	// Version returns the Talos version, build and platform information.
+	// Used by: Version check.
+	Version(ctx context.Context) (*machine.VersionResponse, error)
+
+	// Containers returns the containers in a containerd namespace, listed
+	// through the given driver (containerd for "system", CRI for "k8s.io").
+	// Used by: Containers check.
+	Containers(ctx context.Context, namespace string, driver common.ContainerDriver) (*machine.ContainersResponse, error)
+
+	// Dmesg returns the kernel ring buffer as formatted by Talos, one
+	// message per line ("kern: warning: [<RFC 3339 time>]: <message>").
+	// Used by: Dmesg check.
+	Dmesg(ctx context.Context) ([]byte, error)
+
+	// GetResource returns one resource from the node's COSI state, decoded
+	// into its machinery type (e.g. *config.MachineConfig). A missing
+	// resource returns an error for which state.IsNotFoundError is true.
+	// Used by: Config drift check (active config), Pending reboot check
+	// (persistent/active config, META keys), Version check (kubelet and
+	// static pod images), Machine check (machine status), Kubernetes check
+	// (static pods and their status).
+	GetResource(ctx context.Context, ptr resource.Pointer) (resource.Resource, error)
 }
@@ -0,0 +1,87 @@
+package check
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// errNotImplemented is returned by stubClient for every call a mock does not
+// override.
+var errNotImplemented = errors.New("not implemented")
+
+// stubClient implements TalosClient with methods that all fail. Each check's
+// mock embeds it and overrides only the calls its check makes, so adding a
+// method to TalosClient only touches the mocks that use it.
+type stubClient struct{}
+
+func (stubClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Memory(context.Context) (*machine.MemoryResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Mounts(context.Context) (*machine.MountsResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) EtcdStatusNodes(context.Context, []string) (*machine.EtcdStatusResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) NetworkDeviceStats(context.Context) (*machine.NetworkDeviceStatsResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) DiskStats(context.Context) (*machine.DiskStatsResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Read(context.Context, string) ([]byte, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Processes(context.Context) (*machine.ProcessesResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Version(context.Context) (*machine.VersionResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Containers(context.Context, string, common.ContainerDriver) (*machine.ContainersResponse, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) Dmesg(context.Context) ([]byte, error) {
+	return nil, errNotImplemented
+}
+
+func (stubClient) GetResource(context.Context, resource.Pointer) (resource.Resource, error) {
+	return nil, errNotImplemented
+}
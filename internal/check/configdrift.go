@@ -0,0 +1,349 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/talos/pkg/machinery/resources/config"
+	"gopkg.in/yaml.v3"
+)
+
+// configDriftSummaryKeys is the number of differing keys named in the
+// summary; the long text lists all of them.
+const configDriftSummaryKeys = 3
+
+// DefaultConfigDriftIgnore lists the machine config paths that are always
+// ignored: cluster secrets and identifiers that differ per cluster even
+// when the reference was rendered from the same template, and the per-node
+// hostname.
+var DefaultConfigDriftIgnore = []string{
+	"machine.token",
+	"machine.ca",
+	"machine.network.hostname",
+	"cluster.id",
+	"cluster.secret",
+	"cluster.token",
+	"cluster.ca",
+	"cluster.aggregatorCA",
+	"cluster.serviceAccount",
+	"cluster.etcd.ca",
+	"cluster.secretboxEncryptionSecret",
+	"cluster.aescbcEncryptionSecret",
+}
+
+// ConfigDriftCheck compares the node's active machine configuration with a
+// reference configuration.
+//
+// Both configurations are flattened into dotted key paths
+// (machine.kubelet.image, machine.install.extraKernelArgs.0). Documents
+// other than the v1alpha1 config are prefixed with their kind and name
+// (HostnameConfig.hostname, UserVolumeConfig:data.provisioning.minSize).
+// Keys that match Ignore or DefaultConfigDriftIgnore are skipped. A key is
+// drifted when its value differs or it is present on only one side; values
+// are never reported, since the config holds secrets.
+//
+// Warning and Critical apply to the number of drifted keys.
+type ConfigDriftCheck struct {
+	Warning   threshold.Threshold
+	Critical  *threshold.Threshold // nil = never CRITICAL
+	Reference map[string]string    // flattened reference config
+	Ignore    []string             // dotted path patterns, see matchConfigPath
+}
+
+// NewConfigDriftCheck creates a ConfigDriftCheck from warning and optional
+// critical threshold strings, the reference configuration YAML and
+// additional ignore patterns.
+func NewConfigDriftCheck(w, c string, reference []byte, ignore []string) (*ConfigDriftCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+
+	ch := &ConfigDriftCheck{Warning: wt}
+
+	if c != "" {
+		ct, err := threshold.ParseUnit(c, threshold.UnitNone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid critical threshold: %w", err)
+		}
+		ch.Critical = &ct
+	}
+
+	ch.Reference, err = flattenMachineConfig(reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference config: %w", err)
+	}
+	if len(ch.Reference) == 0 {
+		return nil, fmt.Errorf("invalid reference config: no keys")
+	}
+
+	for _, p := range ignore {
+		if err := validateConfigPattern(p); err != nil {
+			return nil, err
+		}
+	}
+	ch.Ignore = append(append([]string{}, DefaultConfigDriftIgnore...), ignore...)
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *ConfigDriftCheck) Name() string { return "CONFIG-DRIFT" }
+
+// Run executes the config drift check against the Talos API.
+func (ch *ConfigDriftCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	mc, err := getMachineConfig(ctx, client, config.ActiveID)
+	if err != nil {
+		return nil, err
+	}
+	if mc == nil {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No active machine config (node in maintenance mode?)",
+		}, nil
+	}
+
+	data, err := mc.Provider().Bytes()
+	if err != nil {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Cannot encode active machine config: %s", err),
+		}, nil
+	}
+	active, err := flattenMachineConfig(data)
+	if err != nil {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Cannot parse active machine config: %s", err),
+		}, nil
+	}
+	if len(active) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Active machine config has no keys",
+		}, nil
+	}
+
+	var compared, ignored int
+	var drifted []string
+	var details strings.Builder
+	for _, k := range configKeyUnion(active, ch.Reference) {
+		if ch.ignored(k) {
+			ignored++
+			continue
+		}
+		compared++
+
+		activeVal, onNode := active[k]
+		refVal, inRef := ch.Reference[k]
+
+		var what string
+		switch {
+		case !onNode:
+			what = "missing on node"
+		case !inRef:
+			what = "not in reference"
+		case activeVal != refVal:
+			what = "changed"
+		default:
+			continue
+		}
+
+		drifted = append(drifted, k)
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: %s", k, what)
+	}
+
+	status := evaluateCounter(float64(len(drifted)), &ch.Warning, ch.Critical)
+
+	perfData := []output.PerfDatum{
+		{Label: "config_drift_keys", Value: float64(len(drifted)), Warn: ch.Warning.String(), Crit: optionalString(ch.Critical), Min: "0"},
+		{Label: "config_keys", Value: float64(compared), Min: "0"},
+	}
+
+	if len(drifted) == 0 {
+		return &output.Result{
+			Status:    status,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Machine config matches reference (%d keys compared, %d ignored)", compared, ignored),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	named := drifted
+	more := ""
+	if len(named) > configDriftSummaryKeys {
+		more = fmt.Sprintf(" and %d more", len(named)-configDriftSummaryKeys)
+		named = named[:configDriftSummaryKeys]
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d keys differ from reference: %s%s",
+			len(drifted), strings.Join(named, ", "), more),
+		Details:  details.String(),
+		PerfData: perfData,
+	}, nil
+}
+
+// configKeyUnion returns the keys of both flattened configs, sorted.
+func configKeyUnion(a, b map[string]string) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]string{a, b} {
+		for k := range m {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ignored reports whether key matches an ignore pattern.
+func (ch *ConfigDriftCheck) ignored(key string) bool {
+	for _, p := range ch.Ignore {
+		if matchConfigPath(p, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchConfigPath reports whether the dotted key matches pattern. Both are
+// compared segment by segment with path.Match, so "*" matches within one
+// segment ("machine.network.interfaces.*.addresses"). A pattern also
+// matches every key below it: "cluster.etcd" matches "cluster.etcd.ca.crt".
+func matchConfigPath(pattern, key string) bool {
+	ps := strings.Split(pattern, ".")
+	ks := strings.Split(key, ".")
+	if len(ps) > len(ks) {
+		return false
+	}
+	for i, p := range ps {
+		if ok, _ := path.Match(p, ks[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validateConfigPattern checks that every segment of an ignore pattern is a
+// well-formed path.Match pattern.
+func validateConfigPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("invalid ignore pattern %q: must not be empty", pattern)
+	}
+	for _, seg := range strings.Split(pattern, ".") {
+		if seg == "" {
+			return fmt.Errorf("invalid ignore pattern %q: empty path segment", pattern)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid ignore pattern %q: malformed glob pattern", pattern)
+		}
+	}
+	return nil
+}
+
+// getMachineConfig reads the MachineConfig resource with the given ID
+// (config.ActiveID, config.PersistentID) from the node's COSI state. A
+// missing resource returns nil and no error.
+func getMachineConfig(ctx context.Context, client TalosClient, id resource.ID) (*config.MachineConfig, error) {
+	res, err := client.GetResource(ctx, resource.NewMetadata(
+		config.NamespaceName, config.MachineConfigType, id, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mc, ok := res.(*config.MachineConfig)
+	if !ok {
+		return nil, fmt.Errorf("unexpected resource type %T for machine config %s", res, id)
+	}
+	return mc, nil
+}
+
+// flattenMachineConfig parses a (multi-document) machine config and returns
+// its scalar values keyed by dotted path. The v1alpha1 document is keyed
+// from its root; other documents are prefixed with "<kind>" or
+// "<kind>:<name>" and their apiVersion, kind and name fields are dropped.
+// List elements are keyed by index. Empty maps and lists produce no keys.
+func flattenMachineConfig(data []byte) (map[string]string, error) {
+	flat := make(map[string]string)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc map[string]any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+
+		prefix := ""
+		if kind, ok := doc["kind"].(string); ok && kind != "" {
+			prefix = kind
+			if name, ok := doc["name"].(string); ok && name != "" {
+				prefix += ":" + name
+			}
+			delete(doc, "apiVersion")
+			delete(doc, "kind")
+			delete(doc, "name")
+		}
+		flattenValue(flat, prefix, doc)
+	}
+	return flat, nil
+}
+
+// flattenValue adds v to flat under key, descending into maps and lists.
+func flattenValue(flat map[string]string, key string, v any) {
+	join := func(k string) string {
+		if key == "" {
+			return k
+		}
+		return key + "." + k
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			flattenValue(flat, join(k), child)
+		}
+	case map[any]any:
+		for k, child := range val {
+			flattenValue(flat, join(fmt.Sprint(k)), child)
+		}
+	case []any:
+		for i, child := range val {
+			flattenValue(flat, join(strconv.Itoa(i)), child)
+		}
+	default:
+		flat[key] = fmt.Sprint(val)
+	}
+}
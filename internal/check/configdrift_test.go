@@ -0,0 +1,308 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/siderolabs/talos/pkg/machinery/resources/config"
+)
+
+// mockConfigDriftClient implements TalosClient for Config drift check testing.
+type mockConfigDriftClient struct {
+	stubClient
+
+	config *config.MachineConfig // nil = not found
+	err    error
+	ptr    resource.Pointer // last requested resource
+}
+
+func (m *mockConfigDriftClient) GetResource(_ context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	m.ptr = ptr
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.config == nil {
+		return nil, inmem.ErrNotFound(ptr)
+	}
+	return m.config, nil
+}
+
+// referenceConfig is a trimmed two-document control-plane machine config.
+const referenceConfig = `version: v1alpha1
+machine:
+  type: controlplane
+  token: REDACTED-TEMPLATE
+  ca:
+    crt: TEMPLATE-CRT
+    key: TEMPLATE-KEY
+  network:
+    hostname: cp-template
+  install:
+    disk: /dev/sda
+    image: ghcr.io/siderolabs/installer:v1.11.6
+    extraKernelArgs:
+      - console=ttyS0
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.1
+cluster:
+  id: TEMPLATE-ID
+  secret: TEMPLATE-SECRET
+  controlPlane:
+    endpoint: https://10.0.0.10:6443
+  network:
+    cni:
+      name: flannel
+---
+apiVersion: v1alpha1
+kind: UserVolumeConfig
+name: data
+provisioning:
+  minSize: 10GiB
+`
+
+// nodeConfig returns referenceConfig with per-node secrets and hostname,
+// which are ignored by default, after applying replace.
+func nodeConfig(replace ...string) string {
+	cfg := referenceConfig
+	for _, pair := range [][2]string{
+		{"REDACTED-TEMPLATE", "abcdef.0123456789abcdef"},
+		{"TEMPLATE-CRT", "Y3J0LW5vZGU="},
+		{"TEMPLATE-KEY", "a2V5LW5vZGU="},
+		{"cp-template", "cp-1"},
+		{"TEMPLATE-ID", "x1y2z3"},
+		{"TEMPLATE-SECRET", "s3cr3t"},
+	} {
+		cfg = replaceOnce(cfg, pair[0], pair[1])
+	}
+	for i := 0; i+1 < len(replace); i += 2 {
+		cfg = replaceOnce(cfg, replace[i], replace[i+1])
+	}
+	return cfg
+}
+
+// activeMachineConfig returns cfg as the node's active MachineConfig resource.
+func activeMachineConfig(t *testing.T, cfg string) *config.MachineConfig {
+	t.Helper()
+	return machineConfigResource(t, config.ActiveID, cfg, time.Time{})
+}
+
+// replaceOnce replaces the first old in s with new and panics when old is
+// missing, so a stale test fixture fails loudly.
+func replaceOnce(s, old, new string) string {
+	for i := 0; i+len(old) <= len(s); i++ {
+		if s[i:i+len(old)] == old {
+			return s[:i] + new + s[i+len(old):]
+		}
+	}
+	panic(fmt.Sprintf("fixture does not contain %q", old))
+}
+
+func TestNewConfigDriftCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		w, c      string
+		reference string
+		ignore    []string
+		wantErr   bool
+	}{
+		{name: "valid", w: "0", reference: referenceConfig, wantErr: false},
+		{name: "valid with critical and ignore", w: "0", c: "10", reference: referenceConfig, ignore: []string{"machine.install.*"}, wantErr: false},
+		{name: "invalid warning", w: "abc", reference: referenceConfig, wantErr: true},
+		{name: "invalid critical", w: "0", c: "abc", reference: referenceConfig, wantErr: true},
+		{name: "size suffix rejected", w: "1kB", reference: referenceConfig, wantErr: true},
+		{name: "reference not YAML", w: "0", reference: "machine: [", wantErr: true},
+		{name: "empty reference", w: "0", reference: "", wantErr: true},
+		{name: "empty ignore segment", w: "0", reference: referenceConfig, ignore: []string{"machine..install"}, wantErr: true},
+		{name: "malformed ignore glob", w: "0", reference: referenceConfig, ignore: []string{"machine.[install"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewConfigDriftCheck(tt.w, tt.c, []byte(tt.reference), tt.ignore)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "CONFIG-DRIFT" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "CONFIG-DRIFT")
+			}
+			if got, want := len(ch.Ignore), len(DefaultConfigDriftIgnore)+len(tt.ignore); got != want {
+				t.Errorf("len(Ignore) = %d, want %d (defaults plus --ignore)", got, want)
+			}
+		})
+	}
+}
+
+func TestConfigDriftCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		c           string
+		ignore      []string
+		config      string
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - only ignored keys differ",
+			config:      nodeConfig(),
+			wantStatus:  output.OK,
+			wantSummary: "Machine config matches reference (9 keys compared, 6 ignored)",
+		},
+		{
+			name:        "WARNING - kubelet image changed",
+			config:      nodeConfig("kubelet:v1.34.1", "kubelet:v1.33.4"),
+			wantStatus:  output.Warning,
+			wantSummary: "1 keys differ from reference: machine.kubelet.image",
+			wantDetails: "machine.kubelet.image: changed",
+		},
+		{
+			name: "WARNING - added, removed and changed keys",
+			config: nodeConfig(
+				"      - console=ttyS0\n", "      - console=ttyS0\n      - talos.dashboard.disabled=1\n",
+				"    cni:\n      name: flannel\n", "    cni:\n      name: none\n",
+				"provisioning:\n  minSize: 10GiB\n", "provisioning:\n  maxSize: 50GiB\n",
+			),
+			wantStatus:  output.Warning,
+			wantSummary: "4 keys differ from reference: UserVolumeConfig:data.provisioning.maxSize, UserVolumeConfig:data.provisioning.minSize, cluster.network.cni.name and 1 more",
+			wantDetails: "UserVolumeConfig:data.provisioning.maxSize: not in reference\n" +
+				"UserVolumeConfig:data.provisioning.minSize: missing on node\n" +
+				"cluster.network.cni.name: changed\n" +
+				"machine.install.extraKernelArgs.1: not in reference",
+		},
+		{
+			name:        "CRITICAL - critical threshold",
+			c:           "1",
+			config:      nodeConfig("type: controlplane", "type: worker", "/dev/sda", "/dev/nvme0n1"),
+			wantStatus:  output.Critical,
+			wantSummary: "2 keys differ from reference: machine.install.disk, machine.type",
+			wantDetails: "machine.install.disk: changed\nmachine.type: changed",
+		},
+		{
+			name:        "OK - drift under ignored subtree",
+			ignore:      []string{"machine.install"},
+			config:      nodeConfig("/dev/sda", "/dev/nvme0n1", "installer:v1.11.6", "installer:v1.11.5"),
+			wantStatus:  output.OK,
+			wantSummary: "Machine config matches reference (6 keys compared, 9 ignored)",
+		},
+		{
+			name:        "UNKNOWN - no active config",
+			config:      "",
+			wantStatus:  output.Unknown,
+			wantSummary: "No active machine config (node in maintenance mode?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewConfigDriftCheck("0", tt.c, []byte(referenceConfig), tt.ignore)
+			if err != nil {
+				t.Fatalf("NewConfigDriftCheck: %v", err)
+			}
+
+			client := &mockConfigDriftClient{}
+			if tt.config != "" {
+				client.config = activeMachineConfig(t, tt.config)
+			}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := client.ptr.Type() + "/" + client.ptr.ID(); got != config.MachineConfigType+"/"+config.ActiveID {
+				t.Errorf("resource = %q, want the active MachineConfig", got)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "CONFIG-DRIFT" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "CONFIG-DRIFT")
+			}
+			if !contains(result.Summary, tt.wantSummary) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestConfigDriftCheckNoValuesInOutput(t *testing.T) {
+	ch, err := NewConfigDriftCheck("0", "", []byte(referenceConfig), nil)
+	if err != nil {
+		t.Fatalf("NewConfigDriftCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockConfigDriftClient{
+		config: activeMachineConfig(t, nodeConfig("https://10.0.0.10:6443", "https://10.9.9.9:6443")),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, s := range []string{result.Summary, result.Details} {
+		if contains(s, "10.9.9.9") || contains(s, "10.0.0.10") {
+			t.Errorf("output leaks config values: %q", s)
+		}
+	}
+}
+
+func TestConfigDriftCheckPerfData(t *testing.T) {
+	ch, err := NewConfigDriftCheck("0", "5", []byte(referenceConfig), nil)
+	if err != nil {
+		t.Fatalf("NewConfigDriftCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockConfigDriftClient{config: activeMachineConfig(t, nodeConfig("type: controlplane", "type: worker"))})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "config_drift_keys=1;0;5;0; config_keys=9;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestConfigDriftCheckAPIError(t *testing.T) {
+	ch, err := NewConfigDriftCheck("0", "", []byte(referenceConfig), nil)
+	if err != nil {
+		t.Fatalf("NewConfigDriftCheck: %v", err)
+	}
+
+	if _, err := ch.Run(context.Background(), &mockConfigDriftClient{err: fmt.Errorf("permission denied")}); err == nil {
+		t.Error("expected error from GetResource, got nil")
+	}
+}
+
+func TestMatchConfigPath(t *testing.T) {
+	tests := []struct {
+		pattern, key string
+		want         bool
+	}{
+		{"machine.token", "machine.token", true},
+		{"machine.ca", "machine.ca.crt", true},
+		{"cluster.ca", "cluster.aggregatorCA.crt", false},
+		{"machine.install", "machine.installer", false},
+		{"machine.network.interfaces.*.addresses", "machine.network.interfaces.1.addresses.0", true},
+		{"machine.network.interfaces.*.addresses", "machine.network.interfaces.1.mtu", false},
+		{"UserVolumeConfig:*", "UserVolumeConfig:data.provisioning.minSize", true},
+		{"machine.kubelet.image", "machine.kubelet", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchConfigPath(tt.pattern, tt.key); got != tt.want {
+			t.Errorf("matchConfigPath(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/constants"
+)
+
+// containerTopN is the number of problem containers named in the summary.
+const containerTopN = 5
+
+// containerNamespaces are the containerd namespaces the check lists, with
+// the driver Talos serves each one through.
+var containerNamespaces = []struct {
+	name   string
+	driver common.ContainerDriver
+}{
+	{constants.SystemContainerdNamespace, common.ContainerDriver_CONTAINERD},
+	{constants.K8sContainerdNamespace, common.ContainerDriver_CRI},
+}
+
+// ContainersCheck counts the containers running in the Talos system
+// namespace and the Kubernetes (CRI) namespace, and flags containers that
+// have exited or whose state is unknown.
+//
+// Warning and Critical apply to the number of flagged containers (nil =
+// never violated). Two kinds of exited CRI container are expected and not
+// flagged: the previous attempt of a container that has since restarted,
+// which the kubelet keeps next to the running one, and the containers of a
+// pod whose sandbox is no longer ready (completed or deleted pods).
+//
+// RequiredIDs and RequiredPods name containers that must be running; any of
+// them missing or not running is CRITICAL regardless of the thresholds.
+type ContainersCheck struct {
+	Warning      *threshold.Threshold // flagged containers; nil = never WARNING
+	Critical     *threshold.Threshold // flagged containers; nil = never CRITICAL
+	RequiredIDs  []string             // container IDs, e.g. "etcd"
+	RequiredPods []string             // pod ID glob patterns, e.g. "kube-system/coredns-*"
+}
+
+// NewContainersCheck creates a ContainersCheck from optional warning and
+// critical threshold strings for the flagged container count, required
+// container IDs and required pod ID patterns.
+func NewContainersCheck(w, c string, requiredIDs, requiredPods []string) (*ContainersCheck, error) {
+	ch := &ContainersCheck{RequiredIDs: requiredIDs, RequiredPods: requiredPods}
+
+	if err := parseOptionalThresholds(threshold.UnitNone, []optionalThreshold{
+		{"warning", w, &ch.Warning},
+		{"critical", c, &ch.Critical},
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, p := range requiredPods {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pod pattern %q: %w", p, err)
+		}
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *ContainersCheck) Name() string { return "CONTAINERS" }
+
+// Run executes the containers check against the Talos API.
+func (ch *ContainersCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	var containers []*machine.ContainerInfo
+	running := make(map[string]int, len(containerNamespaces))
+	total, runningTotal := 0, 0
+	for _, ns := range containerNamespaces {
+		resp, err := client.Containers(ctx, ns.name, ns.driver)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || len(resp.GetMessages()) == 0 {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   "Empty response from Talos API",
+			}, nil
+		}
+		for _, c := range resp.GetMessages()[0].GetContainers() {
+			containers = append(containers, c)
+			// The CRI driver lists each pod sandbox as an entry of its own;
+			// only its state is used, to tell live pods from stopped ones.
+			st := containerState(c)
+			if strings.HasPrefix(st, "SANDBOX_") {
+				continue
+			}
+			total++
+			if st == "RUNNING" {
+				running[ns.name]++
+				runningTotal++
+			}
+		}
+	}
+
+	flagged := flaggedContainers(containers)
+	missing := ch.missingRequired(containers)
+
+	status := evaluateCounter(float64(len(flagged)), ch.Warning, ch.Critical)
+	if len(missing) > 0 {
+		status = output.Critical
+	}
+
+	perfData := []output.PerfDatum{
+		{Label: "containers_running", Value: float64(runningTotal), Min: "0"},
+		{Label: "containers_total", Value: float64(total), Min: "0"},
+		{Label: "containers_flagged", Value: float64(len(flagged)), Warn: optionalString(ch.Warning), Crit: optionalString(ch.Critical), Min: "0"},
+	}
+
+	summary := fmt.Sprintf("%d containers running (%d %s, %d %s)", runningTotal,
+		running[constants.SystemContainerdNamespace], constants.SystemContainerdNamespace,
+		running[constants.K8sContainerdNamespace], constants.K8sContainerdNamespace)
+
+	var details []string
+	if len(flagged) > 0 {
+		names := make([]string, len(flagged))
+		for i, c := range flagged {
+			names[i] = c.GetId()
+			details = append(details, fmt.Sprintf("%s %s: status=%s, image=%s",
+				c.GetNamespace(), c.GetId(), containerState(c), c.GetImage()))
+		}
+		summary += fmt.Sprintf(", %d exited or unknown: %s", len(flagged), topNames(names, containerTopN))
+	}
+	if len(missing) > 0 {
+		summary += ", required not running: " + strings.Join(missing, ", ")
+		for _, m := range missing {
+			details = append(details, m+": required, not running")
+		}
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary:   summary,
+		Details:   strings.Join(details, "\n"),
+		PerfData:  perfData,
+	}, nil
+}
+
+// flaggedContainers returns the containers that exited or are in an unknown
+// state, sorted by namespace and ID, leaving out restarted attempts and the
+// containers of stopped pods.
+func flaggedContainers(containers []*machine.ContainerInfo) []*machine.ContainerInfo {
+	stoppedPods := make(map[string]bool)
+	runningNames := make(map[string]bool) // "<pod>/<container name>"
+	for _, c := range containers {
+		switch containerState(c) {
+		case "SANDBOX_NOTREADY":
+			stoppedPods[c.GetPodId()] = true
+		case "RUNNING":
+			runningNames[c.GetPodId()+"/"+c.GetName()] = true
+		}
+	}
+
+	var flagged []*machine.ContainerInfo
+	for _, c := range containers {
+		switch containerState(c) {
+		case "EXITED", "STOPPED", "UNKNOWN", "":
+		default:
+			continue
+		}
+		if c.GetNamespace() == constants.K8sContainerdNamespace &&
+			(stoppedPods[c.GetPodId()] || runningNames[c.GetPodId()+"/"+c.GetName()]) {
+			continue
+		}
+		flagged = append(flagged, c)
+	}
+
+	sort.Slice(flagged, func(i, j int) bool {
+		if flagged[i].GetNamespace() != flagged[j].GetNamespace() {
+			return flagged[i].GetNamespace() < flagged[j].GetNamespace()
+		}
+		return flagged[i].GetId() < flagged[j].GetId()
+	})
+	return flagged
+}
+
+// missingRequired returns the required container IDs and pod patterns
+// without a running container, in flag order. Pod patterns are prefixed
+// with "pod " to tell them apart from container IDs.
+func (ch *ContainersCheck) missingRequired(containers []*machine.ContainerInfo) []string {
+	var missing []string
+	for _, id := range ch.RequiredIDs {
+		found := false
+		for _, c := range containers {
+			if c.GetId() == id && containerState(c) == "RUNNING" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, id)
+		}
+	}
+	for _, p := range ch.RequiredPods {
+		found := false
+		for _, c := range containers {
+			if ok, _ := path.Match(p, c.GetPodId()); ok && containerState(c) == "RUNNING" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, "pod "+p)
+		}
+	}
+	return missing
+}
+
+// containerState normalizes the status Talos reports for a container:
+// containerd task states ("running", "stopped") and CRI states
+// ("CONTAINER_RUNNING", "CONTAINER_EXITED") both become upper case without
+// the CONTAINER_ prefix. Sandbox states keep their SANDBOX_ prefix.
+func containerState(c *machine.ContainerInfo) string {
+	return strings.TrimPrefix(strings.ToUpper(c.GetStatus()), "CONTAINER_")
+}
+
+// topNames joins up to n names, appending "and <k> more" for the rest.
+func topNames(names []string, n int) string {
+	if len(names) <= n {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:n], ", "), len(names)-n)
+}
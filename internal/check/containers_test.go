@@ -0,0 +1,308 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockContainersClient implements TalosClient for Containers check testing.
+type mockContainersClient struct {
+	stubClient
+
+	resp    map[string]*machine.ContainersResponse // keyed by namespace
+	err     error
+	drivers []string // "<namespace>=<driver>" per Containers call
+}
+
+func (m *mockContainersClient) Containers(_ context.Context, namespace string, driver common.ContainerDriver) (*machine.ContainersResponse, error) {
+	m.drivers = append(m.drivers, namespace+"="+driver.String())
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.resp[namespace], nil
+}
+
+// containersResp wraps containers in a single-node ContainersResponse.
+func containersResp(containers ...*machine.ContainerInfo) *machine.ContainersResponse {
+	return &machine.ContainersResponse{Messages: []*machine.Container{{Containers: containers}}}
+}
+
+// systemContainer returns a Talos system container in the given containerd
+// task state.
+func systemContainer(id, status string) *machine.ContainerInfo {
+	return &machine.ContainerInfo{
+		Namespace: "system", Id: id, PodId: id, Name: id,
+		Image: "ghcr.io/siderolabs/" + id + ":v1.11.6", Status: status,
+	}
+}
+
+// podSandbox returns the CRI entry of a pod sandbox.
+func podSandbox(pod, status string) *machine.ContainerInfo {
+	return &machine.ContainerInfo{
+		Namespace: "k8s.io", Id: pod, PodId: pod, Name: pod,
+		Image: "registry.k8s.io/pause:3.10", Status: status,
+	}
+}
+
+// podContainer returns a CRI container of a pod. attempt tells restarted
+// instances of the same container apart.
+func podContainer(pod, name, attempt, status string) *machine.ContainerInfo {
+	return &machine.ContainerInfo{
+		Namespace: "k8s.io", Id: pod + ":" + name + ":" + attempt, PodId: pod, Name: name,
+		Image: "registry.k8s.io/" + name + ":v1.34.1", Status: status,
+	}
+}
+
+// healthyNode returns the containers of a control plane node where every
+// container is running.
+func healthyNode() map[string]*machine.ContainersResponse {
+	return map[string]*machine.ContainersResponse{
+		"system": containersResp(
+			systemContainer("apid", "RUNNING"),
+			systemContainer("etcd", "RUNNING"),
+			systemContainer("trustd", "RUNNING"),
+		),
+		"k8s.io": containersResp(
+			podSandbox("kube-system/kube-apiserver-cp-1", "SANDBOX_READY"),
+			podContainer("kube-system/kube-apiserver-cp-1", "kube-apiserver", "1a2b", "CONTAINER_RUNNING"),
+			podSandbox("kube-system/coredns-6f7c8d-x9k2l", "SANDBOX_READY"),
+			podContainer("kube-system/coredns-6f7c8d-x9k2l", "coredns", "3c4d", "CONTAINER_RUNNING"),
+		),
+	}
+}
+
+func TestNewContainersCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		w, c    string
+		pods    []string
+		wantErr bool
+	}{
+		{"no thresholds", "", "", nil, false},
+		{"both thresholds", "0", "3", []string{"kube-system/coredns-*"}, false},
+		{"bad warning", "abc", "", nil, true},
+		{"size suffix rejected", "", "1GB", nil, true},
+		{"malformed pod pattern", "0", "", []string{"kube-system/[coredns"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewContainersCheck(tt.w, tt.c, nil, tt.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainersCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		w, c        string
+		ids, pods   []string
+		resp        func() map[string]*machine.ContainersResponse
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - all running",
+			w:           "0",
+			resp:        healthyNode,
+			wantStatus:  output.OK,
+			wantSummary: "5 containers running (3 system, 2 k8s.io)",
+		},
+		{
+			name: "OK - restarted attempt and completed pod not flagged",
+			w:    "0",
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["k8s.io"].Messages[0].Containers = append(r["k8s.io"].Messages[0].Containers,
+					podContainer("kube-system/coredns-6f7c8d-x9k2l", "coredns", "0f0f", "CONTAINER_EXITED"),
+					podSandbox("default/backup-29123-abcde", "SANDBOX_NOTREADY"),
+					podContainer("default/backup-29123-abcde", "backup", "5e6f", "CONTAINER_EXITED"),
+				)
+				return r
+			},
+			wantStatus:  output.OK,
+			wantSummary: "5 containers running (3 system, 2 k8s.io)",
+		},
+		{
+			name: "WARNING - crashed container without a running attempt",
+			w:    "0",
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["k8s.io"].Messages[0].Containers = append(r["k8s.io"].Messages[0].Containers,
+					podSandbox("kube-system/kube-proxy-7hq4z", "SANDBOX_READY"),
+					podContainer("kube-system/kube-proxy-7hq4z", "kube-proxy", "7a8b", "CONTAINER_EXITED"),
+				)
+				return r
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "5 containers running (3 system, 2 k8s.io), 1 exited or unknown: kube-system/kube-proxy-7hq4z:kube-proxy:7a8b",
+			wantDetails: "k8s.io kube-system/kube-proxy-7hq4z:kube-proxy:7a8b: status=EXITED, image=registry.k8s.io/kube-proxy:v1.34.1",
+		},
+		{
+			name: "CRITICAL - flagged count above critical",
+			w:    "0",
+			c:    "1",
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["system"] = containersResp(
+					systemContainer("apid", "STOPPED"),
+					systemContainer("etcd", "UNKNOWN"),
+					systemContainer("trustd", "RUNNING"),
+				)
+				return r
+			},
+			wantStatus:  output.Critical,
+			wantSummary: "3 containers running (1 system, 2 k8s.io), 2 exited or unknown: apid, etcd",
+			wantDetails: "system apid: status=STOPPED, image=ghcr.io/siderolabs/apid:v1.11.6\n" +
+				"system etcd: status=UNKNOWN, image=ghcr.io/siderolabs/etcd:v1.11.6",
+		},
+		{
+			name: "OK - no thresholds",
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["system"].Messages[0].Containers[0].Status = "STOPPED"
+				return r
+			},
+			wantStatus:  output.OK,
+			wantSummary: "4 containers running (2 system, 2 k8s.io), 1 exited or unknown: apid",
+			wantDetails: "system apid: status=STOPPED, image=ghcr.io/siderolabs/apid:v1.11.6",
+		},
+		{
+			name:        "OK - required present",
+			w:           "0",
+			ids:         []string{"etcd"},
+			pods:        []string{"kube-system/coredns-*", "kube-system/kube-apiserver-*"},
+			resp:        healthyNode,
+			wantStatus:  output.OK,
+			wantSummary: "5 containers running (3 system, 2 k8s.io)",
+		},
+		{
+			name: "CRITICAL - required missing or not running",
+			w:    "0",
+			ids:  []string{"etcd", "kubelet"},
+			pods: []string{"kube-system/coredns-*", "kube-system/cilium-*"},
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["system"].Messages[0].Containers[1].Status = "STOPPED"
+				return r
+			},
+			wantStatus:  output.Critical,
+			wantSummary: "4 containers running (2 system, 2 k8s.io), 1 exited or unknown: etcd, required not running: etcd, kubelet, pod kube-system/cilium-*",
+			wantDetails: "system etcd: status=STOPPED, image=ghcr.io/siderolabs/etcd:v1.11.6\n" +
+				"etcd: required, not running\n" +
+				"kubelet: required, not running\n" +
+				"pod kube-system/cilium-*: required, not running",
+		},
+		{
+			name: "UNKNOWN - empty response",
+			w:    "0",
+			resp: func() map[string]*machine.ContainersResponse {
+				r := healthyNode()
+				r["k8s.io"] = &machine.ContainersResponse{}
+				return r
+			},
+			wantStatus:  output.Unknown,
+			wantSummary: "Empty response from Talos API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewContainersCheck(tt.w, tt.c, tt.ids, tt.pods)
+			if err != nil {
+				t.Fatalf("NewContainersCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), &mockContainersClient{resp: tt.resp()})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "CONTAINERS" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "CONTAINERS")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestContainersCheckSummaryTruncation(t *testing.T) {
+	var system []*machine.ContainerInfo
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		system = append(system, systemContainer(id, "STOPPED"))
+	}
+
+	ch, err := NewContainersCheck("0", "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewContainersCheck: %v", err)
+	}
+	client := &mockContainersClient{resp: map[string]*machine.ContainersResponse{
+		"system": containersResp(system...),
+		"k8s.io": containersResp(),
+	}}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "0 containers running (0 system, 0 k8s.io), 7 exited or unknown: a, b, c, d, e and 2 more"
+	if result.Summary != want {
+		t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, want)
+	}
+}
+
+func TestContainersCheckPerfData(t *testing.T) {
+	ch, err := NewContainersCheck("0", "3", nil, nil)
+	if err != nil {
+		t.Fatalf("NewContainersCheck: %v", err)
+	}
+
+	resp := healthyNode()
+	resp["system"].Messages[0].Containers[2].Status = "STOPPED"
+	client := &mockContainersClient{resp: resp}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "containers_running=4;;;0; containers_total=5;;;0; containers_flagged=1;0;3;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+
+	// system is listed through containerd, k8s.io through CRI.
+	wantDrivers := []string{"system=CONTAINERD", "k8s.io=CRI"}
+	if !reflect.DeepEqual(client.drivers, wantDrivers) {
+		t.Errorf("drivers = %v, want %v", client.drivers, wantDrivers)
+	}
+}
+
+func TestContainersCheckAPIError(t *testing.T) {
+	ch, err := NewContainersCheck("0", "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewContainersCheck: %v", err)
+	}
+
+	_, err = ch.Run(context.Background(), &mockContainersClient{err: fmt.Errorf("connection refused")})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -4,28 +4,51 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
 	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
-// CPUCheck monitors aggregate CPU utilization via the Talos SystemStat API.
+// CPUCheck monitors CPU utilization via the Talos SystemStat API.
+//
+// With a zero SampleDuration, usage is computed from a single sample and
+// therefore reflects the average since boot. With a positive SampleDuration,
+// two samples are taken SampleDuration apart and usage is computed from the
+// counter delta, which reflects current utilization.
+//
+// With PerCore set, thresholds are evaluated against every core individually
+// instead of the aggregate, so a single saturated core is not averaged away.
 type CPUCheck struct {
-	Warning  threshold.Threshold
-	Critical threshold.Threshold
+	Warning        threshold.Threshold
+	Critical       threshold.Threshold
+	SampleDuration time.Duration
+	PerCore        bool
 }
 
-// NewCPUCheck creates a CPUCheck from warning and critical threshold strings.
-func NewCPUCheck(w, c string) (*CPUCheck, error) {
-	wt, err := threshold.Parse(w)
+// hottestCores is the number of busiest cores named in the per-core summary.
+const hottestCores = 3
+
+// NewCPUCheck creates a CPUCheck from warning and critical threshold strings,
+// an optional sample duration (zero selects single-sample mode), and whether
+// thresholds apply per core.
+func NewCPUCheck(w, c string, sampleDuration time.Duration, perCore bool) (*CPUCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
 	if err != nil {
 		return nil, fmt.Errorf("invalid warning threshold: %w", err)
 	}
-	ct, err := threshold.Parse(c)
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
 	if err != nil {
 		return nil, fmt.Errorf("invalid critical threshold: %w", err)
 	}
-	return &CPUCheck{Warning: wt, Critical: ct}, nil
+	if sampleDuration < 0 {
+		return nil, fmt.Errorf("invalid sample duration %s: must not be negative", sampleDuration)
+	}
+	return &CPUCheck{Warning: wt, Critical: ct, SampleDuration: sampleDuration, PerCore: perCore}, nil
 }
 
 // Name returns the check identifier used in Nagios output.
@@ -33,13 +56,92 @@ func (ch *CPUCheck) Name() string { return "CPU" }
 
 // Run executes the CPU check against the Talos API.
 func (ch *CPUCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
-	resp, err := client.SystemStat(ctx)
+	first, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	if ch.SampleDuration == 0 {
+		return ch.evaluate(first, "")
+	}
+
+	// The second sample must complete before the --timeout deadline.
+	waited, err := waitSampleInterval(ctx, ch.SampleDuration)
 	if err != nil {
 		return nil, err
 	}
+	if !waited {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Sample duration %s exceeds remaining timeout", ch.SampleDuration),
+		}, nil
+	}
 
-	if resp == nil || len(resp.GetMessages()) == 0 {
+	second, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	if len(second.cores) != len(first.cores) {
 		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Invalid data: CPU count changed between samples",
+		}, nil
+	}
+
+	delta := second.sub(first)
+	if delta.negative() {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Invalid data: CPU counters decreased between samples",
+		}, nil
+	}
+
+	return ch.evaluate(delta, fmt.Sprintf(" over %s", ch.SampleDuration))
+}
+
+// cpuSample holds the aggregate and per-core counters from one SystemStat call.
+type cpuSample struct {
+	total cpuTimes
+	cores []cpuTimes
+}
+
+// sub returns the per-counter difference s - o for the aggregate and each core.
+func (s cpuSample) sub(o cpuSample) cpuSample {
+	d := cpuSample{total: s.total.sub(o.total), cores: make([]cpuTimes, len(s.cores))}
+	for i := range s.cores {
+		d.cores[i] = s.cores[i].sub(o.cores[i])
+	}
+	return d
+}
+
+// negative reports whether any aggregate or per-core counter is below zero.
+func (s cpuSample) negative() bool {
+	if s.total.negative() {
+		return true
+	}
+	for _, c := range s.cores {
+		if c.negative() {
+			return true
+		}
+	}
+	return false
+}
+
+// sample fetches one SystemStat response and extracts the aggregate and
+// per-core CPU counters. A non-nil Result is returned when the response
+// cannot be used.
+func (ch *CPUCheck) sample(ctx context.Context, client TalosClient) (cpuSample, *output.Result, error) {
+	resp, err := client.SystemStat(ctx)
+	if err != nil {
+		return cpuSample{}, nil, err
+	}
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return cpuSample{}, &output.Result{
 			Status:    output.Unknown,
 			CheckName: ch.Name(),
 			Summary:   "Empty response from Talos API",
@@ -49,30 +151,51 @@ func (ch *CPUCheck) Run(ctx context.Context, client TalosClient) (*output.Result
 	stat := resp.GetMessages()[0]
 	cpu := stat.GetCpuTotal()
 	if cpu == nil {
-		return &output.Result{
+		return cpuSample{}, &output.Result{
 			Status:    output.Unknown,
 			CheckName: ch.Name(),
 			Summary:   "No CPU data in response",
 		}, nil
 	}
 
-	total := cpu.GetUser() + cpu.GetNice() + cpu.GetSystem() +
-		cpu.GetIdle() + cpu.GetIowait() + cpu.GetIrq() +
-		cpu.GetSoftIrq() + cpu.GetSteal()
+	if ch.PerCore && len(stat.GetCpu()) == 0 {
+		return cpuSample{}, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No per-core CPU data in response",
+		}, nil
+	}
+
+	s := cpuSample{total: newCPUTimes(cpu)}
+	for _, core := range stat.GetCpu() {
+		s.cores = append(s.cores, newCPUTimes(core))
+	}
+
+	return s, nil, nil
+}
 
+// evaluate computes usage from the given counters and applies thresholds.
+// In two-sample mode, the per-state breakdown is added to the perfdata.
+func (ch *CPUCheck) evaluate(s cpuSample, suffix string) (*output.Result, error) {
+	times := s.total
+	total := times.total()
 	if total == 0 {
+		summary := "Invalid data: total CPU time is zero"
+		if ch.SampleDuration > 0 {
+			summary = "Invalid data: no CPU time elapsed between samples"
+		}
 		return &output.Result{
 			Status:    output.Unknown,
 			CheckName: ch.Name(),
-			Summary:   "Invalid data: total CPU time is zero",
+			Summary:   summary,
 		}, nil
 	}
 
-	active := total - cpu.GetIdle() - cpu.GetIowait()
-	usagePct := (active / total) * 100
+	usagePct := roundPct(times.active() / total * 100)
 
-	// Round to 1 decimal place for display consistency.
-	usagePct = math.Round(usagePct*10) / 10
+	if ch.PerCore {
+		return ch.evaluatePerCore(s, usagePct, suffix)
+	}
 
 	status := output.OK
 	if ch.Critical.Violated(usagePct) {
@@ -81,20 +204,191 @@ func (ch *CPUCheck) Run(ctx context.Context, client TalosClient) (*output.Result
 		status = output.Warning
 	}
 
+	perfData := []output.PerfDatum{
+		{
+			Label: "cpu_usage",
+			Value: usagePct,
+			UOM:   "",
+			Warn:  ch.Warning.String(),
+			Crit:  ch.Critical.String(),
+			Min:   "0",
+			Max:   "100",
+		},
+	}
+	perfData = append(perfData, ch.breakdownPerfData(times)...)
+
 	return &output.Result{
 		Status:    status,
 		CheckName: ch.Name(),
-		Summary:   fmt.Sprintf("CPU usage %.1f%%", usagePct),
-		PerfData: []output.PerfDatum{
-			{
-				Label: "cpu_usage",
-				Value: usagePct,
-				UOM:   "",
-				Warn:  ch.Warning.String(),
-				Crit:  ch.Critical.String(),
-				Min:   "0",
-				Max:   "100",
-			},
-		},
+		Summary:   fmt.Sprintf("CPU usage %.1f%%%s", usagePct, suffix),
+		PerfData:  perfData,
+	}, nil
+}
+
+// evaluatePerCore applies thresholds to every core and reports the number of
+// cores in violation and the hottest cores. The aggregate usage is reported
+// for context but does not affect the status.
+func (ch *CPUCheck) evaluatePerCore(s cpuSample, usagePct float64, suffix string) (*output.Result, error) {
+	type coreUsage struct {
+		index  int
+		usage  float64
+		status output.Status
+	}
+
+	cores := make([]coreUsage, len(s.cores))
+	var critCount, warnCount int
+	status := output.OK
+
+	for i, c := range s.cores {
+		var pct float64
+		if t := c.total(); t > 0 {
+			pct = roundPct(c.active() / t * 100)
+		}
+
+		coreStatus := output.OK
+		if ch.Critical.Violated(pct) {
+			coreStatus = output.Critical
+			critCount++
+		} else if ch.Warning.Violated(pct) {
+			coreStatus = output.Warning
+			warnCount++
+		}
+		if coreStatus > status {
+			status = coreStatus
+		}
+
+		cores[i] = coreUsage{index: i, usage: pct, status: coreStatus}
+	}
+
+	warnStr := ch.Warning.String()
+	critStr := ch.Critical.String()
+
+	perfData := []output.PerfDatum{
+		{Label: "cpu_usage", Value: usagePct, Min: "0", Max: "100"},
+	}
+	perfData = append(perfData, ch.breakdownPerfData(s.total)...)
+	perfData = append(perfData,
+		output.PerfDatum{Label: "cpu_cores_warning", Value: float64(warnCount), Min: "0", Max: strconv.Itoa(len(cores))},
+		output.PerfDatum{Label: "cpu_cores_critical", Value: float64(critCount), Min: "0", Max: strconv.Itoa(len(cores))},
+	)
+	for _, c := range cores {
+		perfData = append(perfData, output.PerfDatum{
+			Label: fmt.Sprintf("cpu%d_usage", c.index),
+			Value: c.usage,
+			Warn:  warnStr,
+			Crit:  critStr,
+			Min:   "0",
+			Max:   "100",
+		})
+	}
+
+	// Sort by usage (descending), then core index, for deterministic output.
+	sorted := make([]coreUsage, len(cores))
+	copy(sorted, cores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].usage > sorted[j].usage
+	})
+
+	n := hottestCores
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	hottest := make([]string, n)
+	for i := 0; i < n; i++ {
+		hottest[i] = fmt.Sprintf("cpu%d %.1f%%", sorted[i].index, sorted[i].usage)
+	}
+
+	summary := fmt.Sprintf("CPU usage %.1f%%%s, %d/%d cores in violation, hottest: %s",
+		usagePct, suffix, critCount+warnCount, len(cores), strings.Join(hottest, ", "))
+
+	// Long text lists every violating core, hottest first.
+	var details strings.Builder
+	for _, c := range sorted {
+		if c.status == output.OK {
+			continue
+		}
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "cpu%d: usage=%.1f%%, status=%s", c.index, c.usage, c.status)
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary:   summary,
+		Details:   details.String(),
+		PerfData:  perfData,
 	}, nil
 }
+
+// breakdownPerfData returns the per-state perfdata entries emitted in
+// two-sample mode. In single-sample mode it returns nil.
+func (ch *CPUCheck) breakdownPerfData(times cpuTimes) []output.PerfDatum {
+	if ch.SampleDuration == 0 {
+		return nil
+	}
+	total := times.total()
+	return []output.PerfDatum{
+		{Label: "cpu_user", Value: roundPct(times.user / total * 100), Min: "0", Max: "100"},
+		{Label: "cpu_system", Value: roundPct(times.system / total * 100), Min: "0", Max: "100"},
+		{Label: "cpu_iowait", Value: roundPct(times.iowait / total * 100), Min: "0", Max: "100"},
+		{Label: "cpu_steal", Value: roundPct(times.steal / total * 100), Min: "0", Max: "100"},
+	}
+}
+
+// cpuTimes holds the CPU time counters used for usage calculations.
+// Guest time is already accounted for in user/nice and is not included.
+type cpuTimes struct {
+	user, nice, system, idle, iowait, irq, softirq, steal float64
+}
+
+// newCPUTimes extracts cpuTimes from a Talos CPUStat message.
+func newCPUTimes(s *machine.CPUStat) cpuTimes {
+	return cpuTimes{
+		user:    s.GetUser(),
+		nice:    s.GetNice(),
+		system:  s.GetSystem(),
+		idle:    s.GetIdle(),
+		iowait:  s.GetIowait(),
+		irq:     s.GetIrq(),
+		softirq: s.GetSoftIrq(),
+		steal:   s.GetSteal(),
+	}
+}
+
+// total returns the sum of all CPU time counters.
+func (t cpuTimes) total() float64 {
+	return t.user + t.nice + t.system + t.idle + t.iowait + t.irq + t.softirq + t.steal
+}
+
+// active returns the CPU time spent doing work (everything except idle and iowait).
+func (t cpuTimes) active() float64 {
+	return t.total() - t.idle - t.iowait
+}
+
+// sub returns the per-counter difference t - o.
+func (t cpuTimes) sub(o cpuTimes) cpuTimes {
+	return cpuTimes{
+		user:    t.user - o.user,
+		nice:    t.nice - o.nice,
+		system:  t.system - o.system,
+		idle:    t.idle - o.idle,
+		iowait:  t.iowait - o.iowait,
+		irq:     t.irq - o.irq,
+		softirq: t.softirq - o.softirq,
+		steal:   t.steal - o.steal,
+	}
+}
+
+// negative reports whether any counter is below zero, which happens when
+// the node rebooted (or counters wrapped) between two samples.
+func (t cpuTimes) negative() bool {
+	return t.user < 0 || t.nice < 0 || t.system < 0 || t.idle < 0 ||
+		t.iowait < 0 || t.irq < 0 || t.softirq < 0 || t.steal < 0
+}
+
+// roundPct rounds a percentage to 1 decimal place for display consistency.
+func roundPct(v float64) float64 {
+	return math.Round(v*10) / 10
+}
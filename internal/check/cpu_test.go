@@ -4,49 +4,32 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
 // mockCPUClient implements TalosClient for CPU check testing.
+// When samples is set, successive SystemStat calls return successive entries.
 type mockCPUClient struct {
-	resp *machine.SystemStatResponse
-	err  error
+	stubClient
+
+	resp    *machine.SystemStatResponse
+	samples []*machine.SystemStatResponse
+	calls   int
+	err     error
 }
 
 func (m *mockCPUClient) SystemStat(_ context.Context) (*machine.SystemStatResponse, error) {
+	if len(m.samples) > 0 {
+		resp := m.samples[m.calls%len(m.samples)]
+		m.calls++
+		return resp, m.err
+	}
 	return m.resp, m.err
 }
 
-func (m *mockCPUClient) Memory(context.Context) (*machine.MemoryResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) Mounts(context.Context) (*machine.MountsResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockCPUClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
-	return nil, nil
-}
-
 func TestNewCPUCheck(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,11 +41,12 @@ func TestNewCPUCheck(t *testing.T) {
 		{name: "valid ranges", warn: "~:75", crit: "~:95", wantErr: false},
 		{name: "invalid warning", warn: "abc", crit: "90", wantErr: true},
 		{name: "invalid critical", warn: "80", crit: "xyz", wantErr: true},
+		{name: "duration suffix rejected", warn: "10m", crit: "90", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewCPUCheck(tt.warn, tt.crit)
+			ch, err := NewCPUCheck(tt.warn, tt.crit, 0, false)
 			if tt.wantErr {
 				if err == nil {
 					t.Fatal("expected error, got nil")
@@ -204,7 +188,7 @@ func TestCPUCheckRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewCPUCheck(tt.warn, tt.crit)
+			ch, err := NewCPUCheck(tt.warn, tt.crit, 0, false)
 			if err != nil {
 				t.Fatalf("NewCPUCheck: %v", err)
 			}
@@ -242,7 +226,7 @@ func TestCPUCheckRun(t *testing.T) {
 }
 
 func TestCPUCheckPerfData(t *testing.T) {
-	ch, err := NewCPUCheck("80", "90")
+	ch, err := NewCPUCheck("80", "90", 0, false)
 	if err != nil {
 		t.Fatalf("NewCPUCheck: %v", err)
 	}
@@ -327,7 +311,7 @@ func TestCPUCheckOutputFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewCPUCheck(tt.warn, tt.crit)
+			ch, err := NewCPUCheck(tt.warn, tt.crit, 0, false)
 			if err != nil {
 				t.Fatalf("NewCPUCheck: %v", err)
 			}
@@ -343,6 +327,332 @@ func TestCPUCheckOutputFormat(t *testing.T) {
 	}
 }
 
+func TestCPUCheckSampleDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		warn       string
+		crit       string
+		samples    []*machine.SystemStatResponse
+		wantStatus output.Status
+		wantSubstr string
+	}{
+		{
+			name: "CRITICAL - pegged CPU hidden by lifetime average",
+			warn: "80", crit: "90",
+			samples: []*machine.SystemStatResponse{
+				// Lifetime average: 10% usage.
+				makeSystemStatResponse(10000, 0, 0, 90000, 0, 0, 0, 0),
+				// Delta: user=950, idle=50 → 95% usage.
+				makeSystemStatResponse(10950, 0, 0, 90050, 0, 0, 0, 0),
+			},
+			wantStatus: output.Critical,
+			wantSubstr: "CPU usage 95.0% over 1ms",
+		},
+		{
+			name: "OK - idle CPU despite busy lifetime average",
+			warn: "80", crit: "90",
+			samples: []*machine.SystemStatResponse{
+				makeSystemStatResponse(90000, 0, 0, 10000, 0, 0, 0, 0),
+				// Delta: user=100, idle=900 → 10% usage.
+				makeSystemStatResponse(90100, 0, 0, 10900, 0, 0, 0, 0),
+			},
+			wantStatus: output.OK,
+			wantSubstr: "CPU usage 10.0% over 1ms",
+		},
+		{
+			name: "UNKNOWN - no CPU time elapsed",
+			warn: "80", crit: "90",
+			samples: []*machine.SystemStatResponse{
+				makeSystemStatResponse(1000, 0, 0, 9000, 0, 0, 0, 0),
+				makeSystemStatResponse(1000, 0, 0, 9000, 0, 0, 0, 0),
+			},
+			wantStatus: output.Unknown,
+			wantSubstr: "no CPU time elapsed between samples",
+		},
+		{
+			name: "UNKNOWN - counters decreased (reboot between samples)",
+			warn: "80", crit: "90",
+			samples: []*machine.SystemStatResponse{
+				makeSystemStatResponse(1000, 0, 0, 9000, 0, 0, 0, 0),
+				makeSystemStatResponse(10, 0, 0, 90, 0, 0, 0, 0),
+			},
+			wantStatus: output.Unknown,
+			wantSubstr: "CPU counters decreased between samples",
+		},
+		{
+			name: "UNKNOWN - second sample empty",
+			warn: "80", crit: "90",
+			samples: []*machine.SystemStatResponse{
+				makeSystemStatResponse(1000, 0, 0, 9000, 0, 0, 0, 0),
+				{},
+			},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewCPUCheck(tt.warn, tt.crit, time.Millisecond, false)
+			if err != nil {
+				t.Fatalf("NewCPUCheck: %v", err)
+			}
+
+			client := &mockCPUClient{samples: tt.samples}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if client.calls != 2 {
+				t.Errorf("SystemStat calls = %d, want 2", client.calls)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			resultStr := result.String()
+			if !contains(resultStr, tt.wantSubstr) {
+				t.Errorf("output %q does not contain %q", resultStr, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestCPUCheckSampleDurationPerfData(t *testing.T) {
+	ch, err := NewCPUCheck("80", "90", time.Millisecond, false)
+	if err != nil {
+		t.Fatalf("NewCPUCheck: %v", err)
+	}
+
+	client := &mockCPUClient{
+		samples: []*machine.SystemStatResponse{
+			makeSystemStatResponse(1000, 0, 1000, 8000, 0, 0, 0, 0),
+			// Delta: user=400, system=200, idle=250, iowait=100, steal=50, total=1000.
+			makeSystemStatResponse(1400, 0, 1200, 8250, 100, 0, 0, 50),
+		},
+	}
+
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "TALOS CPU OK - CPU usage 65.0% over 1ms | cpu_usage=65;80;90;0;100 cpu_user=40;;;0;100 cpu_system=20;;;0;100 cpu_iowait=10;;;0;100 cpu_steal=5;;;0;100"
+	if got := result.String(); got != want {
+		t.Errorf("output:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestCPUCheckSampleDurationExceedsTimeout(t *testing.T) {
+	ch, err := NewCPUCheck("80", "90", time.Hour, false)
+	if err != nil {
+		t.Fatalf("NewCPUCheck: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client := &mockCPUClient{
+		resp: makeSystemStatResponse(1000, 0, 0, 9000, 0, 0, 0, 0),
+	}
+
+	result, err := ch.Run(ctx, client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.Unknown {
+		t.Errorf("status = %v, want %v", result.Status, output.Unknown)
+	}
+	if !contains(result.Summary, "exceeds remaining timeout") {
+		t.Errorf("summary %q does not mention timeout", result.Summary)
+	}
+}
+
+func TestNewCPUCheckNegativeSampleDuration(t *testing.T) {
+	if _, err := NewCPUCheck("80", "90", -time.Second, false); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCPUCheckPerCore(t *testing.T) {
+	tests := []struct {
+		name        string
+		warn        string
+		crit        string
+		resp        *machine.SystemStatResponse
+		wantStatus  output.Status
+		wantSubstr  string
+		wantDetails string
+	}{
+		{
+			name: "OK - all cores below thresholds",
+			warn: "80", crit: "90",
+			resp:       makePerCoreSystemStatResponse(10, 20, 30, 40),
+			wantStatus: output.OK,
+			wantSubstr: "CPU usage 25.0%, 0/4 cores in violation, hottest: cpu3 40.0%, cpu2 30.0%, cpu1 20.0%",
+		},
+		{
+			name: "CRITICAL - single runaway core hidden by aggregate",
+			warn: "80", crit: "90",
+			resp:        makePerCoreSystemStatResponse(5, 5, 100, 5, 5, 5, 5, 5),
+			wantStatus:  output.Critical,
+			wantSubstr:  "CPU usage 16.9%, 1/8 cores in violation, hottest: cpu2 100.0%, cpu0 5.0%, cpu1 5.0%",
+			wantDetails: "cpu2: usage=100.0%, status=CRITICAL",
+		},
+		{
+			name: "WARNING - one core above warning",
+			warn: "80", crit: "90",
+			resp:        makePerCoreSystemStatResponse(85, 10),
+			wantStatus:  output.Warning,
+			wantSubstr:  "1/2 cores in violation, hottest: cpu0 85.0%, cpu1 10.0%",
+			wantDetails: "cpu0: usage=85.0%, status=WARNING",
+		},
+		{
+			name: "CRITICAL - mixed violations listed hottest first",
+			warn: "80", crit: "90",
+			resp:        makePerCoreSystemStatResponse(85, 95, 10),
+			wantStatus:  output.Critical,
+			wantSubstr:  "2/3 cores in violation",
+			wantDetails: "cpu1: usage=95.0%, status=CRITICAL\ncpu0: usage=85.0%, status=WARNING",
+		},
+		{
+			name: "UNKNOWN - no per-core data",
+			warn: "80", crit: "90",
+			resp:       makeSystemStatResponse(500, 0, 0, 500, 0, 0, 0, 0),
+			wantStatus: output.Unknown,
+			wantSubstr: "No per-core CPU data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewCPUCheck(tt.warn, tt.crit, 0, true)
+			if err != nil {
+				t.Fatalf("NewCPUCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), &mockCPUClient{resp: tt.resp})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestCPUCheckPerCorePerfData(t *testing.T) {
+	ch, err := NewCPUCheck("80", "90", 0, true)
+	if err != nil {
+		t.Fatalf("NewCPUCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockCPUClient{
+		resp: makePerCoreSystemStatResponse(95, 15),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "cpu_usage=55;;;0;100 cpu_cores_warning=0;;;0;2 cpu_cores_critical=1;;;0;2 cpu0_usage=95;80;90;0;100 cpu1_usage=15;80;90;0;100"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestCPUCheckPerCoreSampleDuration(t *testing.T) {
+	ch, err := NewCPUCheck("80", "90", time.Millisecond, true)
+	if err != nil {
+		t.Fatalf("NewCPUCheck: %v", err)
+	}
+
+	t.Run("delta per core", func(t *testing.T) {
+		client := &mockCPUClient{
+			samples: []*machine.SystemStatResponse{
+				makePerCoreSystemStatResponse(10, 10),
+				// Each core adds 100 ticks: core 0 at 99% busy, core 1 at 1% busy.
+				addPerCoreTicks(makePerCoreSystemStatResponse(10, 10), []float64{99, 1}),
+			},
+		}
+
+		result, err := ch.Run(context.Background(), client)
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if result.Status != output.Critical {
+			t.Errorf("status = %v, want %v", result.Status, output.Critical)
+		}
+		if !contains(result.Summary, "hottest: cpu0 99.0%, cpu1 1.0%") {
+			t.Errorf("summary %q does not name hottest core", result.Summary)
+		}
+	})
+
+	t.Run("UNKNOWN - CPU count changed", func(t *testing.T) {
+		client := &mockCPUClient{
+			samples: []*machine.SystemStatResponse{
+				makePerCoreSystemStatResponse(10, 10),
+				makePerCoreSystemStatResponse(10, 10, 10),
+			},
+		}
+
+		result, err := ch.Run(context.Background(), client)
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if result.Status != output.Unknown {
+			t.Errorf("status = %v, want %v", result.Status, output.Unknown)
+		}
+		if !contains(result.Summary, "CPU count changed between samples") {
+			t.Errorf("summary %q does not mention CPU count change", result.Summary)
+		}
+	})
+}
+
+// makePerCoreSystemStatResponse builds a SystemStatResponse with one CPUStat
+// per usage percentage (100 ticks per core) and a matching aggregate.
+func makePerCoreSystemStatResponse(usages ...float64) *machine.SystemStatResponse {
+	total := &machine.CPUStat{}
+	cores := make([]*machine.CPUStat, len(usages))
+	for i, u := range usages {
+		cores[i] = &machine.CPUStat{User: u, Idle: 100 - u}
+		total.User += u
+		total.Idle += 100 - u
+	}
+	return &machine.SystemStatResponse{
+		Messages: []*machine.SystemStat{
+			{
+				CpuTotal: total,
+				Cpu:      cores,
+			},
+		},
+	}
+}
+
+// addPerCoreTicks adds 100 ticks to every core of resp, of which busy[i] are
+// user time, and updates the aggregate accordingly.
+func addPerCoreTicks(resp *machine.SystemStatResponse, busy []float64) *machine.SystemStatResponse {
+	stat := resp.GetMessages()[0]
+	for i, b := range busy {
+		stat.Cpu[i].User += b
+		stat.Cpu[i].Idle += 100 - b
+		stat.CpuTotal.User += b
+		stat.CpuTotal.Idle += 100 - b
+	}
+	return resp
+}
+
 // makeSystemStatResponse builds a SystemStatResponse with a single aggregate CPUStat.
 func makeSystemStatResponse(user, nice, system, idle, iowait, irq, softirq, steal float64) *machine.SystemStatResponse {
 	return &machine.SystemStatResponse{
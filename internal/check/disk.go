@@ -4,30 +4,92 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
 	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
-// DiskCheck monitors disk utilization for a specific mount point via the Talos Mounts API.
+// DiskCheck monitors disk utilization of one or more mount points via the
+// Talos Mounts API.
+//
+// Mounts may be literal paths or glob patterns (path.Match syntax, so "*"
+// does not cross "/"). With All set, every mount point with a non-zero size
+// is evaluated. ExcludeFSTypes and ExcludeMounts remove mounts from the
+// selection; since the Mounts API does not report the filesystem type,
+// ExcludeFSTypes is matched against the type column of /proc/mounts, read
+// via the Read API only when the filter is set.
+//
+// A single literal mount produces the original single-mount output with
+// disk_usage/disk_used/disk_total perfdata. Any other selection produces
+// per-mount perfdata labels (disk_usage_var, ...) and reports the worst
+// mount in the summary. In both modes a literal mount that is missing or
+// reports zero capacity is UNKNOWN; globs and All skip zero-capacity pseudo
+// filesystems.
+//
+// Units selects what the thresholds are evaluated against: the usage
+// percentage (DiskUnitsPercent), available bytes (DiskUnitsBytesFree) or
+// used bytes (DiskUnitsBytesUsed). Byte thresholds accept size suffixes
+// ("10GiB:") and are attached to the disk_free or disk_used perfdata entry
+// instead of disk_usage.
 type DiskCheck struct {
-	Warning  threshold.Threshold
-	Critical threshold.Threshold
-	Mount    string
+	Warning        threshold.Threshold
+	Critical       threshold.Threshold
+	Units          string
+	Mounts         []string
+	All            bool
+	ExcludeFSTypes []string
+	ExcludeMounts  []string
 }
 
-// NewDiskCheck creates a DiskCheck from warning and critical threshold strings and a mount point.
-func NewDiskCheck(w, c, mount string) (*DiskCheck, error) {
-	wt, err := threshold.Parse(w)
+// Threshold units accepted by DiskCheck.
+const (
+	DiskUnitsPercent   = "percent"
+	DiskUnitsBytesFree = "bytes-free"
+	DiskUnitsBytesUsed = "bytes-used"
+)
+
+// NewDiskCheck creates a DiskCheck from warning and critical threshold
+// strings, the threshold units, the mount points or glob patterns to check,
+// whether to check all mounts, and exclusion filters.
+func NewDiskCheck(w, c, units string, mounts []string, all bool, excludeFSTypes, excludeMounts []string) (*DiskCheck, error) {
+	// Percentages take bare numbers; byte units also accept size suffixes.
+	unit := threshold.UnitNone
+	switch units {
+	case DiskUnitsPercent:
+	case DiskUnitsBytesFree, DiskUnitsBytesUsed:
+		unit = threshold.UnitBytes
+	default:
+		return nil, fmt.Errorf("invalid units %q: must be %s, %s or %s",
+			units, DiskUnitsPercent, DiskUnitsBytesFree, DiskUnitsBytesUsed)
+	}
+
+	wt, err := threshold.ParseUnit(w, unit)
 	if err != nil {
 		return nil, fmt.Errorf("invalid warning threshold: %w", err)
 	}
-	ct, err := threshold.Parse(c)
+	ct, err := threshold.ParseUnit(c, unit)
 	if err != nil {
 		return nil, fmt.Errorf("invalid critical threshold: %w", err)
 	}
-	return &DiskCheck{Warning: wt, Critical: ct, Mount: mount}, nil
+	for _, p := range append(append([]string{}, mounts...), excludeMounts...) {
+		if _, err := path.Match(p, "/"); err != nil {
+			return nil, fmt.Errorf("invalid mount pattern %q: %w", p, err)
+		}
+	}
+	return &DiskCheck{
+		Warning:        wt,
+		Critical:       ct,
+		Units:          units,
+		Mounts:         mounts,
+		All:            all,
+		ExcludeFSTypes: excludeFSTypes,
+		ExcludeMounts:  excludeMounts,
+	}, nil
 }
 
 // Name returns the check identifier used in Nagios output.
@@ -58,79 +120,402 @@ func (ch *DiskCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 		}, nil
 	}
 
+	if ch.single() {
+		return ch.runSingle(stats), nil
+	}
+
+	var fstypes map[string]procMount
+	if len(ch.ExcludeFSTypes) > 0 {
+		data, err := client.Read(ctx, procMountsPath)
+		if err != nil {
+			return nil, err
+		}
+		fstypes = parseProcMounts(data)
+		if len(fstypes) == 0 {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("No entries in %s", procMountsPath),
+			}, nil
+		}
+	}
+	return ch.runMulti(stats, fstypes), nil
+}
+
+// single reports whether the check selects exactly one literal mount point.
+func (ch *DiskCheck) single() bool {
+	return !ch.All && len(ch.Mounts) == 1 && !isGlob(ch.Mounts[0])
+}
+
+// runSingle evaluates the one configured mount point.
+func (ch *DiskCheck) runSingle(stats []*machine.MountStat) *output.Result {
+	mount := ch.Mounts[0]
+
 	// Find the mount point matching the requested path.
 	for _, ms := range stats {
-		if ms.GetMountedOn() != ch.Mount {
+		if ms.GetMountedOn() != mount {
 			continue
 		}
 
-		size := ms.GetSize()
-		available := ms.GetAvailable()
-
-		if size == 0 {
+		if ms.GetSize() == 0 {
 			return &output.Result{
 				Status:    output.Unknown,
 				CheckName: ch.Name(),
-				Summary:   fmt.Sprintf("Invalid data: total capacity is zero for %s", ch.Mount),
-			}, nil
+				Summary:   fmt.Sprintf("Invalid data: total capacity is zero for %s", mount),
+			}
 		}
 
-		used := size - available
-		usagePct := (float64(used) / float64(size)) * 100
+		u := ch.evaluate(ms)
 
-		// Round to 1 decimal place for display consistency.
-		usagePct = math.Round(usagePct*10) / 10
+		return &output.Result{
+			Status:    u.status,
+			CheckName: ch.Name(),
+			Summary:   u.describe(),
+			PerfData:  ch.perfData(u, ""),
+		}
+	}
 
-		status := output.OK
-		if ch.Critical.Violated(usagePct) {
-			status = output.Critical
-		} else if ch.Warning.Violated(usagePct) {
-			status = output.Warning
+	// Mount point not found in response.
+	return &output.Result{
+		Status:    output.Unknown,
+		CheckName: ch.Name(),
+		Summary:   fmt.Sprintf("Mount point %s not found", mount),
+	}
+}
+
+// runMulti evaluates every selected mount point and reports the worst.
+// table holds the /proc/mounts entries used by the filesystem type filter;
+// it is nil when no type is excluded.
+func (ch *DiskCheck) runMulti(stats []*machine.MountStat, table map[string]procMount) *output.Result {
+	fstypeSet := toSet(ch.ExcludeFSTypes)
+
+	// Literal mounts that must be present; globs may legitimately match nothing.
+	var missing []string
+	literal := make(map[string]struct{})
+	for _, m := range ch.Mounts {
+		if !isGlob(m) {
+			missing = append(missing, m)
+			literal[m] = struct{}{}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(stats))
+	var usages []diskUsage
+	var zeroSize []string
+
+	for _, ms := range stats {
+		mount := ms.GetMountedOn()
+
+		if !ch.All && !matchAny(ch.Mounts, mount) {
+			continue
+		}
+
+		// The same mount point can be listed more than once (over-mounts);
+		// only the first entry is evaluated.
+		if _, ok := seen[mount]; ok {
+			continue
 		}
+		seen[mount] = struct{}{}
+		missing = removeString(missing, mount)
 
-		sizeStr := strconv.FormatUint(size, 10)
+		// Mounts absent from /proc/mounts have no known type and are kept.
+		if entry, ok := table[mount]; ok {
+			if _, excluded := fstypeSet[entry.fstype]; excluded {
+				continue
+			}
+		}
+		if matchAny(ch.ExcludeMounts, mount) {
+			continue
+		}
 
+		// Pseudo filesystems (proc, sysfs, cgroup, ...) report no capacity.
+		// That is only expected for glob and --all matches; a mount asked
+		// for by name is reported, as in single-mount mode.
+		if ms.GetSize() == 0 {
+			if _, ok := literal[mount]; ok {
+				zeroSize = append(zeroSize, mount)
+			}
+			continue
+		}
+
+		usages = append(usages, ch.evaluate(ms))
+	}
+
+	if len(usages) == 0 && len(missing) == 0 && len(zeroSize) == 0 {
 		return &output.Result{
-			Status:    status,
+			Status:    output.Unknown,
 			CheckName: ch.Name(),
-			Summary: fmt.Sprintf("%s usage %.1f%% (%s / %s)",
-				ch.Mount, usagePct, output.HumanBytes(used), output.HumanBytes(size)),
-			PerfData: []output.PerfDatum{
-				{
-					Label: "disk_usage",
-					Value: usagePct,
-					UOM:   "",
-					Warn:  ch.Warning.String(),
-					Crit:  ch.Critical.String(),
-					Min:   "0",
-					Max:   "100",
-				},
-				{
-					Label: "disk_used",
-					Value: float64(used),
-					UOM:   "B",
-					Warn:  "",
-					Crit:  "",
-					Min:   "0",
-					Max:   sizeStr,
-				},
-				{
-					Label: "disk_total",
-					Value: float64(size),
-					UOM:   "B",
-					Warn:  "",
-					Crit:  "",
-					Min:   "0",
-					Max:   "",
-				},
-			},
-		}, nil
+			Summary:   "No mount points matched",
+		}
+	}
+
+	// Perfdata in mount point order.
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].mount < usages[j].mount
+	})
+
+	var perfData []output.PerfDatum
+	status := output.OK
+	var offenders []diskUsage
+	for _, u := range usages {
+		perfData = append(perfData, ch.perfData(u, "_"+mountLabel(u.mount))...)
+		status = max(status, u.status)
+		if u.status != output.OK {
+			offenders = append(offenders, u)
+		}
+	}
+
+	// Worst first: highest status, then fullest.
+	sort.SliceStable(offenders, func(i, j int) bool {
+		if offenders[i].status != offenders[j].status {
+			return offenders[i].status > offenders[j].status
+		}
+		return ch.fuller(offenders[i], offenders[j])
+	})
+
+	var details strings.Builder
+	for _, m := range missing {
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: not found, status=%s", m, output.Unknown)
+		status = max(status, output.Unknown)
+	}
+	for _, m := range zeroSize {
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: total capacity is zero, status=%s", m, output.Unknown)
+		status = max(status, output.Unknown)
+	}
+	for _, u := range offenders {
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: %s, status=%s", u.mount, u.describeValue(), u.status)
+	}
+
+	var summary string
+	switch {
+	case len(usages) == 0:
+		var parts []string
+		if len(missing) > 0 {
+			parts = append(parts, fmt.Sprintf("Mount point %s not found", strings.Join(missing, ", ")))
+		}
+		if len(zeroSize) > 0 {
+			parts = append(parts, fmt.Sprintf("Invalid data: total capacity is zero for %s", strings.Join(zeroSize, ", ")))
+		}
+		summary = strings.Join(parts, ", ")
+	case len(offenders) > 0:
+		summary = fmt.Sprintf("%d/%d mounts above threshold, worst: %s",
+			len(offenders), len(usages), offenders[0].describe())
+	default:
+		fullest := usages[0]
+		for _, u := range usages[1:] {
+			if ch.fuller(u, fullest) {
+				fullest = u
+			}
+		}
+		summary = fmt.Sprintf("%d mounts OK, fullest: %s", len(usages), fullest.describe())
+	}
+	if len(usages) > 0 {
+		if len(missing) > 0 {
+			summary += fmt.Sprintf(", %d not found", len(missing))
+		}
+		if len(zeroSize) > 0 {
+			summary += fmt.Sprintf(", %d with zero capacity", len(zeroSize))
+		}
 	}
 
-	// Mount point not found in response.
 	return &output.Result{
-		Status:    output.Unknown,
+		Status:    status,
 		CheckName: ch.Name(),
-		Summary:   fmt.Sprintf("Mount point %s not found", ch.Mount),
-	}, nil
+		Summary:   summary,
+		Details:   details.String(),
+		PerfData:  perfData,
+	}
+}
+
+// diskUsage holds the evaluated usage of one mount point.
+type diskUsage struct {
+	mount    string
+	size     uint64
+	used     uint64
+	free     uint64
+	pct      float64
+	status   output.Status
+	showFree bool // lead with free bytes (bytes-free units)
+}
+
+// describe returns "<mount> usage <pct>% (<used> / <size>)", or
+// "<mount> <free> free, usage ..." when free bytes are evaluated.
+func (u diskUsage) describe() string {
+	if u.showFree {
+		return fmt.Sprintf("%s %s free, usage %s", u.mount, output.HumanBytes(u.free), u.usageValue())
+	}
+	return fmt.Sprintf("%s usage %s", u.mount, u.usageValue())
+}
+
+// describeValue returns "<pct>% (<used> / <size>)", prefixed with
+// "<free> free, " when free bytes are evaluated.
+func (u diskUsage) describeValue() string {
+	if u.showFree {
+		return fmt.Sprintf("%s free, %s", output.HumanBytes(u.free), u.usageValue())
+	}
+	return u.usageValue()
+}
+
+// usageValue returns "<pct>% (<used> / <size>)".
+func (u diskUsage) usageValue() string {
+	return fmt.Sprintf("%.1f%% (%s / %s)", u.pct, output.HumanBytes(u.used), output.HumanBytes(u.size))
+}
+
+// fuller reports whether a is closer to its thresholds than b: less free
+// space in bytes-free mode, otherwise a higher usage.
+func (ch *DiskCheck) fuller(a, b diskUsage) bool {
+	switch ch.Units {
+	case DiskUnitsBytesFree:
+		return a.free < b.free
+	case DiskUnitsBytesUsed:
+		return a.used > b.used
+	default:
+		return a.pct > b.pct
+	}
+}
+
+// evaluate computes the usage of a mount with non-zero size and applies the
+// thresholds.
+func (ch *DiskCheck) evaluate(ms *machine.MountStat) diskUsage {
+	size := ms.GetSize()
+	free := ms.GetAvailable()
+	used := size - free
+	usagePct := (float64(used) / float64(size)) * 100
+
+	// Round to 1 decimal place for display consistency.
+	usagePct = math.Round(usagePct*10) / 10
+
+	value := usagePct
+	switch ch.Units {
+	case DiskUnitsBytesFree:
+		value = float64(free)
+	case DiskUnitsBytesUsed:
+		value = float64(used)
+	}
+
+	status := output.OK
+	if ch.Critical.Violated(value) {
+		status = output.Critical
+	} else if ch.Warning.Violated(value) {
+		status = output.Warning
+	}
+
+	return diskUsage{
+		mount:    ms.GetMountedOn(),
+		size:     size,
+		used:     used,
+		free:     free,
+		pct:      usagePct,
+		status:   status,
+		showFree: ch.Units == DiskUnitsBytesFree,
+	}
+}
+
+// perfData returns the usage, used and total perfdata entries for u, with
+// suffix appended to each label. The thresholds are attached to the entry
+// matching the configured units; bytes-free units add a disk_free entry.
+func (ch *DiskCheck) perfData(u diskUsage, suffix string) []output.PerfDatum {
+	var pctWarn, pctCrit, usedWarn, usedCrit string
+	switch ch.Units {
+	case DiskUnitsBytesUsed:
+		usedWarn, usedCrit = ch.Warning.String(), ch.Critical.String()
+	case DiskUnitsPercent:
+		pctWarn, pctCrit = ch.Warning.String(), ch.Critical.String()
+	}
+
+	pd := []output.PerfDatum{
+		{
+			Label: "disk_usage" + suffix,
+			Value: u.pct,
+			UOM:   "",
+			Warn:  pctWarn,
+			Crit:  pctCrit,
+			Min:   "0",
+			Max:   "100",
+		},
+		{
+			Label: "disk_used" + suffix,
+			Value: float64(u.used),
+			UOM:   "B",
+			Warn:  usedWarn,
+			Crit:  usedCrit,
+			Min:   "0",
+			Max:   strconv.FormatUint(u.size, 10),
+		},
+	}
+
+	if ch.Units == DiskUnitsBytesFree {
+		pd = append(pd, output.PerfDatum{
+			Label: "disk_free" + suffix,
+			Value: float64(u.free),
+			UOM:   "B",
+			Warn:  ch.Warning.String(),
+			Crit:  ch.Critical.String(),
+			Min:   "0",
+			Max:   strconv.FormatUint(u.size, 10),
+		})
+	}
+
+	return append(pd, output.PerfDatum{
+		Label: "disk_total" + suffix,
+		Value: float64(u.size),
+		UOM:   "B",
+		Warn:  "",
+		Crit:  "",
+		Min:   "0",
+		Max:   "",
+	})
+}
+
+// mountLabel converts a mount point into a perfdata label suffix:
+// "/" becomes "root", "/var/lib/etcd" becomes "var_lib_etcd".
+func mountLabel(mount string) string {
+	trimmed := strings.Trim(mount, "/")
+	if trimmed == "" {
+		return "root"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, trimmed)
+}
+
+// isGlob reports whether p contains path.Match metacharacters.
+func isGlob(p string) bool {
+	return strings.ContainsAny(p, `*?[\`)
+}
+
+// matchAny reports whether name matches any of the path.Match patterns.
+// Patterns are validated in NewDiskCheck, so match errors are ignored.
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString returns items without any element equal to s.
+func removeString(items []string, s string) []string {
+	out := items[:0]
+	for _, item := range items {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
 }
@@ -11,40 +11,22 @@ import (
 
 // mockDiskClient implements TalosClient for Disk check testing.
 type mockDiskClient struct {
-	resp *machine.MountsResponse
-	err  error
-}
-
-func (m *mockDiskClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
-	return nil, nil
-}
+	stubClient
 
-func (m *mockDiskClient) Memory(context.Context) (*machine.MemoryResponse, error) {
-	return nil, nil
+	resp     *machine.MountsResponse
+	err      error
+	readData string
+	readErr  error
+	reads    int
 }
 
 func (m *mockDiskClient) Mounts(_ context.Context) (*machine.MountsResponse, error) {
 	return m.resp, m.err
 }
 
-func (m *mockDiskClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockDiskClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
-	return nil, nil
-}
-
-func (m *mockDiskClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockDiskClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockDiskClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
-	return nil, nil
+func (m *mockDiskClient) Read(context.Context, string) ([]byte, error) {
+	m.reads++
+	return []byte(m.readData), m.readErr
 }
 
 func TestNewDiskCheck(t *testing.T) {
@@ -53,17 +35,29 @@ func TestNewDiskCheck(t *testing.T) {
 		warn    string
 		crit    string
 		mount   string
+		units   string
 		wantErr bool
 	}{
 		{name: "valid defaults", warn: "80", crit: "90", mount: "/", wantErr: false},
 		{name: "valid ranges", warn: "~:75", crit: "~:95", mount: "/var", wantErr: false},
 		{name: "invalid warning", warn: "abc", crit: "90", mount: "/", wantErr: true},
 		{name: "invalid critical", warn: "80", crit: "xyz", mount: "/", wantErr: true},
+		{name: "valid glob", warn: "80", crit: "90", mount: "/var/*", wantErr: false},
+		{name: "malformed glob", warn: "80", crit: "90", mount: "/var/[", wantErr: true},
+		{name: "bytes-free sizes", warn: "10GiB:", crit: "5GiB:", mount: "/var", units: DiskUnitsBytesFree, wantErr: false},
+		{name: "bytes-used sizes", warn: "~:40GB", crit: "~:45GB", mount: "/var", units: DiskUnitsBytesUsed, wantErr: false},
+		{name: "percent rejects size", warn: "10GiB:", crit: "5GiB:", mount: "/var", units: DiskUnitsPercent, wantErr: true},
+		{name: "unknown size unit", warn: "10XB:", crit: "5GiB:", mount: "/var", units: DiskUnitsBytesFree, wantErr: true},
+		{name: "unknown units", warn: "80", crit: "90", mount: "/var", units: "inodes", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewDiskCheck(tt.warn, tt.crit, tt.mount)
+			units := tt.units
+			if units == "" {
+				units = DiskUnitsPercent
+			}
+			ch, err := NewDiskCheck(tt.warn, tt.crit, units, []string{tt.mount}, false, nil, nil)
 			if tt.wantErr {
 				if err == nil {
 					t.Fatal("expected error, got nil")
@@ -76,8 +70,8 @@ func TestNewDiskCheck(t *testing.T) {
 			if ch.Name() != "DISK" {
 				t.Errorf("Name() = %q, want %q", ch.Name(), "DISK")
 			}
-			if ch.Mount != tt.mount {
-				t.Errorf("Mount = %q, want %q", ch.Mount, tt.mount)
+			if len(ch.Mounts) != 1 || ch.Mounts[0] != tt.mount {
+				t.Errorf("Mounts = %q, want [%q]", ch.Mounts, tt.mount)
 			}
 		})
 	}
@@ -234,7 +228,7 @@ func TestDiskCheckRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewDiskCheck(tt.warn, tt.crit, tt.mount)
+			ch, err := NewDiskCheck(tt.warn, tt.crit, DiskUnitsPercent, []string{tt.mount}, false, nil, nil)
 			if err != nil {
 				t.Fatalf("NewDiskCheck: %v", err)
 			}
@@ -271,7 +265,7 @@ func TestDiskCheckRun(t *testing.T) {
 }
 
 func TestDiskCheckPerfData(t *testing.T) {
-	ch, err := NewDiskCheck("80", "90", "/")
+	ch, err := NewDiskCheck("80", "90", DiskUnitsPercent, []string{"/"}, false, nil, nil)
 	if err != nil {
 		t.Fatalf("NewDiskCheck: %v", err)
 	}
@@ -396,7 +390,7 @@ func TestDiskCheckOutputFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewDiskCheck(tt.warn, tt.crit, tt.mount)
+			ch, err := NewDiskCheck(tt.warn, tt.crit, DiskUnitsPercent, []string{tt.mount}, false, nil, nil)
 			if err != nil {
 				t.Fatalf("NewDiskCheck: %v", err)
 			}
@@ -412,6 +406,320 @@ func TestDiskCheckOutputFormat(t *testing.T) {
 	}
 }
 
+func TestDiskCheckMultiMount(t *testing.T) {
+	const gb = 1073741824
+
+	// A typical Talos node: two data mounts, an ephemeral etcd mount, and
+	// pseudo/virtual filesystems.
+	node := makeMountStatsResponse(
+		&machine.MountStat{Filesystem: "/dev/sda4", MountedOn: "/", Size: 20 * gb, Available: 11 * gb},       // 45.0%
+		&machine.MountStat{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 50 * gb, Available: 8 * gb},     // 84.0%
+		&machine.MountStat{Filesystem: "/dev/sdb1", MountedOn: "/var/lib", Size: 10 * gb, Available: gb / 2}, // 95.0%
+		&machine.MountStat{Filesystem: "/dev/sda6", MountedOn: "/var/log", Size: 50 * gb, Available: 8 * gb},
+		&machine.MountStat{Filesystem: "none", MountedOn: "/run", Size: gb, Available: 0}, // 100.0%, tmpfs
+		&machine.MountStat{Filesystem: "proc", MountedOn: "/proc"},
+		&machine.MountStat{Filesystem: "/dev/sdc1", MountedOn: "/mnt/empty"},
+	)
+
+	// The sources of /run and /var/log do not name their type.
+	procMounts := "/dev/sda4 / xfs ro,relatime 0 0\n" +
+		"/dev/sda6 /var xfs rw,relatime 0 0\n" +
+		"/dev/sdb1 /var/lib ext4 rw,relatime 0 0\n" +
+		"/dev/sda6 /var/log xfs rw,relatime 0 0\n" +
+		"none /run tmpfs rw,nosuid 0 0\n" +
+		"proc /proc proc rw,nosuid 0 0\n"
+
+	tests := []struct {
+		name           string
+		mounts         []string
+		all            bool
+		excludeFSTypes []string
+		excludeMounts  []string
+		wantStatus     output.Status
+		wantSummary    string
+		wantDetails    string
+	}{
+		{
+			name:        "WARNING - two literal mounts",
+			mounts:      []string{"/", "/var"},
+			wantStatus:  output.Warning,
+			wantSummary: "1/2 mounts above threshold, worst: /var usage 84.0% (42.00 GB / 50.00 GB)",
+			wantDetails: "/var: 84.0% (42.00 GB / 50.00 GB), status=WARNING",
+		},
+		{
+			name:        "OK - single literal mount keeps single-mount output",
+			mounts:      []string{"/"},
+			wantStatus:  output.OK,
+			wantSummary: "/ usage 45.0% (9.00 GB / 20.00 GB)",
+		},
+		{
+			name:        "CRITICAL - glob matches children only",
+			mounts:      []string{"/var/*"},
+			wantStatus:  output.Critical,
+			wantSummary: "2/2 mounts above threshold, worst: /var/lib usage 95.0% (9.50 GB / 10.00 GB)",
+			wantDetails: "/var/lib: 95.0% (9.50 GB / 10.00 GB), status=CRITICAL\n" +
+				"/var/log: 84.0% (42.00 GB / 50.00 GB), status=WARNING",
+		},
+		{
+			name:          "WARNING - glob with excluded mount",
+			mounts:        []string{"/var/*"},
+			excludeMounts: []string{"/var/lib"},
+			wantStatus:    output.Warning,
+			wantSummary:   "1/1 mounts above threshold, worst: /var/log usage 84.0% (42.00 GB / 50.00 GB)",
+			wantDetails:   "/var/log: 84.0% (42.00 GB / 50.00 GB), status=WARNING",
+		},
+		{
+			name:           "CRITICAL - all mounts, tmpfs excluded, proc skipped",
+			all:            true,
+			excludeFSTypes: []string{"tmpfs"},
+			wantStatus:     output.Critical,
+			wantSummary:    "3/4 mounts above threshold, worst: /var/lib usage 95.0% (9.50 GB / 10.00 GB)",
+			wantDetails: "/var/lib: 95.0% (9.50 GB / 10.00 GB), status=CRITICAL\n" +
+				"/var: 84.0% (42.00 GB / 50.00 GB), status=WARNING\n" +
+				"/var/log: 84.0% (42.00 GB / 50.00 GB), status=WARNING",
+		},
+		{
+			name:           "OK - all mounts with everything noisy excluded",
+			all:            true,
+			excludeFSTypes: []string{"tmpfs"},
+			excludeMounts:  []string{"/var", "/var/*"},
+			wantStatus:     output.OK,
+			wantSummary:    "1 mounts OK, fullest: / usage 45.0% (9.00 GB / 20.00 GB)",
+		},
+		{
+			name:        "UNKNOWN - literal mount missing alongside a match",
+			mounts:      []string{"/", "/data"},
+			wantStatus:  output.Unknown,
+			wantSummary: "1 mounts OK, fullest: / usage 45.0% (9.00 GB / 20.00 GB), 1 not found",
+			wantDetails: "/data: not found, status=UNKNOWN",
+		},
+		{
+			name:        "UNKNOWN - glob matches nothing",
+			mounts:      []string{"/opt/*"},
+			wantStatus:  output.Unknown,
+			wantSummary: "No mount points matched",
+		},
+		{
+			name:           "CRITICAL - fstype matched by type, not source",
+			mounts:         []string{"/var/*", "/run"},
+			excludeFSTypes: []string{"ext4"},
+			wantStatus:     output.Critical,
+			wantSummary:    "2/2 mounts above threshold, worst: /run usage 100.0% (1.00 GB / 1.00 GB)",
+			wantDetails: "/run: 100.0% (1.00 GB / 1.00 GB), status=CRITICAL\n" +
+				"/var/log: 84.0% (42.00 GB / 50.00 GB), status=WARNING",
+		},
+		{
+			name:        "UNKNOWN - literal mount with zero capacity alongside a match",
+			mounts:      []string{"/", "/mnt/empty"},
+			wantStatus:  output.Unknown,
+			wantSummary: "1 mounts OK, fullest: / usage 45.0% (9.00 GB / 20.00 GB), 1 with zero capacity",
+			wantDetails: "/mnt/empty: total capacity is zero, status=UNKNOWN",
+		},
+		{
+			name:        "UNKNOWN - only literal mounts with zero capacity",
+			mounts:      []string{"/proc", "/mnt/empty"},
+			wantStatus:  output.Unknown,
+			wantSummary: "Invalid data: total capacity is zero for /proc, /mnt/empty",
+			wantDetails: "/proc: total capacity is zero, status=UNKNOWN\n/mnt/empty: total capacity is zero, status=UNKNOWN",
+		},
+		{
+			name:        "OK - glob skips zero capacity mounts",
+			mounts:      []string{"/", "/mnt/*"},
+			wantStatus:  output.OK,
+			wantSummary: "1 mounts OK, fullest: / usage 45.0% (9.00 GB / 20.00 GB)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDiskCheck("80", "90", DiskUnitsPercent, tt.mounts, tt.all, tt.excludeFSTypes, tt.excludeMounts)
+			if err != nil {
+				t.Fatalf("NewDiskCheck: %v", err)
+			}
+
+			client := &mockDiskClient{resp: node, readData: procMounts}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			// /proc/mounts is only read for the filesystem type filter.
+			if wantReads := min(len(tt.excludeFSTypes), 1); client.reads != wantReads {
+				t.Errorf("Read calls = %d, want %d", client.reads, wantReads)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestDiskCheckExcludeFSTypeRead(t *testing.T) {
+	resp := makeMountStatsResponse(
+		&machine.MountStat{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 100, Available: 50},
+	)
+
+	ch, err := NewDiskCheck("80", "90", DiskUnitsPercent, nil, true, []string{"tmpfs"}, nil)
+	if err != nil {
+		t.Fatalf("NewDiskCheck: %v", err)
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		_, err := ch.Run(context.Background(), &mockDiskClient{resp: resp, readErr: fmt.Errorf("permission denied")})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("empty /proc/mounts", func(t *testing.T) {
+		result, err := ch.Run(context.Background(), &mockDiskClient{resp: resp})
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if result.Status != output.Unknown || result.Summary != "No entries in /proc/mounts" {
+			t.Errorf("got %v %q, want UNKNOWN %q", result.Status, result.Summary, "No entries in /proc/mounts")
+		}
+	})
+
+	t.Run("mount missing from /proc/mounts is kept", func(t *testing.T) {
+		result, err := ch.Run(context.Background(), &mockDiskClient{resp: resp, readData: "none /run tmpfs rw 0 0\n"})
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if want := "1 mounts OK, fullest: /var usage 50.0% (50 B / 100 B)"; result.Summary != want {
+			t.Errorf("summary = %q, want %q", result.Summary, want)
+		}
+	})
+}
+
+func TestDiskCheckMultiMountPerfData(t *testing.T) {
+	ch, err := NewDiskCheck("80", "90", DiskUnitsPercent, []string{"/", "/var/lib/etcd"}, false, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDiskCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockDiskClient{
+		resp: makeMultiMountsResponse(
+			mountEntry{path: "/var/lib/etcd", size: 1000, available: 750},
+			mountEntry{path: "/", size: 2000, available: 1000},
+		),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "disk_usage_root=50;80;90;0;100 disk_used_root=1000B;;;0;2000 disk_total_root=2000B;;;0; " +
+		"disk_usage_var_lib_etcd=25;80;90;0;100 disk_used_var_lib_etcd=250B;;;0;1000 disk_total_var_lib_etcd=1000B;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestDiskCheckUnits(t *testing.T) {
+	const gib = 1 << 30
+
+	tests := []struct {
+		name         string
+		warn         string
+		crit         string
+		units        string
+		mounts       []string
+		resp         *machine.MountsResponse
+		wantStatus   output.Status
+		wantSummary  string
+		wantPerfData string
+	}{
+		{
+			name: "bytes-free OK on large disk",
+			warn: "10GiB:", crit: "5GiB:", units: DiskUnitsBytesFree, mounts: []string{"/var"},
+			// 95% used, but 200 GiB still free.
+			resp:        makeMountsResponse("/var", 4000*gib, 200*gib),
+			wantStatus:  output.OK,
+			wantSummary: "/var 200.00 GB free, usage 95.0% (3.71 TB / 3.91 TB)",
+			wantPerfData: "disk_usage=95;;;0;100 disk_used=4080218931200B;;;0;4294967296000 " +
+				"disk_free=214748364800B;10737418240:;5368709120:;0;4294967296000 disk_total=4294967296000B;;;0;",
+		},
+		{
+			name: "bytes-free CRITICAL on small disk",
+			warn: "10GiB:", crit: "5GiB:", units: DiskUnitsBytesFree, mounts: []string{"/var"},
+			resp:        makeMountsResponse("/var", 20*gib, 4*gib),
+			wantStatus:  output.Critical,
+			wantSummary: "/var 4.00 GB free, usage 80.0% (16.00 GB / 20.00 GB)",
+			wantPerfData: "disk_usage=80;;;0;100 disk_used=17179869184B;;;0;21474836480 " +
+				"disk_free=4294967296B;10737418240:;5368709120:;0;21474836480 disk_total=21474836480B;;;0;",
+		},
+		{
+			name: "bytes-used WARNING",
+			warn: "~:15GiB", crit: "~:18GiB", units: DiskUnitsBytesUsed, mounts: []string{"/var"},
+			resp:        makeMountsResponse("/var", 20*gib, 4*gib),
+			wantStatus:  output.Warning,
+			wantSummary: "/var usage 80.0% (16.00 GB / 20.00 GB)",
+			wantPerfData: "disk_usage=80;;;0;100 disk_used=17179869184B;~:16106127360;~:19327352832;0;21474836480 " +
+				"disk_total=21474836480B;;;0;",
+		},
+		{
+			name: "bytes-free multi-mount worst is least free",
+			warn: "10GiB:", crit: "5GiB:", units: DiskUnitsBytesFree, mounts: []string{"/var", "/data"},
+			resp: makeMountStatsResponse(
+				&machine.MountStat{Filesystem: "/dev/sda6", MountedOn: "/var", Size: 20 * gib, Available: 8 * gib},
+				&machine.MountStat{Filesystem: "/dev/sdb1", MountedOn: "/data", Size: 4000 * gib, Available: 6 * gib},
+			),
+			wantStatus:  output.Warning,
+			wantSummary: "2/2 mounts above threshold, worst: /data 6.00 GB free, usage 99.9% (3.90 TB / 3.91 TB)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDiskCheck(tt.warn, tt.crit, tt.units, tt.mounts, false, nil, nil)
+			if err != nil {
+				t.Fatalf("NewDiskCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), &mockDiskClient{resp: tt.resp})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if tt.wantPerfData != "" {
+				if got := output.FormatPerfData(result.PerfData); got != tt.wantPerfData {
+					t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, tt.wantPerfData)
+				}
+			}
+		})
+	}
+}
+
+func TestMountLabel(t *testing.T) {
+	tests := []struct {
+		mount string
+		want  string
+	}{
+		{"/", "root"},
+		{"/var", "var"},
+		{"/var/lib/etcd", "var_lib_etcd"},
+		{"/var/lib/kubelet/pods/x-y", "var_lib_kubelet_pods_x_y"},
+	}
+	for _, tt := range tests {
+		if got := mountLabel(tt.mount); got != tt.want {
+			t.Errorf("mountLabel(%q) = %q, want %q", tt.mount, got, tt.want)
+		}
+	}
+}
+
 // mountEntry describes a single mount point for building test responses.
 type mountEntry struct {
 	path      string
@@ -456,3 +764,12 @@ func makeMultiMountsResponse(entries ...mountEntry) *machine.MountsResponse {
 		},
 	}
 }
+
+// makeMountStatsResponse builds a MountsResponse from explicit MountStat entries.
+func makeMountStatsResponse(stats ...*machine.MountStat) *machine.MountsResponse {
+	return &machine.MountsResponse{
+		Messages: []*machine.Mounts{
+			{Stats: stats},
+		},
+	}
+}
@@ -0,0 +1,326 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// sectorSize is the unit of the sector counters in /proc/diskstats, which is
+// always 512 bytes regardless of the device's physical sector size.
+const sectorSize = 512
+
+// skippedDevicePrefixes lists device name prefixes skipped when no --device
+// filter is given. loop, ram and zram are memory- or file-backed and never
+// saturate a disk; dm-* are device-mapper volumes whose I/O is also counted
+// on the disks below them.
+var skippedDevicePrefixes = []string{"loop", "ram", "zram", "dm-"}
+
+// partitionName matches partitions of SCSI/virtio/Xen disks (sda1, vdb2) and
+// of NVMe and MMC disks (nvme0n1p2, mmcblk0p1). A partition's I/O is also
+// counted on its parent disk, so without --device one saturated disk would
+// be reported once per partition.
+var partitionName = regexp.MustCompile(`^(?:(?:sd|vd|hd|xvd)[a-z]+\d+|(?:nvme\d+n\d+|mmcblk\d+)p\d+)$`)
+
+// DiskIOCheck monitors block device I/O via the Talos DiskStats API.
+//
+// With a zero SampleDuration, the check reports the cumulative counters as
+// perfdata only; utilization cannot be derived from a single sample, so the
+// result is always OK. With a positive SampleDuration, two samples are taken
+// SampleDuration apart and per-device IOPS, throughput and utilization
+// (share of the interval the device was busy) are computed from the delta,
+// divided by the time measured between the two responses.
+// Thresholds apply to utilization.
+type DiskIOCheck struct {
+	Warning        threshold.Threshold
+	Critical       threshold.Threshold
+	Devices        []string
+	SampleDuration time.Duration
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+// NewDiskIOCheck creates a DiskIOCheck from utilization threshold strings,
+// an optional device filter, and an optional sample duration (zero selects
+// counters-only mode).
+func NewDiskIOCheck(w, c string, devices []string, sampleDuration time.Duration) (*DiskIOCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid critical threshold: %w", err)
+	}
+	if sampleDuration < 0 {
+		return nil, fmt.Errorf("invalid sample duration %s: must not be negative", sampleDuration)
+	}
+	return &DiskIOCheck{Warning: wt, Critical: ct, Devices: devices, SampleDuration: sampleDuration, now: time.Now}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *DiskIOCheck) Name() string { return "DISK-IO" }
+
+// Run executes the disk I/O check against the Talos API.
+func (ch *DiskIOCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	first, firstAt, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	if ch.SampleDuration == 0 {
+		return ch.counters(first), nil
+	}
+
+	// The second sample must complete before the --timeout deadline.
+	waited, err := waitSampleInterval(ctx, ch.SampleDuration)
+	if err != nil {
+		return nil, err
+	}
+	if !waited {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Sample duration %s exceeds remaining timeout", ch.SampleDuration),
+		}, nil
+	}
+
+	second, secondAt, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	return ch.rates(first, second, secondAt.Sub(firstAt)), nil
+}
+
+// sample fetches one DiskStats response and returns the devices selected by
+// the filter, sorted by name, and the time the response arrived. A non-nil
+// Result is returned when the response is unusable.
+func (ch *DiskIOCheck) sample(ctx context.Context, client TalosClient) ([]*machine.DiskStat, time.Time, *output.Result, error) {
+	resp, err := client.DiskStats(ctx)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+	at := ch.now()
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return nil, at, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	deviceSet := toSet(ch.Devices)
+
+	var devices []*machine.DiskStat
+	for _, d := range resp.GetMessages()[0].GetDevices() {
+		name := d.GetName()
+		if len(deviceSet) > 0 {
+			if _, ok := deviceSet[name]; !ok {
+				continue
+			}
+		} else if isSkippedDevice(name) {
+			continue
+		}
+		devices = append(devices, d)
+	}
+
+	if len(devices) == 0 {
+		summary := "No block devices in response"
+		if len(deviceSet) > 0 {
+			summary = fmt.Sprintf("No block devices matched --device %s", strings.Join(ch.Devices, ", "))
+		}
+		return nil, at, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   summary,
+		}, nil
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].GetName() < devices[j].GetName()
+	})
+
+	return devices, at, nil, nil
+}
+
+// counters builds the single-sample result: cumulative counters as perfdata
+// and no threshold evaluation.
+func (ch *DiskIOCheck) counters(devices []*machine.DiskStat) *output.Result {
+	perfData := make([]output.PerfDatum, 0, 5*len(devices))
+	for _, d := range devices {
+		name := d.GetName()
+		perfData = append(perfData,
+			output.PerfDatum{Label: name + "_read_ops", Value: float64(d.GetReadCompleted()), UOM: "c", Min: "0"},
+			output.PerfDatum{Label: name + "_write_ops", Value: float64(d.GetWriteCompleted()), UOM: "c", Min: "0"},
+			output.PerfDatum{Label: name + "_read_sectors", Value: float64(d.GetReadSectors()), UOM: "c", Min: "0"},
+			output.PerfDatum{Label: name + "_write_sectors", Value: float64(d.GetWriteSectors()), UOM: "c", Min: "0"},
+			output.PerfDatum{Label: name + "_io_time_ms", Value: float64(d.GetIoTimeMs()), UOM: "c", Min: "0"},
+		)
+	}
+
+	return &output.Result{
+		Status:    output.OK,
+		CheckName: ch.Name(),
+		Summary:   fmt.Sprintf("%d devices, cumulative counters only (set --sample-duration to evaluate utilization)", len(devices)),
+		PerfData:  perfData,
+	}
+}
+
+// diskRates holds the per-second rates of one device over the sample interval.
+type diskRates struct {
+	name       string
+	readIOPS   float64
+	writeIOPS  float64
+	readBytes  float64 // bytes per second
+	writeBytes float64 // bytes per second
+	util       float64 // percent of the interval the device was busy
+	status     output.Status
+}
+
+// rates builds the two-sample result from the counter deltas between first
+// and second, which arrived elapsed apart. The measured gap includes the
+// second RPC's round trip, so rates are not inflated by dividing by the
+// shorter nominal SampleDuration.
+func (ch *DiskIOCheck) rates(first, second []*machine.DiskStat, elapsed time.Duration) *output.Result {
+	prev := make(map[string]*machine.DiskStat, len(first))
+	for _, d := range first {
+		prev[d.GetName()] = d
+	}
+
+	secs := elapsed.Seconds()
+	ms := secs * 1000
+
+	var all []diskRates
+	for _, cur := range second {
+		old, ok := prev[cur.GetName()]
+		if !ok {
+			// Hot-plugged between samples; no baseline to compute rates from.
+			continue
+		}
+
+		if cur.GetReadCompleted() < old.GetReadCompleted() ||
+			cur.GetWriteCompleted() < old.GetWriteCompleted() ||
+			cur.GetReadSectors() < old.GetReadSectors() ||
+			cur.GetWriteSectors() < old.GetWriteSectors() ||
+			cur.GetIoTimeMs() < old.GetIoTimeMs() {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   "Invalid data: disk counters decreased between samples",
+			}
+		}
+
+		r := diskRates{
+			name:       cur.GetName(),
+			readIOPS:   float64(cur.GetReadCompleted()-old.GetReadCompleted()) / secs,
+			writeIOPS:  float64(cur.GetWriteCompleted()-old.GetWriteCompleted()) / secs,
+			readBytes:  float64(cur.GetReadSectors()-old.GetReadSectors()) * sectorSize / secs,
+			writeBytes: float64(cur.GetWriteSectors()-old.GetWriteSectors()) * sectorSize / secs,
+			// io_time advances in jiffies, so its delta can exceed the
+			// measured interval by a tick.
+			util: roundPct(min(float64(cur.GetIoTimeMs()-old.GetIoTimeMs())/ms*100, 100)),
+		}
+
+		r.status = output.OK
+		if ch.Critical.Violated(r.util) {
+			r.status = output.Critical
+		} else if ch.Warning.Violated(r.util) {
+			r.status = output.Warning
+		}
+
+		all = append(all, r)
+	}
+
+	warnStr := ch.Warning.String()
+	critStr := ch.Critical.String()
+
+	status := output.OK
+	var violations []diskRates
+	perfData := make([]output.PerfDatum, 0, 5*len(all))
+	for _, r := range all {
+		status = max(status, r.status)
+		if r.status != output.OK {
+			violations = append(violations, r)
+		}
+		perfData = append(perfData,
+			output.PerfDatum{Label: r.name + "_util", Value: r.util, Warn: warnStr, Crit: critStr, Min: "0", Max: "100"},
+			output.PerfDatum{Label: r.name + "_read_iops", Value: roundPct(r.readIOPS), Min: "0"},
+			output.PerfDatum{Label: r.name + "_write_iops", Value: roundPct(r.writeIOPS), Min: "0"},
+			output.PerfDatum{Label: r.name + "_read_throughput", Value: float64(uint64(r.readBytes)), UOM: "B", Min: "0"},
+			output.PerfDatum{Label: r.name + "_write_throughput", Value: float64(uint64(r.writeBytes)), UOM: "B", Min: "0"},
+		)
+	}
+
+	if len(all) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Invalid data: no block device present in both samples",
+		}
+	}
+
+	over := fmt.Sprintf(" over %s", ch.SampleDuration)
+
+	if len(violations) == 0 {
+		busiest := all[0]
+		for _, r := range all[1:] {
+			if r.util > busiest.util {
+				busiest = r
+			}
+		}
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary: fmt.Sprintf("%d/%d devices OK, busiest: %s %.1f%%%s",
+				len(all), len(all), busiest.name, busiest.util, over),
+			PerfData: perfData,
+		}
+	}
+
+	// Busiest violating device first.
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].util > violations[j].util
+	})
+
+	names := make([]string, len(violations))
+	var details strings.Builder
+	for i, v := range violations {
+		names[i] = fmt.Sprintf("%s %.1f%%", v.name, v.util)
+		if i > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: util=%.1f%%, read_iops=%.1f, write_iops=%.1f, read=%s/s, write=%s/s, status=%s",
+			v.name, v.util, v.readIOPS, v.writeIOPS,
+			output.HumanBytes(uint64(v.readBytes)), output.HumanBytes(uint64(v.writeBytes)), v.status)
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d/%d devices above utilization threshold: %s%s",
+			len(violations), len(all), strings.Join(names, ", "), over),
+		Details:  details.String(),
+		PerfData: perfData,
+	}
+}
+
+// isSkippedDevice reports whether name is a virtual device, a device-mapper
+// volume or a partition, which are not checked unless --device names them.
+func isSkippedDevice(name string) bool {
+	for _, p := range skippedDevicePrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return partitionName.MatchString(name)
+}
@@ -0,0 +1,394 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockDiskIOClient implements TalosClient for Disk I/O check testing.
+// When samples is set, successive DiskStats calls return successive entries.
+type mockDiskIOClient struct {
+	stubClient
+
+	resp    *machine.DiskStatsResponse
+	samples []*machine.DiskStatsResponse
+	calls   int
+	err     error
+}
+
+func (m *mockDiskIOClient) DiskStats(_ context.Context) (*machine.DiskStatsResponse, error) {
+	if len(m.samples) > 0 {
+		resp := m.samples[m.calls%len(m.samples)]
+		m.calls++
+		return resp, m.err
+	}
+	return m.resp, m.err
+}
+
+func TestNewDiskIOCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		warn     string
+		crit     string
+		duration time.Duration
+		wantErr  bool
+	}{
+		{name: "valid defaults", warn: "80", crit: "90", wantErr: false},
+		{name: "valid with sample duration", warn: "80", crit: "90", duration: 2 * time.Second, wantErr: false},
+		{name: "invalid warning", warn: "abc", crit: "90", wantErr: true},
+		{name: "invalid critical", warn: "80", crit: "xyz", wantErr: true},
+		{name: "duration suffix rejected", warn: "10m", crit: "90", wantErr: true},
+		{name: "negative sample duration", warn: "80", crit: "90", duration: -time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDiskIOCheck(tt.warn, tt.crit, nil, tt.duration)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "DISK-IO" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "DISK-IO")
+			}
+		})
+	}
+}
+
+func TestDiskIOCheckCounters(t *testing.T) {
+	tests := []struct {
+		name       string
+		devices    []string
+		client     *mockDiskIOClient
+		wantStatus output.Status
+		wantSubstr string
+	}{
+		{
+			name: "OK - counters only, virtual devices skipped",
+			client: &mockDiskIOClient{resp: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "sda", ReadCompleted: 100},
+				&machine.DiskStat{Name: "loop0"},
+				&machine.DiskStat{Name: "nvme0n1", WriteCompleted: 50},
+				&machine.DiskStat{Name: "zram0"},
+			)},
+			wantStatus: output.OK,
+			wantSubstr: "2 devices, cumulative counters only",
+		},
+		{
+			name: "OK - partitions and device-mapper volumes skipped",
+			client: &mockDiskIOClient{resp: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "sda"},
+				&machine.DiskStat{Name: "sda1"},
+				&machine.DiskStat{Name: "vdb2"},
+				&machine.DiskStat{Name: "nvme0n1"},
+				&machine.DiskStat{Name: "nvme0n1p2"},
+				&machine.DiskStat{Name: "mmcblk0p1"},
+				&machine.DiskStat{Name: "dm-0"},
+			)},
+			wantStatus: output.OK,
+			wantSubstr: "2 devices, cumulative counters only",
+		},
+		{
+			name:    "OK - device filter includes partition",
+			devices: []string{"nvme0n1p2"},
+			client: &mockDiskIOClient{resp: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1"},
+				&machine.DiskStat{Name: "nvme0n1p2"},
+			)},
+			wantStatus: output.OK,
+			wantSubstr: "1 devices",
+		},
+		{
+			name:    "OK - device filter includes loop device",
+			devices: []string{"loop0"},
+			client: &mockDiskIOClient{resp: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "sda"},
+				&machine.DiskStat{Name: "loop0"},
+			)},
+			wantStatus: output.OK,
+			wantSubstr: "1 devices",
+		},
+		{
+			name:    "UNKNOWN - device filter matches nothing",
+			devices: []string{"sdz"},
+			client: &mockDiskIOClient{resp: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "sda"},
+			)},
+			wantStatus: output.Unknown,
+			wantSubstr: "No block devices matched --device sdz",
+		},
+		{
+			name:       "UNKNOWN - no devices",
+			client:     &mockDiskIOClient{resp: makeDiskStatsResponse()},
+			wantStatus: output.Unknown,
+			wantSubstr: "No block devices in response",
+		},
+		{
+			name:       "UNKNOWN - nil response",
+			client:     &mockDiskIOClient{resp: nil},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name:       "UNKNOWN - empty messages",
+			client:     &mockDiskIOClient{resp: &machine.DiskStatsResponse{}},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name:       "error from client",
+			client:     &mockDiskIOClient{err: fmt.Errorf("connection refused")},
+			wantStatus: -1, // not checked; error path
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDiskIOCheck("80", "90", tt.devices, 0)
+			if err != nil {
+				t.Fatalf("NewDiskIOCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), tt.client)
+
+			// Error path: client returns error.
+			if tt.client.err != nil {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if result.CheckName != "DISK-IO" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "DISK-IO")
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestDiskIOCheckCountersPerfData(t *testing.T) {
+	ch, err := NewDiskIOCheck("80", "90", nil, 0)
+	if err != nil {
+		t.Fatalf("NewDiskIOCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockDiskIOClient{
+		resp: makeDiskStatsResponse(&machine.DiskStat{
+			Name:          "sda",
+			ReadCompleted: 100, WriteCompleted: 200,
+			ReadSectors: 3000, WriteSectors: 4000,
+			IoTimeMs: 5000,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "sda_read_ops=100c;;;0; sda_write_ops=200c;;;0; " +
+		"sda_read_sectors=3000c;;;0; sda_write_sectors=4000c;;;0; sda_io_time_ms=5000c;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+// sampleClock returns a clock for DiskIOCheck.now that advances by step on
+// every call, so two samples are measured step apart.
+func sampleClock(step time.Duration) func() time.Time {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestDiskIOCheckSampleDuration(t *testing.T) {
+	// The check divides by the time measured between the two responses
+	// (elapsed, defaulting to the sample interval), so 10ms apart with 5ms
+	// of io_time is 50% utilization.
+	const interval = 10 * time.Millisecond
+
+	base := func() *machine.DiskStatsResponse {
+		return makeDiskStatsResponse(
+			&machine.DiskStat{Name: "nvme0n1", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1000},
+			&machine.DiskStat{Name: "sda", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1000},
+		)
+	}
+
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		second      *machine.DiskStatsResponse
+		wantStatus  output.Status
+		wantSubstr  string
+		wantDetails string
+	}{
+		{
+			name: "OK - both devices below threshold",
+			second: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1", ReadCompleted: 1010, WriteCompleted: 1020, ReadSectors: 1100, WriteSectors: 1200, IoTimeMs: 1005},
+				&machine.DiskStat{Name: "sda", ReadCompleted: 1001, WriteCompleted: 1000, ReadSectors: 1008, WriteSectors: 1000, IoTimeMs: 1001},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "2/2 devices OK, busiest: nvme0n1 50.0% over 10ms",
+		},
+		{
+			name: "WARNING - one device above warning",
+			second: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1000},
+				&machine.DiskStat{Name: "sda", ReadCompleted: 1002, WriteCompleted: 1003, ReadSectors: 1020, WriteSectors: 1040, IoTimeMs: 1009},
+			),
+			wantStatus:  output.Warning,
+			wantSubstr:  "1/2 devices above utilization threshold: sda 90.0% over 10ms",
+			wantDetails: "sda: util=90.0%, read_iops=200.0, write_iops=300.0, read=1000.00 KB/s, write=1.95 MB/s, status=WARNING",
+		},
+		{
+			// 12ms of io_time in 10ms is clamped to 100%.
+			name: "CRITICAL - saturated device",
+			second: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1", ReadCompleted: 1000, WriteCompleted: 1100, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1012},
+				&machine.DiskStat{Name: "sda", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1009},
+			),
+			wantStatus: output.Critical,
+			wantSubstr: "2/2 devices above utilization threshold: nvme0n1 100.0%, sda 90.0% over 10ms",
+			wantDetails: "nvme0n1: util=100.0%, read_iops=0.0, write_iops=10000.0, read=0 B/s, write=0 B/s, status=CRITICAL\n" +
+				"sda: util=90.0%, read_iops=0.0, write_iops=0.0, read=0 B/s, write=0 B/s, status=WARNING",
+		},
+		{
+			name:    "OK - slow RPC widens the measured interval",
+			elapsed: 25 * time.Millisecond,
+			second: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1", ReadCompleted: 1010, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1012},
+				&machine.DiskStat{Name: "sda", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1000},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "2/2 devices OK, busiest: nvme0n1 48.0% over 10ms",
+		},
+		{
+			name: "UNKNOWN - counters decreased",
+			second: makeDiskStatsResponse(
+				&machine.DiskStat{Name: "nvme0n1", IoTimeMs: 10},
+				&machine.DiskStat{Name: "sda", ReadCompleted: 1000, WriteCompleted: 1000, ReadSectors: 1000, WriteSectors: 1000, IoTimeMs: 1000},
+			),
+			wantStatus: output.Unknown,
+			wantSubstr: "disk counters decreased between samples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDiskIOCheck("80", "90", nil, interval)
+			if err != nil {
+				t.Fatalf("NewDiskIOCheck: %v", err)
+			}
+			elapsed := tt.elapsed
+			if elapsed == 0 {
+				elapsed = interval
+			}
+			ch.now = sampleClock(elapsed)
+
+			client := &mockDiskIOClient{samples: []*machine.DiskStatsResponse{base(), tt.second}}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if client.calls != 2 {
+				t.Errorf("DiskStats calls = %d, want 2", client.calls)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestDiskIOCheckSampleDurationPerfData(t *testing.T) {
+	ch, err := NewDiskIOCheck("80", "90", []string{"sda"}, time.Second/100)
+	if err != nil {
+		t.Fatalf("NewDiskIOCheck: %v", err)
+	}
+	ch.now = sampleClock(time.Second / 100)
+
+	client := &mockDiskIOClient{samples: []*machine.DiskStatsResponse{
+		makeDiskStatsResponse(&machine.DiskStat{Name: "sda"}),
+		makeDiskStatsResponse(&machine.DiskStat{
+			Name:          "sda",
+			ReadCompleted: 5, WriteCompleted: 10,
+			ReadSectors: 20, WriteSectors: 40,
+			IoTimeMs: 3,
+		}),
+	}}
+
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "sda_util=30;80;90;0;100 sda_read_iops=500;;;0; sda_write_iops=1000;;;0; " +
+		"sda_read_throughput=1024000B;;;0; sda_write_throughput=2048000B;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestDiskIOCheckSampleDurationExceedsTimeout(t *testing.T) {
+	ch, err := NewDiskIOCheck("80", "90", nil, time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskIOCheck: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client := &mockDiskIOClient{resp: makeDiskStatsResponse(&machine.DiskStat{Name: "sda"})}
+	result, err := ch.Run(ctx, client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.Unknown {
+		t.Errorf("status = %v, want %v", result.Status, output.Unknown)
+	}
+	if !contains(result.Summary, "exceeds remaining timeout") {
+		t.Errorf("summary %q does not mention timeout", result.Summary)
+	}
+}
+
+// makeDiskStatsResponse builds a DiskStatsResponse with the given devices.
+func makeDiskStatsResponse(devices ...*machine.DiskStat) *machine.DiskStatsResponse {
+	return &machine.DiskStatsResponse{
+		Messages: []*machine.DiskStats{
+			{Devices: devices},
+		},
+	}
+}
@@ -0,0 +1,277 @@
+package check
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+)
+
+// dmesgTopN is the number of matching messages listed in the long text.
+const dmesgTopN = 10
+
+// dmesgCursorSlack is how far the timestamp of the cursor message may move
+// between runs. Talos stamps kernel messages with the boot time plus the
+// kernel's monotonic offset, and estimates the boot time afresh for every
+// request, so the same message can come back with a slightly different time.
+const dmesgCursorSlack = 2 * time.Second
+
+// DmesgRule is a pattern searched for in kernel messages and the status a
+// match raises.
+type DmesgRule struct {
+	Name     string // perfdata label suffix, [a-z0-9_]+
+	Severity output.Status
+	Pattern  *regexp.Regexp
+}
+
+// DefaultDmesgRules are the rules applied unless --no-default-rules is set,
+// as "<name>:<severity>:<regex>" strings.
+var DefaultDmesgRules = []string{
+	`oom:warning:(?i)out of memory: killed process`,
+	`io_error:critical:I/O error, dev |Buffer I/O error on dev`,
+	`fs_error:critical:EXT4-fs error|EXT4-fs \(\S+\): Remounting filesystem read-only|XFS \(\S+\): (Corruption|metadata I/O error|log I/O error|Filesystem has been shut down)`,
+	`hung_task:warning:blocked for more than \d+ seconds`,
+	`mce:warning:mce: \[Hardware Error\]|Machine check events logged`,
+}
+
+var dmesgRuleName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ParseDmesgRule parses a rule given as "<name>:<severity>:<regex>", where
+// severity is warning or critical. The regex may itself contain colons.
+func ParseDmesgRule(s string) (DmesgRule, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return DmesgRule{}, fmt.Errorf("expected <name>:<warning|critical>:<regex>")
+	}
+	if !dmesgRuleName.MatchString(parts[0]) {
+		return DmesgRule{}, fmt.Errorf("rule name %q must be lower case letters, digits and underscores", parts[0])
+	}
+
+	var severity output.Status
+	switch parts[1] {
+	case "warning":
+		severity = output.Warning
+	case "critical":
+		severity = output.Critical
+	default:
+		return DmesgRule{}, fmt.Errorf("severity %q must be warning or critical", parts[1])
+	}
+
+	re, err := regexp.Compile(parts[2])
+	if err != nil {
+		return DmesgRule{}, fmt.Errorf("invalid regex: %w", err)
+	}
+
+	return DmesgRule{Name: parts[0], Severity: severity, Pattern: re}, nil
+}
+
+// DmesgCheck scans the kernel ring buffer for rule matches. Only messages
+// newer than the cursor stored in StateFile are counted, and the cursor
+// moves to the newest message on every run, so a message alerts once. The
+// status is the highest severity among the rules that matched.
+//
+// The cursor is the time and text of the last message seen. It is found
+// again by its text (within dmesgCursorSlack of its time); when it has left
+// the ring buffer or the node rebooted, every message after its time is new.
+// Without a state file, or on the first run, the whole buffer is new.
+type DmesgCheck struct {
+	Rules     []DmesgRule
+	StateFile string // cursor location; "" = no cursor
+}
+
+// NewDmesgCheck creates a DmesgCheck from rule strings (see ParseDmesgRule).
+// A rule with the name of a default rule replaces it; with noDefaults the
+// default rules are dropped.
+func NewDmesgCheck(rules []string, noDefaults bool, stateFile string) (*DmesgCheck, error) {
+	var specs []string
+	if !noDefaults {
+		specs = append(specs, DefaultDmesgRules...)
+	}
+	specs = append(specs, rules...)
+
+	ch := &DmesgCheck{StateFile: stateFile}
+	index := make(map[string]int)
+	for _, s := range specs {
+		r, err := ParseDmesgRule(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", s, err)
+		}
+		if i, ok := index[r.Name]; ok {
+			ch.Rules[i] = r
+			continue
+		}
+		index[r.Name] = len(ch.Rules)
+		ch.Rules = append(ch.Rules, r)
+	}
+	if len(ch.Rules) == 0 {
+		return nil, fmt.Errorf("no rules")
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *DmesgCheck) Name() string { return "DMESG" }
+
+// kernelMessage is one parsed line of Talos dmesg output.
+type kernelMessage struct {
+	time time.Time
+	text string
+}
+
+// dmesgCursor is the state persisted between runs.
+type dmesgCursor struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// Run executes the dmesg check against the Talos API.
+func (ch *DmesgCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	data, err := client.Dmesg(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgs := parseDmesg(data)
+
+	cursor, err := ch.readCursor()
+	if err != nil {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Cannot read state file: %s", err),
+		}, nil
+	}
+	fresh := messagesAfter(msgs, cursor)
+
+	counts := make([]int, len(ch.Rules))
+	var matched []string
+	status := output.OK
+	for _, m := range fresh {
+		var names []string
+		for i, r := range ch.Rules {
+			if !r.Pattern.MatchString(m.text) {
+				continue
+			}
+			counts[i]++
+			names = append(names, r.Name)
+			status = max(status, r.Severity)
+		}
+		if len(names) > 0 {
+			matched = append(matched, fmt.Sprintf("[%s] %s: %s",
+				m.time.UTC().Format(time.RFC3339), strings.Join(names, ","), m.text))
+		}
+	}
+
+	if len(msgs) > 0 {
+		last := msgs[len(msgs)-1]
+		if err := writeState(ch.StateFile, dmesgCursor{Time: last.time, Message: last.text}); err != nil {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("Cannot write state file: %s", err),
+			}, nil
+		}
+	}
+
+	perfData := []output.PerfDatum{
+		{Label: "dmesg_new_messages", Value: float64(len(fresh)), Min: "0"},
+	}
+	var hits []string
+	for i, r := range ch.Rules {
+		perfData = append(perfData, output.PerfDatum{Label: "dmesg_" + r.Name, Value: float64(counts[i]), Min: "0"})
+		if counts[i] > 0 {
+			hits = append(hits, fmt.Sprintf("%s=%d", r.Name, counts[i]))
+		}
+	}
+
+	if len(matched) == 0 {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("%d new kernel messages, none matched", len(fresh)),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	// The newest matches are the relevant ones.
+	details := matched
+	if len(details) > dmesgTopN {
+		details = details[len(details)-dmesgTopN:]
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d of %d new kernel messages matched: %s",
+			len(matched), len(fresh), strings.Join(hits, ", ")),
+		Details:  strings.Join(details, "\n"),
+		PerfData: perfData,
+	}, nil
+}
+
+// dmesgLine matches a message as Talos formats it:
+// "kern: warning: [2026-10-16T08:48:00.123456Z]: <message>".
+var dmesgLine = regexp.MustCompile(`^\s*\w+:\s*\w+: \[([^\]]+)\]: (.*)$`)
+
+// parseDmesg parses Talos dmesg output. A line without the Talos prefix
+// continues the previous message and takes over its time.
+func parseDmesg(data []byte) []kernelMessage {
+	var msgs []kernelMessage
+	var last time.Time
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		m := dmesgLine.FindStringSubmatch(line)
+		if m == nil {
+			msgs = append(msgs, kernelMessage{time: last, text: line})
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, m[1]); err == nil {
+			last = t
+		}
+		msgs = append(msgs, kernelMessage{time: last, text: m[2]})
+	}
+	return msgs
+}
+
+// messagesAfter returns the messages that follow the cursor.
+func messagesAfter(msgs []kernelMessage, cursor *dmesgCursor) []kernelMessage {
+	if cursor == nil {
+		return msgs
+	}
+
+	for i := len(msgs) - 1; i >= 0; i-- {
+		d := msgs[i].time.Sub(cursor.Time)
+		if msgs[i].text == cursor.Message && d <= dmesgCursorSlack && d >= -dmesgCursorSlack {
+			return msgs[i+1:]
+		}
+	}
+
+	// The cursor message is gone: the buffer wrapped or the node rebooted.
+	for i, m := range msgs {
+		if m.time.After(cursor.Time) {
+			return msgs[i:]
+		}
+	}
+	return nil
+}
+
+// readCursor loads the cursor from StateFile. A missing or unparsable file
+// means no cursor.
+func (ch *DmesgCheck) readCursor() (*dmesgCursor, error) {
+	var c dmesgCursor
+	ok, err := readState(ch.StateFile, &c)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,310 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+)
+
+// mockDmesgClient implements TalosClient for Dmesg check testing.
+type mockDmesgClient struct {
+	stubClient
+
+	data string
+	err  error
+}
+
+func (m *mockDmesgClient) Dmesg(context.Context) ([]byte, error) {
+	return []byte(m.data), m.err
+}
+
+// bootLog is a kernel ring buffer as Talos formats it.
+const bootLog = `kern:    info: [2026-10-16T08:00:00.100000Z]: Linux version 6.12.57-talos
+kern:  notice: [2026-10-16T08:00:01.200000Z]: EXT4-fs (sda6): mounted filesystem with ordered data mode
+kern:    info: [2026-10-16T08:00:05.300000Z]: eth0: link up
+`
+
+// oomLog is bootLog followed by an OOM kill and an I/O error.
+const oomLog = bootLog + `kern:     err: [2026-10-16T09:15:00.000000Z]: Memory cgroup out of memory: Killed process 4242 (java) total-vm:4194304kB
+kern:     err: [2026-10-16T09:20:00.000000Z]: I/O error, dev sdb, sector 123456 op 0x0:(READ) flags 0x0
+kern:    info: [2026-10-16T09:21:00.000000Z]: eth0: link up
+`
+
+func TestParseDmesgRule(t *testing.T) {
+	tests := []struct {
+		spec     string
+		wantName string
+		wantSev  output.Status
+		wantErr  string
+	}{
+		{"oom:warning:Killed process", "oom", output.Warning, ""},
+		{"nfs:critical:nfs: server \\S+ not responding", "nfs", output.Critical, ""},
+		{"oom:warning", "", output.OK, "expected <name>:<warning|critical>:<regex>"},
+		{"OOM:warning:x", "", output.OK, "lower case"},
+		{"oom:unknown:x", "", output.OK, "must be warning or critical"},
+		{"oom:warning:(", "", output.OK, "invalid regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			r, err := ParseDmesgRule(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDmesgRule: %v", err)
+			}
+			if r.Name != tt.wantName || r.Severity != tt.wantSev {
+				t.Errorf("got %s/%v, want %s/%v", r.Name, r.Severity, tt.wantName, tt.wantSev)
+			}
+		})
+	}
+}
+
+func TestNewDmesgCheckRules(t *testing.T) {
+	ch, err := NewDmesgCheck([]string{"oom:critical:Killed process", "nfs:warning:not responding"}, false, "")
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+
+	var names []string
+	for _, r := range ch.Rules {
+		names = append(names, r.Name)
+	}
+	if got, want := strings.Join(names, ","), "oom,io_error,fs_error,hung_task,mce,nfs"; got != want {
+		t.Errorf("rules = %s, want %s", got, want)
+	}
+	if ch.Rules[0].Severity != output.Critical {
+		t.Errorf("oom severity = %v, want overridden to CRITICAL", ch.Rules[0].Severity)
+	}
+
+	if _, err := NewDmesgCheck(nil, true, ""); err == nil {
+		t.Error("expected error without rules, got nil")
+	}
+	if _, err := NewDmesgCheck([]string{"bad"}, false, ""); err == nil {
+		t.Error("expected error for malformed rule, got nil")
+	}
+}
+
+func TestDmesgCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       []string
+		data        string
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - nothing matches",
+			data:        bootLog,
+			wantStatus:  output.OK,
+			wantSummary: "3 new kernel messages, none matched",
+		},
+		{
+			name:        "CRITICAL - OOM kill and I/O error",
+			data:        oomLog,
+			wantStatus:  output.Critical,
+			wantSummary: "2 of 6 new kernel messages matched: oom=1, io_error=1",
+			wantDetails: "[2026-10-16T09:15:00Z] oom: Memory cgroup out of memory: Killed process 4242 (java) total-vm:4194304kB\n" +
+				"[2026-10-16T09:20:00Z] io_error: I/O error, dev sdb, sector 123456 op 0x0:(READ) flags 0x0",
+		},
+		{
+			name:        "WARNING - custom rule severity",
+			rules:       []string{"io_error:warning:I/O error, dev "},
+			data:        oomLog,
+			wantStatus:  output.Warning,
+			wantSummary: "2 of 6 new kernel messages matched: oom=1, io_error=1",
+			wantDetails: "[2026-10-16T09:15:00Z] oom: Memory cgroup out of memory: Killed process 4242 (java) total-vm:4194304kB\n" +
+				"[2026-10-16T09:20:00Z] io_error: I/O error, dev sdb, sector 123456 op 0x0:(READ) flags 0x0",
+		},
+		{
+			name:  "WARNING - message matching two rules",
+			rules: []string{"sdb:warning:dev sdb"},
+			data: `kern: warning: [2026-10-16T09:20:00Z]: blk_update_request: I/O error, dev sdb, sector 8
+`,
+			wantStatus:  output.Critical,
+			wantSummary: "1 of 1 new kernel messages matched: io_error=1, sdb=1",
+			wantDetails: "[2026-10-16T09:20:00Z] io_error,sdb: blk_update_request: I/O error, dev sdb, sector 8",
+		},
+		{
+			name:        "OK - empty ring buffer",
+			data:        "",
+			wantStatus:  output.OK,
+			wantSummary: "0 new kernel messages, none matched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewDmesgCheck(tt.rules, false, "")
+			if err != nil {
+				t.Fatalf("NewDmesgCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), &mockDmesgClient{data: tt.data})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "DMESG" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "DMESG")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestDmesgCheckCursor(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "dmesg.json")
+	ch, err := NewDmesgCheck(nil, false, stateFile)
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+	run := func(data string) *output.Result {
+		t.Helper()
+		result, err := ch.Run(context.Background(), &mockDmesgClient{data: data})
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return result
+	}
+
+	// First run: the whole buffer is new.
+	if r := run(oomLog); r.Status != output.Critical {
+		t.Fatalf("first run status = %v, want CRITICAL (%s)", r.Status, r.Summary)
+	}
+
+	// Same buffer again: the matches were already reported.
+	if r := run(oomLog); r.Status != output.OK || r.Summary != "0 new kernel messages, none matched" {
+		t.Errorf("second run = %v %q, want OK with no new messages", r.Status, r.Summary)
+	}
+
+	// The last message comes back stamped 1s later (boot time estimate
+	// moved); only the appended hung task message is new.
+	shifted := strings.Replace(oomLog, "09:21:00.000000Z", "09:21:01.000000Z", 1) +
+		"kern:     err: [2026-10-16T09:30:00Z]: INFO: task jbd2/sda6-8:312 blocked for more than 120 seconds.\n"
+	r := run(shifted)
+	if r.Status != output.Warning || r.Summary != "1 of 1 new kernel messages matched: hung_task=1" {
+		t.Errorf("third run = %v %q, want WARNING for the hung task only", r.Status, r.Summary)
+	}
+
+	// After a reboot the cursor message is gone; everything after its time
+	// is new.
+	reboot := `kern:    info: [2026-10-16T10:00:00Z]: Linux version 6.12.57-talos
+kern:     err: [2026-10-16T10:05:00Z]: EXT4-fs error (device sda6): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0
+`
+	r = run(reboot)
+	if r.Status != output.Critical || r.Summary != "1 of 2 new kernel messages matched: fs_error=1" {
+		t.Errorf("after reboot = %v %q, want CRITICAL for the fs error", r.Status, r.Summary)
+	}
+}
+
+func TestDmesgCheckCorruptState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "dmesg.json")
+	if err := os.WriteFile(stateFile, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := NewDmesgCheck(nil, false, stateFile)
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+	result, err := ch.Run(context.Background(), &mockDmesgClient{data: oomLog})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.Critical {
+		t.Errorf("status = %v, want CRITICAL: a corrupt state file starts over", result.Status)
+	}
+}
+
+func TestDmesgCheckStateFileError(t *testing.T) {
+	// A regular file where the state directory should be.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := NewDmesgCheck(nil, false, filepath.Join(blocker, "dmesg.json"))
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+	result, err := ch.Run(context.Background(), &mockDmesgClient{data: bootLog})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.Unknown || !strings.HasPrefix(result.Summary, "Cannot read state file: ") {
+		t.Errorf("got %v %q, want UNKNOWN Cannot read state file", result.Status, result.Summary)
+	}
+}
+
+func TestDmesgCheckPerfData(t *testing.T) {
+	ch, err := NewDmesgCheck(nil, false, "")
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockDmesgClient{data: oomLog})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "dmesg_new_messages=6;;;0; dmesg_oom=1;;;0; dmesg_io_error=1;;;0; " +
+		"dmesg_fs_error=0;;;0; dmesg_hung_task=0;;;0; dmesg_mce=0;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestDmesgCheckDetailsKeepNewest(t *testing.T) {
+	var b strings.Builder
+	for i := range 15 {
+		fmt.Fprintf(&b, "kern: err: [2026-10-16T09:%02d:00Z]: I/O error, dev sdb, sector %d\n", i, i)
+	}
+
+	ch, err := NewDmesgCheck(nil, false, "")
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+	result, err := ch.Run(context.Background(), &mockDmesgClient{data: b.String()})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	lines := strings.Split(result.Details, "\n")
+	if len(lines) != dmesgTopN {
+		t.Fatalf("got %d detail lines, want %d", len(lines), dmesgTopN)
+	}
+	if !strings.HasSuffix(lines[0], "sector 5") || !strings.HasSuffix(lines[len(lines)-1], "sector 14") {
+		t.Errorf("details should hold the newest matches, got first %q last %q", lines[0], lines[len(lines)-1])
+	}
+}
+
+func TestDmesgCheckAPIError(t *testing.T) {
+	ch, err := NewDmesgCheck(nil, false, "")
+	if err != nil {
+		t.Fatalf("NewDmesgCheck: %v", err)
+	}
+
+	_, err = ch.Run(context.Background(), &mockDmesgClient{err: fmt.Errorf("connection refused")})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
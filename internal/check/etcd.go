@@ -3,6 +3,7 @@ package check
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
@@ -11,38 +12,160 @@ import (
 )
 
 // EtcdCheck monitors etcd cluster health via the Talos API.
-// It verifies leader presence, member count, active alarms, and DB size
-// against configurable thresholds.
+// It verifies leader presence, quorum, active alarms, the member's reported
+// errors, and DB size against configurable thresholds.
+//
+// ClusterSize is the number of voting members the control plane is built
+// for. Fewer voting members than its quorum (ClusterSize/2+1) is CRITICAL,
+// fewer than ClusterSize is WARNING. Learners, members that replicate but
+// do not vote (added while a control-plane node is replaced), are counted
+// separately.
+//
+// Warning and Critical apply to the allocated DB size: in bytes, or with
+// Units percent as a percentage of Quota, the backend quota at which etcd
+// raises the NOSPACE alarm and stops accepting writes. FragWarning and
+// FragCritical apply to the fragmentation, the share of the allocated size
+// not in use, which only a defragmentation returns.
+//
+// LagWarning and LagCritical apply to the raft entries the member has
+// committed but not yet applied. TermWarning and TermCritical apply to the
+// raft term changes (leader elections) since the previous run, found by
+// comparing the term with the one kept in StateFile. A StateFile that
+// cannot be read or written skips them with a note in the summary.
+//
+// With Cluster set, the status is also fetched from every member through
+// apid, and the members must answer without critical errors, belong to the
+// member list and agree on the leader. Members that do not answer are
+// WARNING while the voters that do still hold quorum, CRITICAL below it. LagWarning and LagCritical then also apply
+// to the distance of each member behind the highest raft index, and
+// SizeTolerance to the spread of the in-use DB sizes.
 type EtcdCheck struct {
-	Warning    threshold.Threshold
-	Critical   threshold.Threshold
-	MinMembers int
+	Warning       threshold.Threshold
+	Critical      threshold.Threshold
+	ClusterSize   int
+	Units         string               // EtcdUnitsBytes or EtcdUnitsPercent
+	Quota         int64                // backend quota in bytes
+	FragWarning   *threshold.Threshold // fragmentation percent; nil = not evaluated
+	FragCritical  *threshold.Threshold // fragmentation percent; nil = not evaluated
+	LagWarning    *threshold.Threshold // unapplied entries; nil = not evaluated
+	LagCritical   *threshold.Threshold // unapplied entries; nil = not evaluated
+	TermWarning   *threshold.Threshold // term changes since last run; nil = not evaluated
+	TermCritical  *threshold.Threshold // term changes since last run; nil = not evaluated
+	StateFile     string               // last seen raft term; "" = no term tracking
+	Cluster       bool                 // query every member, not only the target node
+	SizeTolerance *threshold.Threshold // in-use DB size spread percent; nil = not evaluated
 }
 
-// NewEtcdCheck creates an EtcdCheck from warning/critical threshold strings
-// and a minimum member count.
-func NewEtcdCheck(w, c string, minMembers int) (*EtcdCheck, error) {
-	wt, err := threshold.Parse(w)
+// Threshold units accepted by EtcdCheck.
+const (
+	EtcdUnitsBytes   = "bytes"
+	EtcdUnitsPercent = "percent"
+)
+
+// DefaultEtcdQuota is etcd's default --quota-backend-bytes, which Talos does
+// not change.
+const DefaultEtcdQuota = 2 << 30
+
+// EtcdOptions holds the optional etcd settings. An empty Units means bytes,
+// an empty Quota means DefaultEtcdQuota, and an empty threshold leaves its
+// metric unevaluated.
+type EtcdOptions struct {
+	Units         string
+	Quota         string // size, suffixes allowed
+	FragWarning   string
+	FragCritical  string
+	LagWarning    string
+	LagCritical   string
+	TermWarning   string
+	TermCritical  string
+	StateFile     string
+	Cluster       bool
+	SizeTolerance string
+}
+
+// NewEtcdCheck creates an EtcdCheck from warning/critical threshold strings,
+// the expected number of voting members and the optional settings in opts. The thresholds
+// are sizes with Units bytes and bare percentages with Units percent.
+func NewEtcdCheck(w, c string, clusterSize int, opts EtcdOptions) (*EtcdCheck, error) {
+	units := opts.Units
+	if units == "" {
+		units = EtcdUnitsBytes
+	}
+	unit := threshold.UnitBytes
+	switch units {
+	case EtcdUnitsBytes:
+	case EtcdUnitsPercent:
+		unit = threshold.UnitNone
+	default:
+		return nil, fmt.Errorf("invalid units %q: must be %s or %s", units, EtcdUnitsBytes, EtcdUnitsPercent)
+	}
+
+	wt, err := threshold.ParseUnit(w, unit)
 	if err != nil {
 		return nil, fmt.Errorf("invalid warning threshold: %w", err)
 	}
-	ct, err := threshold.Parse(c)
+	ct, err := threshold.ParseUnit(c, unit)
 	if err != nil {
 		return nil, fmt.Errorf("invalid critical threshold: %w", err)
 	}
-	return &EtcdCheck{Warning: wt, Critical: ct, MinMembers: minMembers}, nil
+
+	ch := &EtcdCheck{
+		Warning:     wt,
+		Critical:    ct,
+		ClusterSize: clusterSize,
+		Units:       units,
+		Quota:       DefaultEtcdQuota,
+		StateFile:   opts.StateFile,
+		Cluster:     opts.Cluster,
+	}
+
+	if opts.Quota != "" {
+		q, err := threshold.ParseValue(opts.Quota, threshold.UnitBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota: %w", err)
+		}
+		if q < 1 {
+			return nil, fmt.Errorf("invalid quota %q: must be positive", opts.Quota)
+		}
+		ch.Quota = int64(q)
+	}
+
+	if err := parseOptionalThresholds(threshold.UnitNone, []optionalThreshold{
+		{"fragmentation warning", opts.FragWarning, &ch.FragWarning},
+		{"fragmentation critical", opts.FragCritical, &ch.FragCritical},
+		{"lag warning", opts.LagWarning, &ch.LagWarning},
+		{"lag critical", opts.LagCritical, &ch.LagCritical},
+		{"term warning", opts.TermWarning, &ch.TermWarning},
+		{"term critical", opts.TermCritical, &ch.TermCritical},
+		{"size tolerance", opts.SizeTolerance, &ch.SizeTolerance},
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
 }
 
 // Name returns the check identifier used in Nagios output.
 func (ch *EtcdCheck) Name() string { return "ETCD" }
 
+// etcdState is the state persisted between runs.
+type etcdState struct {
+	MemberID uint64 `json:"member_id"`
+	RaftTerm uint64 `json:"raft_term"`
+}
+
 // Run executes the etcd check against the Talos API.
 //
 // Evaluation order per DESIGN.md Section 4.5:
-//  1. EtcdStatus — leader != 0, errors[] empty
-//  2. EtcdMemberList — len(members) >= MinMembers
+//  1. EtcdStatus — leader != 0
+//  2. EtcdMemberList — voting members >= quorum of ClusterSize
 //  3. EtcdAlarmList — any active alarm → CRITICAL
-//  4. db_size against thresholds
+//  4. errors[] — a critical error (see etcdCriticalError) → CRITICAL,
+//     any other error → WARNING
+//  5. With Cluster, EtcdStatus on every member — voters answering hold
+//     quorum, no critical errors, same leader, known member IDs
+//  6. db_size (or quota usage), fragmentation, raft apply lag and term
+//     changes against thresholds
 func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
 	// Step 1: Get etcd status.
 	statusResp, err := client.EtcdStatus(ctx)
@@ -72,6 +195,21 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 	leader := memberStatus.GetLeader()
 	dbSize := memberStatus.GetDbSize()
 	dbSizeInUse := memberStatus.GetDbSizeInUse()
+	raftTerm := memberStatus.GetRaftTerm()
+
+	// Committed entries wait in the apply queue; etcd rejects new requests
+	// once the queue holds 5000 of them.
+	raftIndex, raftApplied := memberStatus.GetRaftIndex(), memberStatus.GetRaftAppliedIndex()
+	var raftLag uint64
+	if raftIndex > raftApplied {
+		raftLag = raftIndex - raftApplied
+	}
+
+	// The term goes up by one with every election. The state is stored
+	// before any assertion fails, so a leaderless run still records it.
+	// Term tracking is an add-on: a state file that cannot be used skips
+	// it with a note rather than hiding the rest of the check.
+	termChanges, stateErr := ch.termChanges(memberId, raftTerm)
 
 	// Step 2: Get member list.
 	memberResp, err := client.EtcdMemberList(ctx)
@@ -88,7 +226,15 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 	}
 
 	members := memberResp.GetMessages()[0].GetMembers()
-	memberCount := len(members)
+	var voting, learners int
+	for _, m := range members {
+		if m.GetIsLearner() {
+			learners++
+		} else {
+			voting++
+		}
+	}
+	quorum := ch.ClusterSize/2 + 1
 
 	// Step 3: Get alarm list.
 	alarmResp, err := client.EtcdAlarmList(ctx)
@@ -98,14 +244,28 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 
 	activeAlarms := collectAlarms(alarmResp)
 
+	// Fragmentation is the allocated space not holding data; etcd only
+	// returns it to the filesystem on defragmentation.
+	fragmentation := 0.0
+	if dbSize > 0 {
+		fragmentation = roundPct(max(1-float64(dbSizeInUse)/float64(dbSize), 0) * 100)
+	}
+	quotaUsage := roundPct(float64(dbSize) / float64(ch.Quota) * 100)
+
+	// The size thresholds go with the metric they are evaluated against.
+	sizeWarn, sizeCrit, quotaWarn, quotaCrit := ch.Warning.String(), ch.Critical.String(), "", ""
+	if ch.Units == EtcdUnitsPercent {
+		sizeWarn, sizeCrit, quotaWarn, quotaCrit = "", "", sizeWarn, sizeCrit
+	}
+
 	// Build perfdata (always emitted when data was retrieved).
 	perfData := []output.PerfDatum{
 		{
 			Label: "etcd_dbsize",
 			Value: float64(dbSize),
 			UOM:   "B",
-			Warn:  ch.Warning.String(),
-			Crit:  ch.Critical.String(),
+			Warn:  sizeWarn,
+			Crit:  sizeCrit,
 			Min:   "0",
 			Max:   "",
 		},
@@ -118,12 +278,65 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 		},
 		{
 			Label: "etcd_members",
-			Value: float64(memberCount),
+			Value: float64(voting),
+			Warn:  fmt.Sprintf("%d:", ch.ClusterSize),
+			Crit:  fmt.Sprintf("%d:", quorum),
+			Min:   "0",
+			Max:   "",
+		},
+		{
+			Label: "etcd_learners",
+			Value: float64(learners),
 			Min:   "0",
 			Max:   "",
 		},
+		{
+			Label: "etcd_quota_usage",
+			Value: quotaUsage,
+			Warn:  quotaWarn,
+			Crit:  quotaCrit,
+			Min:   "0",
+			Max:   "100",
+		},
+		{
+			Label: "etcd_fragmentation",
+			Value: fragmentation,
+			Warn:  optionalString(ch.FragWarning),
+			Crit:  optionalString(ch.FragCritical),
+			Min:   "0",
+			Max:   "100",
+		},
+		{
+			Label: "etcd_raft_lag",
+			Value: float64(raftLag),
+			Warn:  optionalString(ch.LagWarning),
+			Crit:  optionalString(ch.LagCritical),
+			Min:   "0",
+		},
+		{
+			Label: "etcd_raft_term",
+			Value: float64(raftTerm),
+			UOM:   "c",
+			Min:   "0",
+		},
+		{
+			Label: "etcd_errors",
+			Value: float64(len(memberStatus.GetErrors())),
+			Min:   "0",
+		},
 	}
 
+	// Errors are listed before the members in the long text.
+	criticalErrs, otherErrs := splitEtcdErrors(memberStatus.GetErrors())
+	var lines []string
+	for _, e := range criticalErrs {
+		lines = append(lines, "error (critical): "+e)
+	}
+	for _, e := range otherErrs {
+		lines = append(lines, "error (warning): "+e)
+	}
+	details := strings.Join(append(lines, memberRoster(members, leader)), "\n")
+
 	// Evaluation order: structural assertions first, then thresholds.
 
 	// Check 1: Leader must exist.
@@ -132,17 +345,20 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 			Status:    output.Critical,
 			CheckName: ch.Name(),
 			Summary:   "No leader elected",
+			Details:   details,
 			PerfData:  perfData,
 		}, nil
 	}
 
-	// Check 2: Member count must meet minimum.
-	if memberCount < ch.MinMembers {
+	// Check 2: Voting members must hold quorum. Learners do not vote.
+	if voting < quorum {
 		return &output.Result{
 			Status:    output.Critical,
 			CheckName: ch.Name(),
-			Summary:   fmt.Sprintf("Member count %d below minimum %d", memberCount, ch.MinMembers),
-			PerfData:  perfData,
+			Summary: fmt.Sprintf("Voting members %d below quorum %d of cluster size %d",
+				voting, quorum, ch.ClusterSize),
+			Details:  details,
+			PerfData: perfData,
 		}, nil
 	}
 
@@ -152,18 +368,88 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 			Status:    output.Critical,
 			CheckName: ch.Name(),
 			Summary:   fmt.Sprintf("Active alarm: %s", strings.Join(activeAlarms, ", ")),
+			Details:   details,
+			PerfData:  perfData,
+		}, nil
+	}
+
+	// Check 4: The member reports no critical errors.
+	if len(criticalErrs) > 0 {
+		return &output.Result{
+			Status:    output.Critical,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Member errors: %s", strings.Join(criticalErrs, "; ")),
+			Details:   details,
 			PerfData:  perfData,
 		}, nil
 	}
 
-	// Check 4: DB size against thresholds.
-	dbSizeFloat := float64(dbSize)
+	// Check 5: In cluster mode, every member must agree.
+	var cluster *etcdCluster
+	if ch.Cluster {
+		var unknown string
+		cluster, unknown, err = ch.etcdCluster(ctx, client, members)
+		if err != nil {
+			return nil, err
+		}
+		if unknown != "" {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   unknown,
+				PerfData:  perfData,
+			}, nil
+		}
+		perfData = append(perfData, cluster.perfData(ch)...)
 
-	status := output.OK
-	if ch.Critical.Violated(dbSizeFloat) {
-		status = output.Critical
-	} else if ch.Warning.Violated(dbSizeFloat) {
-		status = output.Warning
+		if issues := cluster.issues(leader, members, quorum); len(issues) > 0 {
+			return &output.Result{
+				Status:    output.Critical,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("Cluster inconsistent: %s", strings.Join(issues, ", ")),
+				Details:   details + "\n" + cluster.details(),
+				PerfData:  perfData,
+			}, nil
+		}
+	}
+
+	// Check 6: DB size against thresholds, then fragmentation.
+	sizeValue, inUseValue := float64(dbSize), float64(dbSizeInUse)
+	if ch.Units == EtcdUnitsPercent {
+		sizeValue = quotaUsage
+		inUseValue = roundPct(float64(dbSizeInUse) / float64(ch.Quota) * 100)
+	}
+	sizeStatus := ch.sizeStatus(sizeValue)
+	fragStatus := evaluateCounter(fragmentation, ch.FragWarning, ch.FragCritical)
+	lagStatus := evaluateCounter(float64(raftLag), ch.LagWarning, ch.LagCritical)
+	termStatus := output.OK
+	if stateErr == nil {
+		termStatus = evaluateCounter(float64(termChanges), ch.TermWarning, ch.TermCritical)
+	}
+	// Quorum holds but the cluster survives fewer failures than designed.
+	quorumStatus := output.OK
+	if voting < ch.ClusterSize {
+		quorumStatus = output.Warning
+	}
+	errStatus := output.OK
+	if len(otherErrs) > 0 {
+		errStatus = output.Warning
+	}
+	status := max(quorumStatus, errStatus, sizeStatus, fragStatus, lagStatus, termStatus)
+
+	clusterLagStatus, spreadStatus := output.OK, output.OK
+	var clusterWarnings []string
+	if cluster != nil {
+		clusterLagStatus = evaluateCounter(float64(cluster.lag), ch.LagWarning, ch.LagCritical)
+		// A size difference is a hint, never an outage on its own.
+		if ch.SizeTolerance != nil && ch.SizeTolerance.Violated(cluster.spread) {
+			spreadStatus = output.Warning
+		}
+		clusterWarnings = cluster.warnings(quorum)
+		if len(clusterWarnings) > 0 {
+			status = max(status, output.Warning)
+		}
+		status = max(status, clusterLagStatus, spreadStatus)
 	}
 
 	var role string
@@ -173,17 +459,175 @@ func (ch *EtcdCheck) Run(ctx context.Context, client TalosClient) (*output.Resul
 		role = fmt.Sprintf("Follower, leader %d", leader)
 	}
 
-	summary := fmt.Sprintf("%s, %d/%d members, DB %s",
-		role, memberCount, ch.MinMembers, output.HumanBytes(uint64(dbSize)))
+	summary := fmt.Sprintf("%s, %d/%d members", role, voting, ch.ClusterSize)
+	switch {
+	case learners == 1:
+		summary += " (+1 learner)"
+	case learners > 1:
+		summary += fmt.Sprintf(" (+%d learners)", learners)
+	}
+	if quorumStatus != output.OK {
+		summary += fmt.Sprintf(", fault tolerance %d", voting-quorum)
+	}
+	summary += fmt.Sprintf(", DB %s", output.HumanBytes(uint64(dbSize)))
+	if ch.Units == EtcdUnitsPercent {
+		summary += fmt.Sprintf(" (%.1f%% of %s quota)", quotaUsage, output.HumanBytes(uint64(ch.Quota)))
+	}
+	summary += thresholdNote(sizeStatus, ch.Warning, ch.Critical)
+
+	if fragStatus != output.OK {
+		summary += fmt.Sprintf(", %.1f%% fragmented%s",
+			fragmentation, optionalThresholdNote(fragStatus, ch.FragWarning, ch.FragCritical))
+	}
+
+	// Fragmentation caused the breach when the data actually in use would
+	// not breach the same size threshold; a defrag then clears it.
+	if fragStatus != output.OK || (sizeStatus != output.OK && ch.sizeStatus(inUseValue) < sizeStatus) {
+		summary += fmt.Sprintf(", defragment to reclaim %s (talosctl etcd defrag)",
+			output.HumanBytes(uint64(max(dbSize-dbSizeInUse, 0))))
+	}
+
+	if lagStatus != output.OK {
+		summary += fmt.Sprintf(", %d raft entries not applied%s",
+			raftLag, optionalThresholdNote(lagStatus, ch.LagWarning, ch.LagCritical))
+	}
+	if termStatus != output.OK {
+		summary += fmt.Sprintf(", %d leader elections since last check (term %d)%s",
+			termChanges, raftTerm, optionalThresholdNote(termStatus, ch.TermWarning, ch.TermCritical))
+	}
+	if stateErr != nil {
+		summary += fmt.Sprintf(", leader elections not tracked (%s)", stateErr)
+	}
+	if errStatus != output.OK {
+		summary += fmt.Sprintf(", member errors: %s", strings.Join(otherErrs, "; "))
+	}
+
+	if cluster != nil {
+		summary += fmt.Sprintf(", %d/%d members agree", cluster.answering, len(cluster.nodes))
+		for _, w := range clusterWarnings {
+			summary += ", " + w
+		}
+		if clusterLagStatus != output.OK {
+			summary += fmt.Sprintf(", %s %d raft entries behind%s",
+				cluster.lagging, cluster.lag, optionalThresholdNote(clusterLagStatus, ch.LagWarning, ch.LagCritical))
+		}
+		if spreadStatus != output.OK {
+			summary += fmt.Sprintf(", in-use DB sizes differ by %.1f%%, tolerance %s",
+				cluster.spread, ch.SizeTolerance.Human())
+		}
+		details += "\n" + cluster.details()
+	}
 
 	return &output.Result{
 		Status:    status,
 		CheckName: ch.Name(),
 		Summary:   summary,
+		Details:   details,
 		PerfData:  perfData,
 	}, nil
 }
 
+// termChanges returns the raft term changes since the term kept in
+// StateFile and stores the current one. A different member or a lower term
+// (a rebuilt cluster) starts over. Without a StateFile it returns zero.
+func (ch *EtcdCheck) termChanges(memberID, raftTerm uint64) (uint64, error) {
+	if ch.StateFile == "" {
+		return 0, nil
+	}
+	var prev etcdState
+	hasPrev, err := readState(ch.StateFile, &prev)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read state file: %w", err)
+	}
+	var changes uint64
+	if hasPrev && prev.MemberID == memberID && raftTerm > prev.RaftTerm {
+		changes = raftTerm - prev.RaftTerm
+	}
+	if err := writeState(ch.StateFile, etcdState{MemberID: memberID, RaftTerm: raftTerm}); err != nil {
+		return 0, fmt.Errorf("cannot write state file: %w", err)
+	}
+	return changes, nil
+}
+
+// sizeStatus evaluates a DB size value, in bytes or percent of the quota,
+// against the size thresholds.
+func (ch *EtcdCheck) sizeStatus(v float64) output.Status {
+	switch {
+	case ch.Critical.Violated(v):
+		return output.Critical
+	case ch.Warning.Violated(v):
+		return output.Warning
+	default:
+		return output.OK
+	}
+}
+
+// thresholdNote returns ", <level> threshold <range>" naming the threshold
+// that produced a WARNING or CRITICAL status, in human form, or "" for any
+// other status.
+func thresholdNote(status output.Status, warn, crit threshold.Threshold) string {
+	switch status {
+	case output.Critical:
+		return ", critical threshold " + crit.Human()
+	case output.Warning:
+		return ", warning threshold " + warn.Human()
+	default:
+		return ""
+	}
+}
+
+// optionalThresholdNote is thresholdNote for optional thresholds; the
+// threshold named by status is always set.
+func optionalThresholdNote(status output.Status, warn, crit *threshold.Threshold) string {
+	switch status {
+	case output.Critical:
+		return ", critical threshold " + crit.Human()
+	case output.Warning:
+		return ", warning threshold " + warn.Human()
+	default:
+		return ""
+	}
+}
+
+// etcdCriticalError matches the errors[] messages that mean the member
+// cannot serve: etcd reports a missing leader as "etcdserver: no leader" and
+// every active alarm as "memberID:<id> alarm:<type>". NOSPACE stops writes
+// and CORRUPT marks diverged data. Other messages (slow requests, a leader
+// change in progress) are transient and only WARNING.
+var etcdCriticalError = regexp.MustCompile(`(?i)no leader|alarm:\s*(NOSPACE|CORRUPT)|database space exceeded|corrupt`)
+
+// splitEtcdErrors splits a member's errors[] into critical and other errors.
+func splitEtcdErrors(errs []string) (critical, other []string) {
+	for _, e := range errs {
+		if etcdCriticalError.MatchString(e) {
+			critical = append(critical, e)
+		} else {
+			other = append(other, e)
+		}
+	}
+	return critical, other
+}
+
+// memberRoster returns one long-text line per member with its role:
+// leader, follower or learner.
+func memberRoster(members []*machine.EtcdMember, leader uint64) string {
+	lines := make([]string, len(members))
+	for i, m := range members {
+		role := "follower"
+		switch {
+		case m.GetIsLearner():
+			role = "learner"
+		case m.GetId() == leader:
+			role = "leader"
+		}
+		lines[i] = fmt.Sprintf("%s (id %d): %s", m.GetHostname(), m.GetId(), role)
+		if urls := m.GetPeerUrls(); len(urls) > 0 {
+			lines[i] += ", peer " + strings.Join(urls, ",")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // collectAlarms extracts active alarm type names from an EtcdAlarmListResponse.
 // Only non-NONE alarms are returned.
 func collectAlarms(resp *machine.EtcdAlarmListResponse) []string {
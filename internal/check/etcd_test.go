@@ -3,42 +3,40 @@ package check
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
 // mockEtcdClient implements TalosClient for Etcd check testing.
 type mockEtcdClient struct {
+	stubClient
+
 	statusResp *machine.EtcdStatusResponse
 	statusErr  error
 	memberResp *machine.EtcdMemberListResponse
 	memberErr  error
 	alarmResp  *machine.EtcdAlarmListResponse
 	alarmErr   error
-}
-
-func (m *mockEtcdClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
-	return nil, nil
-}
-
-func (m *mockEtcdClient) Memory(context.Context) (*machine.MemoryResponse, error) {
-	return nil, nil
-}
-
-func (m *mockEtcdClient) Mounts(context.Context) (*machine.MountsResponse, error) {
-	return nil, nil
-}
-
-func (m *mockEtcdClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
-	return nil, nil
+	nodesResp  *machine.EtcdStatusResponse
+	nodesErr   error
+	nodes      []string // nodes passed to EtcdStatusNodes
 }
 
 func (m *mockEtcdClient) EtcdStatus(_ context.Context) (*machine.EtcdStatusResponse, error) {
 	return m.statusResp, m.statusErr
 }
 
+func (m *mockEtcdClient) EtcdStatusNodes(_ context.Context, nodes []string) (*machine.EtcdStatusResponse, error) {
+	m.nodes = nodes
+	return m.nodesResp, m.nodesErr
+}
+
 func (m *mockEtcdClient) EtcdMemberList(_ context.Context) (*machine.EtcdMemberListResponse, error) {
 	return m.memberResp, m.memberErr
 }
@@ -47,10 +45,6 @@ func (m *mockEtcdClient) EtcdAlarmList(_ context.Context) (*machine.EtcdAlarmLis
 	return m.alarmResp, m.alarmErr
 }
 
-func (m *mockEtcdClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
-	return nil, nil
-}
-
 // Helper to build an EtcdStatusResponse.
 func makeEtcdStatusResponse(memberId, leader uint64, dbSize, dbSizeInUse int64) *machine.EtcdStatusResponse {
 	return &machine.EtcdStatusResponse{
@@ -72,8 +66,10 @@ func makeEtcdMemberListResponse(count int) *machine.EtcdMemberListResponse {
 	members := make([]*machine.EtcdMember, count)
 	for i := 0; i < count; i++ {
 		members[i] = &machine.EtcdMember{
-			Id:       uint64(i + 1),
-			Hostname: fmt.Sprintf("cp-%d", i+1),
+			Id:         uint64(i + 1),
+			Hostname:   fmt.Sprintf("cp-%d", i+1),
+			ClientUrls: []string{fmt.Sprintf("https://10.0.0.%d:2379", i+1)},
+			PeerUrls:   []string{fmt.Sprintf("https://10.0.0.%d:2380", i+1)},
 		}
 	}
 	return &machine.EtcdMemberListResponse{
@@ -114,21 +110,35 @@ func makeEtcdAlarmListResponse(alarms ...machine.EtcdMemberAlarm_AlarmType) *mac
 
 func TestNewEtcdCheck(t *testing.T) {
 	tests := []struct {
-		name       string
-		warn       string
-		crit       string
-		minMembers int
-		wantErr    bool
+		name        string
+		warn        string
+		crit        string
+		clusterSize int
+		opts        EtcdOptions
+		wantErr     bool
 	}{
-		{name: "valid defaults", warn: "~:100000000", crit: "~:200000000", minMembers: 3, wantErr: false},
-		{name: "valid custom ranges", warn: "~:50000000", crit: "~:100000000", minMembers: 5, wantErr: false},
-		{name: "invalid warning", warn: "abc", crit: "~:200000000", minMembers: 3, wantErr: true},
-		{name: "invalid critical", warn: "~:100000000", crit: "xyz", minMembers: 3, wantErr: true},
+		{name: "valid defaults", warn: "~:100000000", crit: "~:200000000", clusterSize: 3, wantErr: false},
+		{name: "valid custom ranges", warn: "~:50000000", crit: "~:100000000", clusterSize: 5, wantErr: false},
+		{name: "valid size suffixes", warn: "~:100MB", crit: "~:1.5GiB", clusterSize: 3, wantErr: false},
+		{name: "duration suffix rejected", warn: "~:10m", crit: "~:200MB", clusterSize: 3, wantErr: true},
+		{name: "invalid warning", warn: "abc", crit: "~:200000000", clusterSize: 3, wantErr: true},
+		{name: "invalid critical", warn: "~:100000000", crit: "xyz", clusterSize: 3, wantErr: true},
+		{name: "percent units", warn: "80", crit: "90", clusterSize: 3, opts: EtcdOptions{Units: EtcdUnitsPercent}, wantErr: false},
+		{name: "size suffix rejected for percent", warn: "80", crit: "~:2GB", clusterSize: 3, opts: EtcdOptions{Units: EtcdUnitsPercent}, wantErr: true},
+		{name: "unknown units", warn: "80", crit: "90", clusterSize: 3, opts: EtcdOptions{Units: "ratio"}, wantErr: true},
+		{name: "custom quota", warn: "80", crit: "90", clusterSize: 3, opts: EtcdOptions{Units: EtcdUnitsPercent, Quota: "8GiB"}, wantErr: false},
+		{name: "invalid quota", warn: "80", crit: "90", clusterSize: 3, opts: EtcdOptions{Units: EtcdUnitsPercent, Quota: "8 gigs"}, wantErr: true},
+		{name: "zero quota", warn: "80", crit: "90", clusterSize: 3, opts: EtcdOptions{Units: EtcdUnitsPercent, Quota: "0"}, wantErr: true},
+		{name: "fragmentation thresholds", warn: "~:100MB", crit: "~:200MB", clusterSize: 3, opts: EtcdOptions{FragWarning: "50", FragCritical: "80"}, wantErr: false},
+		{name: "invalid fragmentation threshold", warn: "~:100MB", crit: "~:200MB", clusterSize: 3, opts: EtcdOptions{FragWarning: "50%"}, wantErr: true},
+		{name: "raft thresholds", warn: "~:100MB", crit: "~:200MB", clusterSize: 3, opts: EtcdOptions{LagWarning: "1000", LagCritical: "5000", TermWarning: "1", TermCritical: "3"}, wantErr: false},
+		{name: "invalid lag threshold", warn: "~:100MB", crit: "~:200MB", clusterSize: 3, opts: EtcdOptions{LagCritical: "5k"}, wantErr: true},
+		{name: "invalid term threshold", warn: "~:100MB", crit: "~:200MB", clusterSize: 3, opts: EtcdOptions{TermWarning: "1h"}, wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.minMembers)
+			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.clusterSize, tt.opts)
 			if tt.wantErr {
 				if err == nil {
 					t.Fatal("expected error, got nil")
@@ -141,8 +151,8 @@ func TestNewEtcdCheck(t *testing.T) {
 			if ch.Name() != "ETCD" {
 				t.Errorf("Name() = %q, want %q", ch.Name(), "ETCD")
 			}
-			if ch.MinMembers != tt.minMembers {
-				t.Errorf("MinMembers = %d, want %d", ch.MinMembers, tt.minMembers)
+			if ch.ClusterSize != tt.clusterSize {
+				t.Errorf("ClusterSize = %d, want %d", ch.ClusterSize, tt.clusterSize)
 			}
 		})
 	}
@@ -150,20 +160,20 @@ func TestNewEtcdCheck(t *testing.T) {
 
 func TestEtcdCheckRun(t *testing.T) {
 	tests := []struct {
-		name       string
-		warn       string
-		crit       string
-		minMembers int
-		client     *mockEtcdClient
-		wantStatus output.Status
-		wantSubstr string
-		wantErr    bool
+		name        string
+		warn        string
+		crit        string
+		clusterSize int
+		client      *mockEtcdClient
+		wantStatus  output.Status
+		wantSubstr  string
+		wantErr     bool
 	}{
 		{
-			name:       "OK - healthy cluster",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - healthy cluster",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -173,10 +183,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "CRITICAL - no leader",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL - no leader",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(0, 0, 45000000, 40000000),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -186,23 +196,49 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "No leader elected",
 		},
 		{
-			name:       "CRITICAL - member count below minimum",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "WARNING - voting member missing, quorum holds",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
+			client: &mockEtcdClient{
+				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
+				memberResp: makeEtcdMemberListResponse(2),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			},
+			wantStatus: output.Warning,
+			wantSubstr: "Leader, 2/3 members, fault tolerance 0, DB 12.50 MB |",
+		},
+		{
+			name:        "CRITICAL - voting members below quorum",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
+			client: &mockEtcdClient{
+				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
+				memberResp: makeEtcdMemberListResponse(1),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			},
+			wantStatus: output.Critical,
+			wantSubstr: "Voting members 1 below quorum 2 of cluster size 3",
+		},
+		{
+			name:        "CRITICAL - quorum of a five-member cluster",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 5,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(2),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
 			wantStatus: output.Critical,
-			wantSubstr: "Member count 2 below minimum 3",
+			wantSubstr: "Voting members 2 below quorum 3 of cluster size 5",
 		},
 		{
-			name:       "CRITICAL - active NOSPACE alarm",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL - active NOSPACE alarm",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 2147483648, 2000000000),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -212,10 +248,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Active alarm: NOSPACE",
 		},
 		{
-			name:       "CRITICAL - active CORRUPT alarm",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL - active CORRUPT alarm",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -225,10 +261,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Active alarm: CORRUPT",
 		},
 		{
-			name:       "WARNING - DB size exceeds warning threshold",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "WARNING - DB size exceeds warning threshold",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 117878784, 96468992),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -238,10 +274,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "CRITICAL - DB size exceeds critical threshold",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL - DB size exceeds critical threshold",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 250000000, 200000000),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -251,10 +287,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "UNKNOWN - nil status response",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "UNKNOWN - nil status response",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: nil,
 				memberResp: makeEtcdMemberListResponse(3),
@@ -264,10 +300,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Empty response from Talos API",
 		},
 		{
-			name:       "UNKNOWN - empty status messages",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "UNKNOWN - empty status messages",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: &machine.EtcdStatusResponse{},
 				memberResp: makeEtcdMemberListResponse(3),
@@ -277,10 +313,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Empty response from Talos API",
 		},
 		{
-			name:       "UNKNOWN - nil member status",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "UNKNOWN - nil member status",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: &machine.EtcdStatusResponse{
 					Messages: []*machine.EtcdStatus{
@@ -294,20 +330,20 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "No etcd status data in response",
 		},
 		{
-			name:       "error from EtcdStatus",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "error from EtcdStatus",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusErr: fmt.Errorf("etcd not running on this node"),
 			},
 			wantErr: true,
 		},
 		{
-			name:       "error from EtcdMemberList",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "error from EtcdMemberList",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberErr:  fmt.Errorf("connection refused"),
@@ -315,10 +351,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:       "error from EtcdAlarmList",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "error from EtcdAlarmList",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -327,10 +363,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:       "UNKNOWN - nil member list response",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "UNKNOWN - nil member list response",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: nil,
@@ -340,10 +376,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Empty member list response from Talos API",
 		},
 		{
-			name:       "UNKNOWN - empty member list messages",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "UNKNOWN - empty member list messages",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: &machine.EtcdMemberListResponse{},
@@ -353,10 +389,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Empty member list response from Talos API",
 		},
 		{
-			name:       "OK - DB size at exact warning boundary (not violated)",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - DB size at exact warning boundary (not violated)",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 100000000, 80000000),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -366,10 +402,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "WARNING - DB size just above warning boundary",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "WARNING - DB size just above warning boundary",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 100000001, 80000000),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -379,10 +415,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "OK - 5 members with min 3",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - 5 members with min 3",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(5),
@@ -392,10 +428,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "5/3 members",
 		},
 		{
-			name:       "OK - NONE alarm type is ignored",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - NONE alarm type is ignored",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -405,10 +441,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "OK - nil alarm response treated as no alarms",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - nil alarm response treated as no alarms",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -418,10 +454,10 @@ func TestEtcdCheckRun(t *testing.T) {
 			wantSubstr: "Leader",
 		},
 		{
-			name:       "OK - follower node reports leader ID",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK - follower node reports leader ID",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(5678, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
@@ -434,7 +470,7 @@ func TestEtcdCheckRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.minMembers)
+			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.clusterSize, EtcdOptions{})
 			if err != nil {
 				t.Fatalf("NewEtcdCheck: %v", err)
 			}
@@ -471,7 +507,7 @@ func TestEtcdCheckRun(t *testing.T) {
 }
 
 func TestEtcdCheckPerfData(t *testing.T) {
-	ch, err := NewEtcdCheck("~:100000000", "~:200000000", 3)
+	ch, err := NewEtcdCheck("~:100000000", "~:200000000", 3, EtcdOptions{})
 	if err != nil {
 		t.Fatalf("NewEtcdCheck: %v", err)
 	}
@@ -487,8 +523,8 @@ func TestEtcdCheckPerfData(t *testing.T) {
 		t.Fatalf("Run: %v", err)
 	}
 
-	if len(result.PerfData) != 3 {
-		t.Fatalf("PerfData length = %d, want 3", len(result.PerfData))
+	if len(result.PerfData) != 9 {
+		t.Fatalf("PerfData length = %d, want 9", len(result.PerfData))
 	}
 
 	// etcd_dbsize
@@ -547,103 +583,271 @@ func TestEtcdCheckPerfData(t *testing.T) {
 	if pd.UOM != "" {
 		t.Errorf("PerfData[2].UOM = %q, want empty", pd.UOM)
 	}
-	if pd.Warn != "" {
-		t.Errorf("PerfData[2].Warn = %q, want empty", pd.Warn)
+	if pd.Warn != "3:" {
+		t.Errorf("PerfData[2].Warn = %q, want %q", pd.Warn, "3:")
 	}
-	if pd.Crit != "" {
-		t.Errorf("PerfData[2].Crit = %q, want empty", pd.Crit)
+	if pd.Crit != "2:" {
+		t.Errorf("PerfData[2].Crit = %q, want %q (quorum)", pd.Crit, "2:")
 	}
 	if pd.Min != "0" {
 		t.Errorf("PerfData[2].Min = %q, want %q", pd.Min, "0")
 	}
+
+	// etcd_learners
+	pd = result.PerfData[3]
+	if pd.Label != "etcd_learners" {
+		t.Errorf("PerfData[3].Label = %q, want %q", pd.Label, "etcd_learners")
+	}
+	if pd.Value != 0 {
+		t.Errorf("PerfData[3].Value = %v, want %v", pd.Value, 0)
+	}
+
+	// etcd_quota_usage: 13107200 / 2GiB, thresholds only with --units percent
+	pd = result.PerfData[4]
+	if pd.Label != "etcd_quota_usage" {
+		t.Errorf("PerfData[4].Label = %q, want %q", pd.Label, "etcd_quota_usage")
+	}
+	if pd.Value != 0.6 {
+		t.Errorf("PerfData[4].Value = %v, want %v", pd.Value, 0.6)
+	}
+	if pd.Warn != "" || pd.Crit != "" {
+		t.Errorf("PerfData[4] Warn/Crit = %q/%q, want empty", pd.Warn, pd.Crit)
+	}
+	if pd.Max != "100" {
+		t.Errorf("PerfData[4].Max = %q, want %q", pd.Max, "100")
+	}
+
+	// etcd_fragmentation: 1 - 8388608/13107200
+	pd = result.PerfData[5]
+	if pd.Label != "etcd_fragmentation" {
+		t.Errorf("PerfData[5].Label = %q, want %q", pd.Label, "etcd_fragmentation")
+	}
+	if pd.Value != 36 {
+		t.Errorf("PerfData[5].Value = %v, want %v", pd.Value, 36)
+	}
+	if pd.Max != "100" {
+		t.Errorf("PerfData[5].Max = %q, want %q", pd.Max, "100")
+	}
+
+	// etcd_errors
+	pd = result.PerfData[8]
+	if pd.Label != "etcd_errors" {
+		t.Errorf("PerfData[8].Label = %q, want %q", pd.Label, "etcd_errors")
+	}
+	if pd.Value != 0 {
+		t.Errorf("PerfData[8].Value = %v, want %v", pd.Value, 0)
+	}
 }
 
-func TestEtcdCheckOutputFormat(t *testing.T) {
+func TestEtcdCheckQuotaAndFragmentation(t *testing.T) {
+	const gib = 1 << 30
+
 	tests := []struct {
 		name       string
 		warn       string
 		crit       string
-		minMembers int
-		client     *mockEtcdClient
-		want       string
+		opts       EtcdOptions
+		dbSize     int64
+		dbInUse    int64
+		wantStatus output.Status
+		wantOutput string
 	}{
 		{
-			name:       "OK output matches DESIGN.md format",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:       "percent OK",
+			warn:       "80",
+			crit:       "90",
+			opts:       EtcdOptions{Units: EtcdUnitsPercent},
+			dbSize:     gib,
+			dbInUse:    gib * 3 / 4,
+			wantStatus: output.OK,
+			wantOutput: "TALOS ETCD OK - Leader, 3/3 members, DB 1.00 GB (50.0% of 2.00 GB quota) | etcd_dbsize=1073741824B;;;0; etcd_dbsize_in_use=805306368B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=50;80;90;0;100 etcd_fragmentation=25;;;0;100",
+		},
+		{
+			name:       "percent CRITICAL from data recommends no defrag",
+			warn:       "80",
+			crit:       "90",
+			opts:       EtcdOptions{Units: EtcdUnitsPercent},
+			dbSize:     gib * 19 / 10,
+			dbInUse:    gib * 19 / 10,
+			wantStatus: output.Critical,
+			wantOutput: "TALOS ETCD CRITICAL - Leader, 3/3 members, DB 1.90 GB (95.0% of 2.00 GB quota), critical threshold 90 |",
+		},
+		{
+			name:       "percent CRITICAL from fragmentation recommends defrag",
+			warn:       "80",
+			crit:       "90",
+			opts:       EtcdOptions{Units: EtcdUnitsPercent},
+			dbSize:     gib * 19 / 10,
+			dbInUse:    gib / 2,
+			wantStatus: output.Critical,
+			wantOutput: "TALOS ETCD CRITICAL - Leader, 3/3 members, DB 1.90 GB (95.0% of 2.00 GB quota), critical threshold 90, defragment to reclaim 1.40 GB (talosctl etcd defrag) |",
+		},
+		{
+			name:       "custom quota",
+			warn:       "80",
+			crit:       "90",
+			opts:       EtcdOptions{Units: EtcdUnitsPercent, Quota: "8GiB"},
+			dbSize:     gib * 19 / 10,
+			dbInUse:    gib * 19 / 10,
+			wantStatus: output.OK,
+			wantOutput: "TALOS ETCD OK - Leader, 3/3 members, DB 1.90 GB (23.7% of 8.00 GB quota) |",
+		},
+		{
+			name:       "fragmentation WARNING",
+			warn:       "~:100MB",
+			crit:       "~:200MB",
+			opts:       EtcdOptions{FragWarning: "50", FragCritical: "80"},
+			dbSize:     80000000,
+			dbInUse:    20000000,
+			wantStatus: output.Warning,
+			wantOutput: "TALOS ETCD WARNING - Leader, 3/3 members, DB 76.29 MB, 75.0% fragmented, warning threshold 50, defragment to reclaim 57.22 MB (talosctl etcd defrag) | etcd_dbsize=80000000B;~:100000000;~:200000000;0; etcd_dbsize_in_use=20000000B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=3.7;;;0;100 etcd_fragmentation=75;50;80;0;100",
+		},
+		{
+			name:       "fragmentation CRITICAL beats size WARNING",
+			warn:       "~:100MB",
+			crit:       "~:200MB",
+			opts:       EtcdOptions{FragWarning: "50", FragCritical: "80"},
+			dbSize:     150000000,
+			dbInUse:    15000000,
+			wantStatus: output.Critical,
+			wantOutput: "TALOS ETCD CRITICAL - Leader, 3/3 members, DB 143.05 MB, warning threshold ~:100MB, 90.0% fragmented, critical threshold 80, defragment to reclaim 128.75 MB (talosctl etcd defrag) |",
+		},
+		{
+			name:       "fragmentation unevaluated without thresholds",
+			warn:       "~:100MB",
+			crit:       "~:200MB",
+			dbSize:     80000000,
+			dbInUse:    20000000,
+			wantStatus: output.OK,
+			wantOutput: "TALOS ETCD OK - Leader, 3/3 members, DB 76.29 MB |",
+		},
+		{
+			name:       "empty database",
+			warn:       "~:100MB",
+			crit:       "~:200MB",
+			opts:       EtcdOptions{FragWarning: "50"},
+			wantStatus: output.OK,
+			wantOutput: "etcd_fragmentation=0;50;;0;100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck(tt.warn, tt.crit, 3, tt.opts)
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			result, err := ch.Run(context.Background(), &mockEtcdClient{
+				statusResp: makeEtcdStatusResponse(1234, 1234, tt.dbSize, tt.dbInUse),
+				memberResp: makeEtcdMemberListResponse(3),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if got := result.String(); !contains(got, tt.wantOutput) {
+				t.Errorf("output %q does not contain %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestEtcdCheckOutputFormat(t *testing.T) {
+	// The long text lists the members; the fixtures' leader 1234 is not
+	// one of them.
+	const roster1 = "\ncp-1 (id 1): follower, peer https://10.0.0.1:2380"
+	const roster3 = roster1 +
+		"\ncp-2 (id 2): follower, peer https://10.0.0.2:2380" +
+		"\ncp-3 (id 3): follower, peer https://10.0.0.3:2380"
+
+	tests := []struct {
+		name        string
+		warn        string
+		crit        string
+		clusterSize int
+		client      *mockEtcdClient
+		want        string
+	}{
+		{
+			name:        "OK output matches DESIGN.md format",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
-			want: "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=3;;;0;",
+			want: "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=0.6;;;0;100 etcd_fragmentation=36;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster3,
 		},
 		{
-			name:       "WARNING output matches DESIGN.md format",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "WARNING output matches DESIGN.md format",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 117878784, 96468992),
 				memberResp: makeEtcdMemberListResponse(3),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
-			want: "TALOS ETCD WARNING - Leader, 3/3 members, DB 112.42 MB | etcd_dbsize=117878784B;~:100000000;~:200000000;0; etcd_dbsize_in_use=96468992B;;;0; etcd_members=3;;;0;",
+			want: "TALOS ETCD WARNING - Leader, 3/3 members, DB 112.42 MB, warning threshold ~:100MB, defragment to reclaim 20.42 MB (talosctl etcd defrag) | etcd_dbsize=117878784B;~:100000000;~:200000000;0; etcd_dbsize_in_use=96468992B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=5.5;;;0;100 etcd_fragmentation=18.2;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster3,
 		},
 		{
-			name:       "CRITICAL no leader matches DESIGN.md format",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL no leader matches DESIGN.md format",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(0, 0, 45000000, 40000000),
 				memberResp: makeEtcdMemberListResponse(3),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
-			want: "TALOS ETCD CRITICAL - No leader elected | etcd_dbsize=45000000B;~:100000000;~:200000000;0; etcd_dbsize_in_use=40000000B;;;0; etcd_members=3;;;0;",
+			want: "TALOS ETCD CRITICAL - No leader elected | etcd_dbsize=45000000B;~:100000000;~:200000000;0; etcd_dbsize_in_use=40000000B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=2.1;;;0;100 etcd_fragmentation=11.1;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster3,
 		},
 		{
-			name:       "CRITICAL member count below minimum",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL voting members below quorum",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 13107200, 8388608),
-				memberResp: makeEtcdMemberListResponse(2),
+				memberResp: makeEtcdMemberListResponse(1),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
-			want: "TALOS ETCD CRITICAL - Member count 2 below minimum 3 | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=2;;;0;",
+			want: "TALOS ETCD CRITICAL - Voting members 1 below quorum 2 of cluster size 3 | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=1;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=0.6;;;0;100 etcd_fragmentation=36;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster1,
 		},
 		{
-			name:       "CRITICAL active NOSPACE alarm",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "CRITICAL active NOSPACE alarm",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(1234, 1234, 2147483648, 2000000000),
 				memberResp: makeEtcdMemberListResponse(3),
 				alarmResp:  makeEtcdAlarmListResponse(machine.EtcdMemberAlarm_NOSPACE),
 			},
-			want: "TALOS ETCD CRITICAL - Active alarm: NOSPACE | etcd_dbsize=2147483648B;~:100000000;~:200000000;0; etcd_dbsize_in_use=2000000000B;;;0; etcd_members=3;;;0;",
+			want: "TALOS ETCD CRITICAL - Active alarm: NOSPACE | etcd_dbsize=2147483648B;~:100000000;~:200000000;0; etcd_dbsize_in_use=2000000000B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=100;;;0;100 etcd_fragmentation=6.9;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster3,
 		},
 		{
-			name:       "OK follower output format",
-			warn:       "~:100000000",
-			crit:       "~:200000000",
-			minMembers: 3,
+			name:        "OK follower output format",
+			warn:        "~:100000000",
+			crit:        "~:200000000",
+			clusterSize: 3,
 			client: &mockEtcdClient{
 				statusResp: makeEtcdStatusResponse(5678, 1234, 13107200, 8388608),
 				memberResp: makeEtcdMemberListResponse(3),
 				alarmResp:  makeEtcdAlarmListResponse(),
 			},
-			want: "TALOS ETCD OK - Follower, leader 1234, 3/3 members, DB 12.50 MB | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=3;;;0;",
+			want: "TALOS ETCD OK - Follower, leader 1234, 3/3 members, DB 12.50 MB | etcd_dbsize=13107200B;~:100000000;~:200000000;0; etcd_dbsize_in_use=8388608B;;;0; etcd_members=3;3:;2:;0; etcd_learners=0;;;0; etcd_quota_usage=0.6;;;0;100 etcd_fragmentation=36;;;0;100 etcd_raft_lag=0;;;0; etcd_raft_term=0c;;;0; etcd_errors=0;;;0;" + roster3,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.minMembers)
+			ch, err := NewEtcdCheck(tt.warn, tt.crit, tt.clusterSize, EtcdOptions{})
 			if err != nil {
 				t.Fatalf("NewEtcdCheck: %v", err)
 			}
@@ -664,7 +868,7 @@ func TestEtcdCheckStructuralAssertionsBeforeThresholds(t *testing.T) {
 	// take precedence over threshold evaluation, even when DB size
 	// is within normal range.
 	t.Run("no leader takes precedence over OK DB size", func(t *testing.T) {
-		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3)
+		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3, EtcdOptions{})
 		client := &mockEtcdClient{
 			statusResp: makeEtcdStatusResponse(0, 0, 5000000, 4000000), // Small DB, but no leader
 			memberResp: makeEtcdMemberListResponse(3),
@@ -683,7 +887,7 @@ func TestEtcdCheckStructuralAssertionsBeforeThresholds(t *testing.T) {
 	})
 
 	t.Run("low members takes precedence over alarm", func(t *testing.T) {
-		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3)
+		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3, EtcdOptions{})
 		client := &mockEtcdClient{
 			statusResp: makeEtcdStatusResponse(1234, 1234, 5000000, 4000000),
 			memberResp: makeEtcdMemberListResponse(1), // Below minimum
@@ -696,13 +900,13 @@ func TestEtcdCheckStructuralAssertionsBeforeThresholds(t *testing.T) {
 		if result.Status != output.Critical {
 			t.Errorf("status = %v, want CRITICAL", result.Status)
 		}
-		if !contains(result.Summary, "Member count 1 below minimum 3") {
-			t.Errorf("summary %q should contain member count message", result.Summary)
+		if !contains(result.Summary, "Voting members 1 below quorum 2 of cluster size 3") {
+			t.Errorf("summary %q should contain quorum message", result.Summary)
 		}
 	})
 
 	t.Run("alarm takes precedence over DB size threshold", func(t *testing.T) {
-		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3)
+		ch, _ := NewEtcdCheck("~:100000000", "~:200000000", 3, EtcdOptions{})
 		client := &mockEtcdClient{
 			statusResp: makeEtcdStatusResponse(1234, 1234, 5000000, 4000000), // Small DB
 			memberResp: makeEtcdMemberListResponse(3),
@@ -720,3 +924,605 @@ func TestEtcdCheckStructuralAssertionsBeforeThresholds(t *testing.T) {
 		}
 	})
 }
+
+// makeEtcdRaftStatusResponse builds an EtcdStatusResponse for a small leader
+// with the given raft term, committed index and applied index.
+func makeEtcdRaftStatusResponse(memberId, term, index, applied uint64) *machine.EtcdStatusResponse {
+	resp := makeEtcdStatusResponse(memberId, memberId, 13107200, 8388608)
+	ms := resp.GetMessages()[0].GetMemberStatus()
+	ms.RaftTerm = term
+	ms.RaftIndex = index
+	ms.RaftAppliedIndex = applied
+	return resp
+}
+
+func TestEtcdCheckRaftLag(t *testing.T) {
+	tests := []struct {
+		name       string
+		index      uint64
+		applied    uint64
+		wantStatus output.Status
+		wantOutput string
+	}{
+		{
+			name:       "caught up",
+			index:      5000,
+			applied:    5000,
+			wantStatus: output.OK,
+			wantOutput: "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB |",
+		},
+		{
+			name:       "WARNING lag",
+			index:      5000,
+			applied:    3500,
+			wantStatus: output.Warning,
+			wantOutput: "TALOS ETCD WARNING - Leader, 3/3 members, DB 12.50 MB, 1500 raft entries not applied, warning threshold 1000 |",
+		},
+		{
+			name:       "CRITICAL lag",
+			index:      9000,
+			applied:    2000,
+			wantStatus: output.Critical,
+			wantOutput: "7000 raft entries not applied, critical threshold 5000 |",
+		},
+		{
+			name:       "applied ahead of committed reads as no lag",
+			index:      100,
+			applied:    101,
+			wantStatus: output.OK,
+			wantOutput: "etcd_raft_lag=0;1000;5000;0;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{LagWarning: "1000", LagCritical: "5000"})
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			result, err := ch.Run(context.Background(), &mockEtcdClient{
+				statusResp: makeEtcdRaftStatusResponse(1234, 7, tt.index, tt.applied),
+				memberResp: makeEtcdMemberListResponse(3),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if got := result.String(); !contains(got, tt.wantOutput) {
+				t.Errorf("output %q does not contain %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestEtcdCheckTermChanges(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "etcd.json")
+	ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{
+		TermWarning: "1", TermCritical: "3", StateFile: stateFile,
+	})
+	if err != nil {
+		t.Fatalf("NewEtcdCheck: %v", err)
+	}
+
+	// Each run sees the term of the step; changes count from the previous run.
+	steps := []struct {
+		name       string
+		memberId   uint64
+		term       uint64
+		wantStatus output.Status
+		wantOutput string
+	}{
+		{"first run has no baseline", 1234, 10, output.OK, "etcd_raft_term=10c;;;0; etcd_errors=0;;;0;"},
+		{"same term", 1234, 10, output.OK, "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB |"},
+		{"one election", 1234, 11, output.OK, "TALOS ETCD OK"},
+		{"two elections", 1234, 13, output.Warning, "2 leader elections since last check (term 13), warning threshold 1 |"},
+		{"four elections", 1234, 17, output.Critical, "4 leader elections since last check (term 17), critical threshold 3 |"},
+		{"lower term starts over", 1234, 2, output.OK, "etcd_raft_term=2c;;;0; etcd_errors=0;;;0;"},
+		{"other member starts over", 5678, 9, output.OK, "TALOS ETCD OK"},
+	}
+	for _, st := range steps {
+		result, err := ch.Run(context.Background(), &mockEtcdClient{
+			statusResp: makeEtcdRaftStatusResponse(st.memberId, st.term, 100, 100),
+			memberResp: makeEtcdMemberListResponse(3),
+			alarmResp:  makeEtcdAlarmListResponse(),
+		})
+		if err != nil {
+			t.Fatalf("%s: Run: %v", st.name, err)
+		}
+		if result.Status != st.wantStatus {
+			t.Errorf("%s: Status = %v, want %v", st.name, result.Status, st.wantStatus)
+		}
+		if got := result.String(); !contains(got, st.wantOutput) {
+			t.Errorf("%s: output %q does not contain %q", st.name, got, st.wantOutput)
+		}
+	}
+}
+
+func TestEtcdCheckTermRecordedWithoutLeader(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "etcd.json")
+	ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{TermWarning: "1", StateFile: stateFile})
+	if err != nil {
+		t.Fatalf("NewEtcdCheck: %v", err)
+	}
+
+	noLeader := makeEtcdRaftStatusResponse(1234, 5, 100, 100)
+	noLeader.GetMessages()[0].GetMemberStatus().Leader = 0
+	for _, resp := range []*machine.EtcdStatusResponse{makeEtcdRaftStatusResponse(1234, 3, 100, 100), noLeader} {
+		if _, err := ch.Run(context.Background(), &mockEtcdClient{
+			statusResp: resp,
+			memberResp: makeEtcdMemberListResponse(3),
+			alarmResp:  makeEtcdAlarmListResponse(),
+		}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	}
+
+	// The leaderless run stored term 5, so the elections are not counted twice.
+	result, err := ch.Run(context.Background(), &mockEtcdClient{
+		statusResp: makeEtcdRaftStatusResponse(1234, 5, 100, 100),
+		memberResp: makeEtcdMemberListResponse(3),
+		alarmResp:  makeEtcdAlarmListResponse(),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.OK {
+		t.Errorf("Status = %v, want OK: %q", result.Status, result.Summary)
+	}
+}
+
+func TestEtcdCheckStateFileError(t *testing.T) {
+	// A regular file where the state directory should be.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts EtcdOptions
+	}{
+		{"no term thresholds", EtcdOptions{StateFile: filepath.Join(blocker, "etcd.json")}},
+		{"term thresholds", EtcdOptions{TermWarning: "0", StateFile: filepath.Join(blocker, "etcd.json")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, tt.opts)
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			result, err := ch.Run(context.Background(), &mockEtcdClient{
+				statusResp: makeEtcdRaftStatusResponse(1234, 3, 100, 100),
+				memberResp: makeEtcdMemberListResponse(3),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			// The term check is skipped; everything else is still evaluated.
+			want := "Leader, 3/3 members, DB 12.50 MB, leader elections not tracked (cannot read state file: "
+			if result.Status != output.OK || !strings.HasPrefix(result.Summary, want) {
+				t.Errorf("got %v %q, want OK %q...", result.Status, result.Summary, want)
+			}
+			if len(result.PerfData) != 9 {
+				t.Errorf("len(PerfData) = %d, want 9", len(result.PerfData))
+			}
+		})
+	}
+}
+
+// makeEtcdNodeStatus builds the reply of one node to EtcdStatusNodes.
+func makeEtcdNodeStatus(node string, memberId, leader, index, applied uint64, inUse int64, errs ...string) *machine.EtcdStatus {
+	return &machine.EtcdStatus{
+		Metadata: &common.Metadata{Hostname: node},
+		MemberStatus: &machine.EtcdMemberStatus{
+			MemberId:         memberId,
+			Leader:           leader,
+			RaftIndex:        index,
+			RaftAppliedIndex: applied,
+			DbSize:           13107200,
+			DbSizeInUse:      inUse,
+			Errors:           errs,
+		},
+	}
+}
+
+func TestEtcdCheckCluster(t *testing.T) {
+	healthy := func() []*machine.EtcdStatus {
+		return []*machine.EtcdStatus{
+			makeEtcdNodeStatus("10.0.0.1", 1, 1, 500, 500, 8388608),
+			makeEtcdNodeStatus("10.0.0.2", 2, 1, 500, 499, 8388608),
+			makeEtcdNodeStatus("10.0.0.3", 3, 1, 499, 499, 8388608),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modify     func([]*machine.EtcdStatus) []*machine.EtcdStatus
+		wantStatus output.Status
+		wantOutput string
+	}{
+		{
+			name:       "all members agree",
+			modify:     func(s []*machine.EtcdStatus) []*machine.EtcdStatus { return s },
+			wantStatus: output.OK,
+			wantOutput: "TALOS ETCD OK - Leader, 3/3 members, DB 12.50 MB, 3/3 members agree |",
+		},
+		{
+			name: "split brain",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[2].MemberStatus.Leader = 3
+				return s
+			},
+			wantStatus: output.Critical,
+			wantOutput: "TALOS ETCD CRITICAL - Cluster inconsistent: cp-3 sees leader 3 |",
+		},
+		{
+			name: "member of another cluster",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[2].MemberStatus.MemberId = 99
+				s[2].MemberStatus.Leader = 99
+				return s
+			},
+			wantStatus: output.Critical,
+			wantOutput: "Cluster inconsistent: cp-3 is member 99, not in member list, cp-3 sees leader 99 |",
+		},
+		{
+			name: "member without a leader",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[1].MemberStatus.Leader = 0
+				return s
+			},
+			wantStatus: output.Critical,
+			wantOutput: "Cluster inconsistent: cp-2 sees no leader |",
+		},
+		{
+			name: "member reports critical errors",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[1].MemberStatus.Errors = []string{"memberID:2 alarm:CORRUPT "}
+				return s
+			},
+			wantStatus: output.Critical,
+			wantOutput: "Cluster inconsistent: cp-2 reports errors: memberID:2 alarm:CORRUPT  |",
+		},
+		{
+			name: "member reports other errors",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[1].MemberStatus.Errors = []string{"etcdserver: request timed out"}
+				return s
+			},
+			wantStatus: output.Warning,
+			wantOutput: "3/3 members agree, cp-2 reports errors: etcdserver: request timed out |",
+		},
+		{
+			name: "1 of 3 unreachable",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[1] = &machine.EtcdStatus{Metadata: &common.Metadata{Hostname: "10.0.0.2", Error: "connection refused"}}
+				return s
+			},
+			wantStatus: output.Warning,
+			wantOutput: "TALOS ETCD WARNING - Leader, 3/3 members, DB 12.50 MB, 2/3 members agree, cp-2: connection refused |",
+		},
+		{
+			name:       "missing reply",
+			modify:     func(s []*machine.EtcdStatus) []*machine.EtcdStatus { return s[:2] },
+			wantStatus: output.Warning,
+			wantOutput: "2/3 members agree, cp-3: no response |",
+		},
+		{
+			name: "2 of 3 unreachable",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[1] = &machine.EtcdStatus{Metadata: &common.Metadata{Hostname: "10.0.0.2", Error: "connection refused"}}
+				return s[:2]
+			},
+			wantStatus: output.Critical,
+			wantOutput: "Cluster inconsistent: 1/3 voting members answering, below quorum 2, cp-2: connection refused, cp-3: no response |",
+		},
+		{
+			name: "lagging member",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[2].MemberStatus.RaftIndex = 9000
+				s[2].MemberStatus.RaftAppliedIndex = 2000
+				for _, st := range s[:2] {
+					st.MemberStatus.RaftIndex = 9000
+					st.MemberStatus.RaftAppliedIndex = 9000
+				}
+				return s
+			},
+			wantStatus: output.Critical,
+			wantOutput: "3/3 members agree, cp-3 7000 raft entries behind, critical threshold 5000 |",
+		},
+		{
+			name: "DB sizes out of tolerance",
+			modify: func(s []*machine.EtcdStatus) []*machine.EtcdStatus {
+				s[2].MemberStatus.DbSizeInUse = 4194304
+				return s
+			},
+			wantStatus: output.Warning,
+			wantOutput: "3/3 members agree, in-use DB sizes differ by 50.0%, tolerance 20 |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{
+				LagWarning:    "1000",
+				LagCritical:   "5000",
+				Cluster:       true,
+				SizeTolerance: "20",
+			})
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			client := &mockEtcdClient{
+				statusResp: makeEtcdStatusResponse(1, 1, 13107200, 8388608),
+				memberResp: makeEtcdMemberListResponse(3),
+				alarmResp:  makeEtcdAlarmListResponse(),
+				nodesResp:  &machine.EtcdStatusResponse{Messages: tt.modify(healthy())},
+			}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if got := result.String(); !contains(got, tt.wantOutput) {
+				t.Errorf("output %q does not contain %q", got, tt.wantOutput)
+			}
+			if got := strings.Join(client.nodes, ","); got != "10.0.0.1,10.0.0.2,10.0.0.3" {
+				t.Errorf("nodes = %q, want the member client URL hosts", got)
+			}
+			if n := len(strings.Split(result.Details, "\n")); n != 6 {
+				t.Errorf("Details has %d lines, want a role and a status line per member:\n%s", n, result.Details)
+			}
+		})
+	}
+}
+
+func TestEtcdCheckClusterPerfData(t *testing.T) {
+	ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{
+		LagWarning:    "1000",
+		LagCritical:   "5000",
+		Cluster:       true,
+		SizeTolerance: "20",
+	})
+	if err != nil {
+		t.Fatalf("NewEtcdCheck: %v", err)
+	}
+	result, err := ch.Run(context.Background(), &mockEtcdClient{
+		statusResp: makeEtcdStatusResponse(1, 1, 13107200, 8388608),
+		memberResp: makeEtcdMemberListResponse(3),
+		alarmResp:  makeEtcdAlarmListResponse(),
+		nodesResp: &machine.EtcdStatusResponse{Messages: []*machine.EtcdStatus{
+			makeEtcdNodeStatus("10.0.0.1", 1, 1, 500, 500, 8388608),
+			makeEtcdNodeStatus("10.0.0.2", 2, 1, 500, 480, 8388608),
+			makeEtcdNodeStatus("10.0.0.3", 3, 1, 490, 490, 7549747),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := " etcd_cluster_answering=3;;;0;3 etcd_cluster_lag=20;1000;5000;0; etcd_cluster_size_spread=10;20;;0;100\n"
+	if got := result.String(); !contains(got, want) {
+		t.Errorf("output %q does not contain %q", got, want)
+	}
+}
+
+func TestEtcdCheckClusterErrors(t *testing.T) {
+	ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{Cluster: true})
+	if err != nil {
+		t.Fatalf("NewEtcdCheck: %v", err)
+	}
+	base := func() *mockEtcdClient {
+		return &mockEtcdClient{
+			statusResp: makeEtcdStatusResponse(1, 1, 13107200, 8388608),
+			memberResp: makeEtcdMemberListResponse(3),
+			alarmResp:  makeEtcdAlarmListResponse(),
+		}
+	}
+
+	t.Run("client error is returned", func(t *testing.T) {
+		client := base()
+		client.nodesErr = fmt.Errorf("connection refused")
+		if _, err := ch.Run(context.Background(), client); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("empty response is UNKNOWN", func(t *testing.T) {
+		client := base()
+		client.nodesResp = &machine.EtcdStatusResponse{}
+		result, err := ch.Run(context.Background(), client)
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if result.Status != output.Unknown || result.Summary != "Empty response from Talos API" {
+			t.Errorf("got %v %q, want UNKNOWN Empty response from Talos API", result.Status, result.Summary)
+		}
+	})
+
+	t.Run("not queried without --cluster", func(t *testing.T) {
+		single, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{})
+		if err != nil {
+			t.Fatalf("NewEtcdCheck: %v", err)
+		}
+		client := base()
+		client.nodesErr = fmt.Errorf("must not be called")
+		result, err := single.Run(context.Background(), client)
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if result.Status != output.OK || client.nodes != nil {
+			t.Errorf("got %v, nodes %v; want OK without a cluster query", result.Status, client.nodes)
+		}
+	})
+}
+
+// makeEtcdLearnerMemberListResponse builds a member list of voting members
+// cp-1..cp-<voting> followed by learners.
+func makeEtcdLearnerMemberListResponse(voting, learners int) *machine.EtcdMemberListResponse {
+	resp := makeEtcdMemberListResponse(voting + learners)
+	for _, m := range resp.GetMessages()[0].GetMembers()[voting:] {
+		m.IsLearner = true
+	}
+	return resp
+}
+
+func TestEtcdCheckLearners(t *testing.T) {
+	tests := []struct {
+		name        string
+		voting      int
+		learners    int
+		wantStatus  output.Status
+		wantSummary string
+		wantPerf    string
+	}{
+		{
+			name:        "learner beside a full cluster",
+			voting:      3,
+			learners:    1,
+			wantStatus:  output.OK,
+			wantSummary: "Leader, 3/3 members (+1 learner), DB 12.50 MB",
+			wantPerf:    "etcd_members=3;3:;2:;0; etcd_learners=1;;;0;",
+		},
+		{
+			name:        "learner does not count toward the cluster size",
+			voting:      2,
+			learners:    1,
+			wantStatus:  output.Warning,
+			wantSummary: "Leader, 2/3 members (+1 learner), fault tolerance 0, DB 12.50 MB",
+			wantPerf:    "etcd_members=2;3:;2:;0; etcd_learners=1;;;0;",
+		},
+		{
+			name:        "learners do not count toward quorum",
+			voting:      1,
+			learners:    2,
+			wantStatus:  output.Critical,
+			wantSummary: "Voting members 1 below quorum 2 of cluster size 3",
+			wantPerf:    "etcd_members=1;3:;2:;0; etcd_learners=2;;;0;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{})
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			result, err := ch.Run(context.Background(), &mockEtcdClient{
+				statusResp: makeEtcdStatusResponse(1, 1, 13107200, 8388608),
+				memberResp: makeEtcdLearnerMemberListResponse(tt.voting, tt.learners),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("Summary = %q, want %q", result.Summary, tt.wantSummary)
+			}
+			if got := result.String(); !contains(got, tt.wantPerf) {
+				t.Errorf("output %q does not contain %q", got, tt.wantPerf)
+			}
+
+			lines := strings.Split(result.Details, "\n")
+			if len(lines) != tt.voting+tt.learners {
+				t.Fatalf("Details has %d lines, want one per member:\n%s", len(lines), result.Details)
+			}
+			if want := "cp-1 (id 1): leader, peer https://10.0.0.1:2380"; lines[0] != want {
+				t.Errorf("Details[0] = %q, want %q", lines[0], want)
+			}
+			last := tt.voting + tt.learners
+			if want := fmt.Sprintf("cp-%d (id %d): learner, peer https://10.0.0.%d:2380", last, last, last); lines[last-1] != want {
+				t.Errorf("Details[%d] = %q, want %q", last-1, lines[last-1], want)
+			}
+		})
+	}
+}
+
+func TestEtcdCheckMemberErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		errors      []string
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails []string // leading long-text lines
+	}{
+		{
+			name:        "no errors",
+			wantStatus:  output.OK,
+			wantSummary: "Leader, 3/3 members, DB 12.50 MB",
+		},
+		{
+			name:        "transient error is WARNING",
+			errors:      []string{"etcdserver: request timed out"},
+			wantStatus:  output.Warning,
+			wantSummary: "Leader, 3/3 members, DB 12.50 MB, member errors: etcdserver: request timed out",
+			wantDetails: []string{"error (warning): etcdserver: request timed out"},
+		},
+		{
+			name:        "alarm is CRITICAL",
+			errors:      []string{"memberID:1 alarm:NOSPACE"},
+			wantStatus:  output.Critical,
+			wantSummary: "Member errors: memberID:1 alarm:NOSPACE",
+			wantDetails: []string{"error (critical): memberID:1 alarm:NOSPACE"},
+		},
+		{
+			name:        "critical errors listed first",
+			errors:      []string{"etcdserver: leader changed", "memberID:1 alarm:CORRUPT"},
+			wantStatus:  output.Critical,
+			wantSummary: "Member errors: memberID:1 alarm:CORRUPT",
+			wantDetails: []string{
+				"error (critical): memberID:1 alarm:CORRUPT",
+				"error (warning): etcdserver: leader changed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewEtcdCheck("~:100MB", "~:200MB", 3, EtcdOptions{})
+			if err != nil {
+				t.Fatalf("NewEtcdCheck: %v", err)
+			}
+			status := makeEtcdStatusResponse(1, 1, 13107200, 8388608)
+			status.GetMessages()[0].GetMemberStatus().Errors = tt.errors
+			result, err := ch.Run(context.Background(), &mockEtcdClient{
+				statusResp: status,
+				memberResp: makeEtcdMemberListResponse(3),
+				alarmResp:  makeEtcdAlarmListResponse(),
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("Summary = %q, want %q", result.Summary, tt.wantSummary)
+			}
+			want := fmt.Sprintf("etcd_errors=%d;;;0;", len(tt.errors))
+			if got := result.String(); !contains(got, want) {
+				t.Errorf("output %q does not contain %q", got, want)
+			}
+
+			lines := strings.Split(result.Details, "\n")
+			if len(lines) != len(tt.wantDetails)+3 {
+				t.Fatalf("Details has %d lines, want the errors and 3 members:\n%s", len(lines), result.Details)
+			}
+			for i, want := range tt.wantDetails {
+				if lines[i] != want {
+					t.Errorf("Details[%d] = %q, want %q", i, lines[i], want)
+				}
+			}
+		})
+	}
+}
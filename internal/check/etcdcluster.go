@@ -0,0 +1,227 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// etcdNode is one member as seen by the cluster-wide fan-out.
+type etcdNode struct {
+	name    string                    // member hostname
+	addr    string                    // node address the request went to
+	learner bool                      // non-voting member
+	status  *machine.EtcdMemberStatus // nil when the node did not answer
+	err     string                    // why the node did not answer
+}
+
+// etcdCluster is the cluster-wide view built in --cluster mode.
+type etcdCluster struct {
+	nodes     []etcdNode
+	lag       uint64  // largest distance of an applied index behind the highest committed index
+	lagging   string  // member with that lag
+	spread    float64 // spread of the in-use DB sizes, percent of the largest
+	answering int
+}
+
+// etcdCluster asks every member for its status through apid and computes
+// the cross-member metrics. Members are addressed by the host of their
+// first client URL, which on Talos is the node IP. It returns a non-empty
+// unknown message when the response is unusable.
+func (ch *EtcdCheck) etcdCluster(ctx context.Context, client TalosClient, members []*machine.EtcdMember) (*etcdCluster, string, error) {
+	c := &etcdCluster{}
+	var addrs []string
+	for _, m := range members {
+		n := etcdNode{name: m.GetHostname(), addr: memberAddr(m), learner: m.GetIsLearner()}
+		if n.name == "" {
+			n.name = fmt.Sprintf("%d", m.GetId())
+		}
+		if n.addr == "" {
+			n.err = "no client or peer URL"
+		} else {
+			addrs = append(addrs, n.addr)
+		}
+		c.nodes = append(c.nodes, n)
+	}
+	if len(addrs) == 0 {
+		return c, "", nil
+	}
+
+	resp, err := client.EtcdStatusNodes(ctx, addrs)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return nil, "Empty response from Talos API", nil
+	}
+
+	// apid names each reply after the node it was sent to.
+	byAddr := make(map[string]*machine.EtcdStatus, len(resp.GetMessages()))
+	for _, msg := range resp.GetMessages() {
+		byAddr[msg.GetMetadata().GetHostname()] = msg
+	}
+	for i := range c.nodes {
+		n := &c.nodes[i]
+		if n.addr == "" {
+			continue
+		}
+		msg, ok := byAddr[n.addr]
+		switch {
+		case !ok:
+			n.err = "no response"
+		case msg.GetMetadata().GetError() != "":
+			n.err = msg.GetMetadata().GetError()
+		case msg.GetMemberStatus() == nil:
+			n.err = "no etcd status data"
+		default:
+			n.status = msg.GetMemberStatus()
+		}
+	}
+
+	var highest uint64
+	var minInUse, maxInUse int64 = -1, 0
+	for _, n := range c.nodes {
+		if n.status == nil {
+			continue
+		}
+		c.answering++
+		highest = max(highest, n.status.GetRaftIndex())
+		inUse := n.status.GetDbSizeInUse()
+		maxInUse = max(maxInUse, inUse)
+		if minInUse < 0 || inUse < minInUse {
+			minInUse = inUse
+		}
+	}
+	for _, n := range c.nodes {
+		if n.status == nil || n.status.GetRaftAppliedIndex() >= highest {
+			continue
+		}
+		if lag := highest - n.status.GetRaftAppliedIndex(); lag > c.lag {
+			c.lag, c.lagging = lag, n.name
+		}
+	}
+	if maxInUse > 0 {
+		c.spread = roundPct(float64(maxInUse-minInUse) / float64(maxInUse) * 100)
+	}
+
+	return c, "", nil
+}
+
+// votersAnswering returns the number of voting members that answered.
+func (c *etcdCluster) votersAnswering() (answering, voters int) {
+	for _, n := range c.nodes {
+		if n.learner {
+			continue
+		}
+		voters++
+		if n.status != nil {
+			answering++
+		}
+	}
+	return answering, voters
+}
+
+// issues returns the disagreements that make the cluster view CRITICAL:
+// members that did not answer when the voters that did fall below quorum,
+// members that report critical errors, members the member list does not
+// know (a member of another cluster), and members that see a different
+// leader (a split brain).
+func (c *etcdCluster) issues(leader uint64, members []*machine.EtcdMember, quorum int) []string {
+	known := make(map[uint64]bool, len(members))
+	for _, m := range members {
+		known[m.GetId()] = true
+	}
+
+	var issues []string
+	answering, voters := c.votersAnswering()
+	if answering < quorum {
+		issues = append(issues, fmt.Sprintf("%d/%d voting members answering, below quorum %d", answering, voters, quorum))
+	}
+	for _, n := range c.nodes {
+		if n.status == nil {
+			if answering < quorum {
+				issues = append(issues, fmt.Sprintf("%s: %s", n.name, n.err))
+			}
+			continue
+		}
+		if critical, _ := splitEtcdErrors(n.status.GetErrors()); len(critical) > 0 {
+			issues = append(issues, fmt.Sprintf("%s reports errors: %s", n.name, strings.Join(critical, "; ")))
+		}
+		if id := n.status.GetMemberId(); !known[id] {
+			issues = append(issues, fmt.Sprintf("%s is member %d, not in member list", n.name, id))
+		}
+		switch l := n.status.GetLeader(); {
+		case l == 0:
+			issues = append(issues, fmt.Sprintf("%s sees no leader", n.name))
+		case l != leader:
+			issues = append(issues, fmt.Sprintf("%s sees leader %d", n.name, l))
+		}
+	}
+	return issues
+}
+
+// warnings returns what makes the cluster view WARNING: members that did
+// not answer while the voters that did still hold quorum, as without
+// --cluster a lost member with quorum held is, and the non-critical errors
+// members report.
+func (c *etcdCluster) warnings(quorum int) []string {
+	var warnings []string
+	answering, _ := c.votersAnswering()
+	for _, n := range c.nodes {
+		if n.status == nil {
+			if answering >= quorum {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", n.name, n.err))
+			}
+			continue
+		}
+		if _, other := splitEtcdErrors(n.status.GetErrors()); len(other) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s reports errors: %s", n.name, strings.Join(other, "; ")))
+		}
+	}
+	return warnings
+}
+
+// details returns one long-text line per member.
+func (c *etcdCluster) details() string {
+	lines := make([]string, len(c.nodes))
+	for i, n := range c.nodes {
+		if n.status == nil {
+			lines[i] = fmt.Sprintf("%s (%s): %s", n.name, n.addr, n.err)
+			continue
+		}
+		s := n.status
+		lines[i] = fmt.Sprintf("%s (%s): member %d, leader %d, raft index %d, applied %d, DB %s, in use %s",
+			n.name, n.addr, s.GetMemberId(), s.GetLeader(), s.GetRaftIndex(), s.GetRaftAppliedIndex(),
+			output.HumanBytes(uint64(s.GetDbSize())), output.HumanBytes(uint64(s.GetDbSizeInUse())))
+		if errs := s.GetErrors(); len(errs) > 0 {
+			lines[i] += ", errors: " + strings.Join(errs, "; ")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// perfData returns the cluster-wide perfdata.
+func (c *etcdCluster) perfData(ch *EtcdCheck) []output.PerfDatum {
+	return []output.PerfDatum{
+		{Label: "etcd_cluster_answering", Value: float64(c.answering), Min: "0", Max: fmt.Sprintf("%d", len(c.nodes))},
+		{Label: "etcd_cluster_lag", Value: float64(c.lag), Warn: optionalString(ch.LagWarning), Crit: optionalString(ch.LagCritical), Min: "0"},
+		{Label: "etcd_cluster_size_spread", Value: c.spread, Warn: optionalString(ch.SizeTolerance), Min: "0", Max: "100"},
+	}
+}
+
+// memberAddr returns the host of a member's first client URL, or of its
+// first peer URL for a member that has not started (a new learner).
+func memberAddr(m *machine.EtcdMember) string {
+	for _, urls := range [][]string{m.GetClientUrls(), m.GetPeerUrls()} {
+		for _, raw := range urls {
+			if u, err := url.Parse(raw); err == nil && u.Hostname() != "" {
+				return u.Hostname()
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,300 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/talos/pkg/machinery/resources/k8s"
+)
+
+// KubernetesCheck reports the health of the Kubernetes components Talos
+// runs on the node: the kubelet service and, on control plane nodes, the
+// kube-apiserver, kube-controller-manager and kube-scheduler static pods.
+//
+// The kubelet is read from ServiceList and is healthy under the same rule as
+// ServicesCheck. A static pod is expected when Talos has rendered its
+// StaticPod resource; its StaticPodStatus (the mirror pod status the kubelet
+// reports) must be in phase Running with the Ready condition True. Anything
+// else, including a missing status, is CRITICAL. This catches a
+// crash-looping control plane pod, which ServicesCheck cannot see because
+// the kubelet service itself stays healthy.
+type KubernetesCheck struct{}
+
+// NewKubernetesCheck creates a KubernetesCheck. It takes no options.
+func NewKubernetesCheck() (*KubernetesCheck, error) {
+	return &KubernetesCheck{}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *KubernetesCheck) Name() string { return "KUBERNETES" }
+
+// kubeComponent is the evaluated state of the kubelet or one static pod.
+type kubeComponent struct {
+	name     string
+	healthy  bool
+	detail   string  // long-text description without the status
+	restarts float64 // container restarts (static pods only)
+}
+
+// Run executes the Kubernetes component check against the Talos API.
+func (ch *KubernetesCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	kubelet, unknown, err := ch.kubelet(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if unknown != "" {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   unknown,
+		}, nil
+	}
+
+	pods, unknown, err := ch.staticPods(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if unknown != "" {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   unknown,
+		}, nil
+	}
+
+	components := append([]kubeComponent{kubelet}, pods...)
+
+	var unhealthy []string
+	details := make([]string, len(components))
+	for i, c := range components {
+		status := output.OK
+		if !c.healthy {
+			status = output.Critical
+			unhealthy = append(unhealthy, c.name)
+		}
+		details[i] = fmt.Sprintf("%s: %s, status=%s", c.name, c.detail, status)
+	}
+
+	perfData := []output.PerfDatum{
+		{Label: "kubernetes_components", Value: float64(len(components)), Min: "0"},
+		{Label: "kubernetes_unhealthy", Value: float64(len(unhealthy)), Min: "0"},
+	}
+	for _, p := range pods {
+		perfData = append(perfData, output.PerfDatum{
+			Label: strings.ReplaceAll(p.name, "-", "_") + "_restarts",
+			Value: p.restarts,
+			UOM:   "c",
+			Min:   "0",
+		})
+	}
+
+	if len(unhealthy) == 0 {
+		summary := fmt.Sprintf("kubelet healthy, %d/%d control plane pods ready", len(pods), len(pods))
+		if len(pods) == 0 {
+			summary = "kubelet healthy (no control plane static pods)"
+		}
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   summary,
+			Details:   strings.Join(details, "\n"),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	return &output.Result{
+		Status:    output.Critical,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d/%d Kubernetes components unhealthy: %s",
+			len(unhealthy), len(components), strings.Join(unhealthy, ", ")),
+		Details:  strings.Join(details, "\n"),
+		PerfData: perfData,
+	}, nil
+}
+
+// kubelet evaluates the kubelet service from ServiceList. It returns a
+// non-empty unknown message when the response is unusable.
+func (ch *KubernetesCheck) kubelet(ctx context.Context, client TalosClient) (kubeComponent, string, error) {
+	c := kubeComponent{name: "kubelet"}
+
+	resp, err := client.ServiceList(ctx)
+	if err != nil {
+		return c, "", err
+	}
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return c, "Empty response from Talos API", nil
+	}
+
+	for _, svc := range resp.GetMessages()[0].GetServices() {
+		if svc.GetId() != "kubelet" {
+			continue
+		}
+
+		svcState := svc.GetState()
+		h := svc.GetHealth()
+		healthDesc := "unknown"
+		msg := ""
+		if h != nil {
+			switch {
+			case h.GetHealthy():
+				healthDesc = "healthy"
+			case h.GetUnknown():
+				healthDesc = "unknown"
+			default:
+				healthDesc = "unhealthy"
+			}
+			msg = h.GetLastMessage()
+		}
+
+		// Same rule as ServicesCheck: Running AND (healthy OR unknown).
+		c.healthy = svcState == "Running" && h != nil && (h.GetHealthy() || h.GetUnknown())
+		c.detail = fmt.Sprintf("state=%s, health=%s", svcState, healthDesc)
+		if !c.healthy {
+			c.detail += fmt.Sprintf(", message=%q", msg)
+		}
+		return c, "", nil
+	}
+
+	c.detail = "service not found"
+	return c, "", nil
+}
+
+// staticPods evaluates the control plane static pods Talos has rendered for
+// this node. It returns a non-empty unknown message when a resource has an
+// unexpected type or the node name is missing.
+func (ch *KubernetesCheck) staticPods(ctx context.Context, client TalosClient) ([]kubeComponent, string, error) {
+	var specs []*k8s.StaticPod
+	for _, id := range controlPlaneStaticPods {
+		res, err := client.GetResource(ctx, resource.NewMetadata(
+			k8s.NamespaceName, k8s.StaticPodType, id, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+			return nil, "", err
+		}
+		pod, ok := res.(*k8s.StaticPod)
+		if !ok {
+			return nil, fmt.Sprintf("Unexpected resource type %s for static pod %s", res.Metadata().Type(), id), nil
+		}
+		specs = append(specs, pod)
+	}
+	if len(specs) == 0 {
+		return nil, "", nil
+	}
+
+	// The kubelet names a mirror pod "<name>-<nodename>".
+	res, err := client.GetResource(ctx, resource.NewMetadata(
+		k8s.NamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, "No Kubernetes node name (kubelet not configured?)", nil
+		}
+		return nil, "", err
+	}
+	nodename, ok := res.(*k8s.Nodename)
+	if !ok {
+		return nil, fmt.Sprintf("Unexpected resource type %s for node name", res.Metadata().Type()), nil
+	}
+
+	pods := make([]kubeComponent, 0, len(specs))
+	for _, spec := range specs {
+		name := spec.Metadata().ID()
+		namespace := "kube-system"
+		if meta, ok := spec.TypedSpec().Pod["metadata"].(map[string]any); ok {
+			if v, ok := meta["name"].(string); ok && v != "" {
+				name = v
+			}
+			if v, ok := meta["namespace"].(string); ok && v != "" {
+				namespace = v
+			}
+		}
+
+		id := fmt.Sprintf("%s/%s-%s", namespace, name, nodename.TypedSpec().Nodename)
+		res, err := client.GetResource(ctx, resource.NewMetadata(
+			k8s.NamespaceName, k8s.StaticPodStatusType, id, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return nil, "", err
+			}
+			pods = append(pods, kubeComponent{name: spec.Metadata().ID(), detail: "no pod status (not started?)"})
+			continue
+		}
+		status, ok := res.(*k8s.StaticPodStatus)
+		if !ok {
+			return nil, fmt.Sprintf("Unexpected resource type %s for static pod status %s", res.Metadata().Type(), id), nil
+		}
+
+		pods = append(pods, evaluatePodStatus(spec.Metadata().ID(), status.TypedSpec().PodStatus))
+	}
+
+	return pods, "", nil
+}
+
+// evaluatePodStatus evaluates a Kubernetes PodStatus decoded into a generic
+// map: the pod is healthy in phase Running with the Ready condition True.
+func evaluatePodStatus(name string, status map[string]any) kubeComponent {
+	phase, _ := status["phase"].(string)
+	if phase == "" {
+		phase = "Unknown"
+	}
+
+	ready := "Unknown"
+	conditions, _ := status["conditions"].([]any)
+	for _, c := range conditions {
+		cond, _ := c.(map[string]any)
+		if t, _ := cond["type"].(string); t == "Ready" {
+			if s, ok := cond["status"].(string); ok {
+				ready = s
+			}
+		}
+	}
+
+	var restarts float64
+	var reasons []string
+	containers, _ := status["containerStatuses"].([]any)
+	for _, c := range containers {
+		cs, _ := c.(map[string]any)
+		restarts += podNumber(cs["restartCount"])
+		// A crash-looping container waits with reason CrashLoopBackOff.
+		st, _ := cs["state"].(map[string]any)
+		if waiting, ok := st["waiting"].(map[string]any); ok {
+			if r, _ := waiting["reason"].(string); r != "" {
+				reasons = append(reasons, r)
+			}
+		}
+	}
+
+	detail := fmt.Sprintf("phase=%s, ready=%s, restarts=%.0f", phase, ready, restarts)
+	if len(reasons) > 0 {
+		detail += ", waiting=" + strings.Join(reasons, ",")
+	}
+
+	return kubeComponent{
+		name:     name,
+		healthy:  phase == "Running" && ready == "True",
+		detail:   detail,
+		restarts: restarts,
+	}
+}
+
+// podNumber returns a numeric PodStatus field, which decodes as float64 from
+// the protobuf Struct encoding and as an integer from YAML.
+func podNumber(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	default:
+		return 0
+	}
+}
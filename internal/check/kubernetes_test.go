@@ -0,0 +1,296 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/resources/k8s"
+)
+
+// mockKubernetesClient implements TalosClient for Kubernetes check testing.
+type mockKubernetesClient struct {
+	stubClient
+
+	services    *machine.ServiceListResponse
+	servicesErr error
+	resources   map[string]resource.Resource // keyed by "<type>/<id>"
+	err         error
+}
+
+func (m *mockKubernetesClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
+	return m.services, m.servicesErr
+}
+
+func (m *mockKubernetesClient) GetResource(_ context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	r, ok := m.resources[ptr.Type()+"/"+ptr.ID()]
+	if !ok {
+		return nil, inmem.ErrNotFound(ptr)
+	}
+	return r, nil
+}
+
+// kubeletService returns a ServiceList response with a kubelet service in
+// the given state.
+func kubeletService(state string, healthy bool, message string) *machine.ServiceListResponse {
+	return &machine.ServiceListResponse{
+		Messages: []*machine.ServiceList{{
+			Services: []*machine.ServiceInfo{
+				{Id: "apid", State: "Running", Health: &machine.ServiceHealth{Healthy: true}},
+				{Id: "kubelet", State: state, Health: &machine.ServiceHealth{Healthy: healthy, LastMessage: message}},
+			},
+		}},
+	}
+}
+
+// controlPlaneStaticPod returns the StaticPod resource Talos renders for a
+// control plane component.
+func controlPlaneStaticPod(id string) *k8s.StaticPod {
+	pod := k8s.NewStaticPod(k8s.NamespaceName, id)
+	pod.TypedSpec().Pod = map[string]any{
+		"metadata": map[string]any{"name": id, "namespace": "kube-system"},
+	}
+	return pod
+}
+
+// staticPodStatus returns the mirror pod status of a control plane component
+// on node "cp-1".
+func staticPodStatus(id, phase, ready string, restarts float64, waiting string) *k8s.StaticPodStatus {
+	container := map[string]any{"name": id, "restartCount": restarts}
+	if waiting != "" {
+		container["state"] = map[string]any{"waiting": map[string]any{"reason": waiting}}
+	}
+	status := k8s.NewStaticPodStatus(k8s.NamespaceName, "kube-system/"+id+"-cp-1")
+	status.TypedSpec().PodStatus = map[string]any{
+		"phase": phase,
+		"conditions": []any{
+			map[string]any{"type": "Initialized", "status": "True"},
+			map[string]any{"type": "Ready", "status": ready},
+		},
+		"containerStatuses": []any{container},
+	}
+	return status
+}
+
+// kubernetesResources keys resources by "<type>/<id>" for mockKubernetesClient.
+func kubernetesResources(rs ...resource.Resource) map[string]resource.Resource {
+	m := make(map[string]resource.Resource, len(rs))
+	for _, r := range rs {
+		m[r.Metadata().Type()+"/"+r.Metadata().ID()] = r
+	}
+	return m
+}
+
+// controlPlaneResources returns the static pods, their statuses and the
+// node name of a control plane node. statuses replace the healthy status of
+// the component with the same ID prefix.
+func controlPlaneResources(statuses ...*k8s.StaticPodStatus) []resource.Resource {
+	nodename := k8s.NewNodename(k8s.NamespaceName, k8s.NodenameID)
+	nodename.TypedSpec().Nodename = "cp-1"
+
+	rs := []resource.Resource{nodename}
+	for _, id := range controlPlaneStaticPods {
+		rs = append(rs, controlPlaneStaticPod(id), staticPodStatus(id, "Running", "True", 0, ""))
+	}
+	for _, s := range statuses {
+		rs = append(rs, s)
+	}
+	return rs
+}
+
+func TestKubernetesCheckRun(t *testing.T) {
+	healthyKubelet := kubeletService("Running", true, "")
+
+	tests := []struct {
+		name        string
+		services    *machine.ServiceListResponse
+		resources   []resource.Resource
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - control plane node",
+			services:    healthyKubelet,
+			resources:   controlPlaneResources(),
+			wantStatus:  output.OK,
+			wantSummary: "kubelet healthy, 3/3 control plane pods ready",
+			wantDetails: "kubelet: state=Running, health=healthy, status=OK\n" +
+				"kube-apiserver: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-controller-manager: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-scheduler: phase=Running, ready=True, restarts=0, status=OK",
+		},
+		{
+			name:        "OK - worker node",
+			services:    healthyKubelet,
+			wantStatus:  output.OK,
+			wantSummary: "kubelet healthy (no control plane static pods)",
+			wantDetails: "kubelet: state=Running, health=healthy, status=OK",
+		},
+		{
+			name:     "CRITICAL - crash-looping API server",
+			services: healthyKubelet,
+			resources: controlPlaneResources(
+				staticPodStatus(k8s.APIServerID, "Running", "False", 14, "CrashLoopBackOff"),
+			),
+			wantStatus:  output.Critical,
+			wantSummary: "1/4 Kubernetes components unhealthy: kube-apiserver",
+			wantDetails: "kubelet: state=Running, health=healthy, status=OK\n" +
+				"kube-apiserver: phase=Running, ready=False, restarts=14, waiting=CrashLoopBackOff, status=CRITICAL\n" +
+				"kube-controller-manager: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-scheduler: phase=Running, ready=True, restarts=0, status=OK",
+		},
+		{
+			name:     "CRITICAL - pending scheduler",
+			services: healthyKubelet,
+			resources: controlPlaneResources(
+				staticPodStatus(k8s.SchedulerID, "Pending", "False", 0, "ContainerCreating"),
+			),
+			wantStatus:  output.Critical,
+			wantSummary: "1/4 Kubernetes components unhealthy: kube-scheduler",
+			wantDetails: "kubelet: state=Running, health=healthy, status=OK\n" +
+				"kube-apiserver: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-controller-manager: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-scheduler: phase=Pending, ready=False, restarts=0, waiting=ContainerCreating, status=CRITICAL",
+		},
+		{
+			name:     "CRITICAL - static pod without status",
+			services: healthyKubelet,
+			resources: func() []resource.Resource {
+				nodename := k8s.NewNodename(k8s.NamespaceName, k8s.NodenameID)
+				nodename.TypedSpec().Nodename = "cp-1"
+				return []resource.Resource{nodename, controlPlaneStaticPod(k8s.APIServerID)}
+			}(),
+			wantStatus:  output.Critical,
+			wantSummary: "1/2 Kubernetes components unhealthy: kube-apiserver",
+			wantDetails: "kubelet: state=Running, health=healthy, status=OK\n" +
+				"kube-apiserver: no pod status (not started?), status=CRITICAL",
+		},
+		{
+			name:        "CRITICAL - kubelet not running",
+			services:    kubeletService("Finished", false, "exit status 1"),
+			resources:   controlPlaneResources(),
+			wantStatus:  output.Critical,
+			wantSummary: "1/4 Kubernetes components unhealthy: kubelet",
+			wantDetails: "kubelet: state=Finished, health=unhealthy, message=\"exit status 1\", status=CRITICAL\n" +
+				"kube-apiserver: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-controller-manager: phase=Running, ready=True, restarts=0, status=OK\n" +
+				"kube-scheduler: phase=Running, ready=True, restarts=0, status=OK",
+		},
+		{
+			name: "CRITICAL - kubelet service missing",
+			services: &machine.ServiceListResponse{Messages: []*machine.ServiceList{{
+				Services: []*machine.ServiceInfo{{Id: "apid", State: "Running"}},
+			}}},
+			wantStatus:  output.Critical,
+			wantSummary: "1/1 Kubernetes components unhealthy: kubelet",
+			wantDetails: "kubelet: service not found, status=CRITICAL",
+		},
+		{
+			name:        "UNKNOWN - empty service list",
+			services:    &machine.ServiceListResponse{},
+			wantStatus:  output.Unknown,
+			wantSummary: "Empty response from Talos API",
+		},
+		{
+			name:        "UNKNOWN - static pods without node name",
+			services:    healthyKubelet,
+			resources:   []resource.Resource{controlPlaneStaticPod(k8s.APIServerID)},
+			wantStatus:  output.Unknown,
+			wantSummary: "No Kubernetes node name (kubelet not configured?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewKubernetesCheck()
+			if err != nil {
+				t.Fatalf("NewKubernetesCheck: %v", err)
+			}
+
+			client := &mockKubernetesClient{services: tt.services, resources: kubernetesResources(tt.resources...)}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "KUBERNETES" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "KUBERNETES")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestKubernetesCheckPerfData(t *testing.T) {
+	ch, err := NewKubernetesCheck()
+	if err != nil {
+		t.Fatalf("NewKubernetesCheck: %v", err)
+	}
+
+	client := &mockKubernetesClient{
+		services: kubeletService("Running", true, ""),
+		resources: kubernetesResources(controlPlaneResources(
+			staticPodStatus(k8s.ControllerManagerID, "Running", "True", 3, ""),
+		)...),
+	}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "kubernetes_components=4;;;0; kubernetes_unhealthy=0;;;0; " +
+		"kube_apiserver_restarts=0c;;;0; kube_controller_manager_restarts=3c;;;0; kube_scheduler_restarts=0c;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestKubernetesCheckAPIError(t *testing.T) {
+	ch, err := NewKubernetesCheck()
+	if err != nil {
+		t.Fatalf("NewKubernetesCheck: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		client *mockKubernetesClient
+	}{
+		{"ServiceList", &mockKubernetesClient{servicesErr: fmt.Errorf("connection refused")}},
+		{"GetResource", &mockKubernetesClient{services: kubeletService("Running", true, ""), err: fmt.Errorf("permission denied")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ch.Run(context.Background(), tt.client); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestEvaluatePodStatusIntegerRestarts(t *testing.T) {
+	// Pod statuses decoded from YAML carry integer counts.
+	c := evaluatePodStatus("kube-apiserver", map[string]any{
+		"phase":             "Running",
+		"conditions":        []any{map[string]any{"type": "Ready", "status": "True"}},
+		"containerStatuses": []any{map[string]any{"restartCount": 2}, map[string]any{"restartCount": int64(1)}},
+	})
+	if !c.healthy || c.restarts != 3 {
+		t.Errorf("got healthy=%v restarts=%v, want healthy=true restarts=3", c.healthy, c.restarts)
+	}
+}
@@ -24,7 +24,7 @@ func NewLoadCheck(w, c, period string) (*LoadCheck, error) {
 	ch := &LoadCheck{Period: period}
 
 	if w != "" {
-		wt, err := threshold.Parse(w)
+		wt, err := threshold.ParseUnit(w, threshold.UnitNone)
 		if err != nil {
 			return nil, fmt.Errorf("invalid warning threshold: %w", err)
 		}
@@ -32,7 +32,7 @@ func NewLoadCheck(w, c, period string) (*LoadCheck, error) {
 	}
 
 	if c != "" {
-		ct, err := threshold.Parse(c)
+		ct, err := threshold.ParseUnit(c, threshold.UnitNone)
 		if err != nil {
 			return nil, fmt.Errorf("invalid critical threshold: %w", err)
 		}
@@ -11,6 +11,8 @@ import (
 
 // mockLoadClient implements TalosClient for Load check testing.
 type mockLoadClient struct {
+	stubClient
+
 	loadResp *machine.LoadAvgResponse
 	loadErr  error
 	statResp *machine.SystemStatResponse
@@ -21,30 +23,6 @@ func (m *mockLoadClient) SystemStat(_ context.Context) (*machine.SystemStatRespo
 	return m.statResp, m.statErr
 }
 
-func (m *mockLoadClient) Memory(context.Context) (*machine.MemoryResponse, error) {
-	return nil, nil
-}
-
-func (m *mockLoadClient) Mounts(context.Context) (*machine.MountsResponse, error) {
-	return nil, nil
-}
-
-func (m *mockLoadClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockLoadClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
-	return nil, nil
-}
-
-func (m *mockLoadClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockLoadClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
-	return nil, nil
-}
-
 func (m *mockLoadClient) LoadAvg(_ context.Context) (*machine.LoadAvgResponse, error) {
 	return m.loadResp, m.loadErr
 }
@@ -93,6 +71,7 @@ func TestNewLoadCheck(t *testing.T) {
 		{name: "valid ranges", warn: "~:6", crit: "~:12", period: "1", wantErr: false, wantW: true, wantC: true},
 		{name: "invalid warning", warn: "abc", crit: "8", period: "5", wantErr: true},
 		{name: "invalid critical", warn: "4", crit: "xyz", period: "5", wantErr: true},
+		{name: "duration suffix rejected", warn: "4m", crit: "8", period: "5", wantErr: true},
 		{name: "only warning provided", warn: "4", crit: "", period: "5", wantErr: false, wantW: true, wantC: false},
 		{name: "only critical provided", warn: "", crit: "8", period: "5", wantErr: false, wantW: false, wantC: true},
 	}
@@ -0,0 +1,101 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// MachineCheck reports the node's own view of its health from the
+// MachineStatus COSI resource: the machine stage (booting, running,
+// upgrading, ...) and whether all readiness conditions for that stage are
+// met. A node is OK only when it is running and ready; anything else is
+// CRITICAL, with the unmet conditions listed in long text.
+type MachineCheck struct{}
+
+// NewMachineCheck creates a MachineCheck. It takes no options.
+func NewMachineCheck() (*MachineCheck, error) {
+	return &MachineCheck{}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *MachineCheck) Name() string { return "MACHINE" }
+
+// Run executes the machine status check against the Talos API.
+func (ch *MachineCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	res, err := client.GetResource(ctx, resource.NewMetadata(
+		runtime.NamespaceName, runtime.MachineStatusType, runtime.MachineStatusID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   "No machine status resource",
+			}, nil
+		}
+		return nil, err
+	}
+
+	ms, ok := res.(*runtime.MachineStatus)
+	if !ok {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Unexpected resource type %s for machine status", res.Metadata().Type()),
+		}, nil
+	}
+
+	spec := ms.TypedSpec()
+	stage := spec.Stage.String()
+	unmet := spec.Status.UnmetConditions
+
+	ready := 0.0
+	if spec.Status.Ready {
+		ready = 1
+	}
+	perfData := []output.PerfDatum{
+		{Label: "machine_ready", Value: ready, Min: "0", Max: "1"},
+		{Label: "machine_unmet_conditions", Value: float64(len(unmet)), Min: "0"},
+	}
+
+	if spec.Stage == runtime.MachineStageRunning && spec.Status.Ready {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   "Machine running and ready",
+			PerfData:  perfData,
+		}, nil
+	}
+
+	names := make([]string, len(unmet))
+	details := make([]string, len(unmet))
+	for i, c := range unmet {
+		names[i] = c.Name
+		details[i] = fmt.Sprintf("%s: %s", c.Name, c.Reason)
+	}
+
+	readiness := "ready"
+	if !spec.Status.Ready {
+		readiness = "not ready"
+	}
+	summary := fmt.Sprintf("Machine %s, %s", stage, readiness)
+	if spec.Stage == runtime.MachineStageRunning {
+		summary = "Machine running but not ready"
+	}
+	if len(unmet) > 0 {
+		summary += fmt.Sprintf(": %d unmet conditions (%s)", len(unmet), strings.Join(names, ", "))
+	}
+
+	return &output.Result{
+		Status:    output.Critical,
+		CheckName: ch.Name(),
+		Summary:   summary,
+		Details:   strings.Join(details, "\n"),
+		PerfData:  perfData,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// mockMachineClient implements TalosClient for Machine check testing.
+type mockMachineClient struct {
+	stubClient
+
+	status resource.Resource // nil = not found
+	err    error
+	ptr    resource.Pointer // last requested resource
+}
+
+func (m *mockMachineClient) GetResource(_ context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	m.ptr = ptr
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.status == nil {
+		return nil, inmem.ErrNotFound(ptr)
+	}
+	return m.status, nil
+}
+
+// machineStatus returns a MachineStatus resource with the given stage,
+// readiness and unmet conditions.
+func machineStatus(stage runtime.MachineStage, ready bool, unmet ...runtime.UnmetCondition) *runtime.MachineStatus {
+	ms := runtime.NewMachineStatus()
+	ms.TypedSpec().Stage = stage
+	ms.TypedSpec().Status.Ready = ready
+	ms.TypedSpec().Status.UnmetConditions = unmet
+	return ms
+}
+
+func TestMachineCheckRun(t *testing.T) {
+	timeSync := runtime.UnmetCondition{Name: "time", Reason: "time is not in sync"}
+	services := runtime.UnmetCondition{Name: "services", Reason: "some services are not healthy: kubelet"}
+
+	tests := []struct {
+		name        string
+		status      resource.Resource
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - running and ready",
+			status:      machineStatus(runtime.MachineStageRunning, true),
+			wantStatus:  output.OK,
+			wantSummary: "Machine running and ready",
+		},
+		{
+			name:        "CRITICAL - running but not ready",
+			status:      machineStatus(runtime.MachineStageRunning, false, timeSync, services),
+			wantStatus:  output.Critical,
+			wantSummary: "Machine running but not ready: 2 unmet conditions (time, services)",
+			wantDetails: "time: time is not in sync\nservices: some services are not healthy: kubelet",
+		},
+		{
+			name:        "CRITICAL - booting",
+			status:      machineStatus(runtime.MachineStageBooting, false, services),
+			wantStatus:  output.Critical,
+			wantSummary: "Machine booting, not ready: 1 unmet conditions (services)",
+			wantDetails: "services: some services are not healthy: kubelet",
+		},
+		{
+			name:        "CRITICAL - upgrading while ready",
+			status:      machineStatus(runtime.MachineStageUpgrading, true),
+			wantStatus:  output.Critical,
+			wantSummary: "Machine upgrading, ready",
+		},
+		{
+			name:        "CRITICAL - maintenance without conditions",
+			status:      machineStatus(runtime.MachineStageMaintenance, false),
+			wantStatus:  output.Critical,
+			wantSummary: "Machine maintenance, not ready",
+		},
+		{
+			name:        "UNKNOWN - no machine status",
+			wantStatus:  output.Unknown,
+			wantSummary: "No machine status resource",
+		},
+		{
+			name:        "UNKNOWN - unexpected resource type",
+			status:      runtime.NewMetaKey(runtime.NamespaceName, "0x0a"),
+			wantStatus:  output.Unknown,
+			wantSummary: "Unexpected resource type MetaKeys.runtime.talos.dev for machine status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewMachineCheck()
+			if err != nil {
+				t.Fatalf("NewMachineCheck: %v", err)
+			}
+
+			client := &mockMachineClient{status: tt.status}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if client.ptr.Type() != runtime.MachineStatusType || client.ptr.ID() != runtime.MachineStatusID {
+				t.Errorf("requested %s/%s, want %s/%s", client.ptr.Type(), client.ptr.ID(),
+					runtime.MachineStatusType, runtime.MachineStatusID)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "MACHINE" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "MACHINE")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary:\n  got:  %q\n  want: %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestMachineCheckPerfData(t *testing.T) {
+	ch, err := NewMachineCheck()
+	if err != nil {
+		t.Fatalf("NewMachineCheck: %v", err)
+	}
+
+	client := &mockMachineClient{status: machineStatus(runtime.MachineStageRunning, false,
+		runtime.UnmetCondition{Name: "time", Reason: "time is not in sync"})}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "machine_ready=0;;;0;1 machine_unmet_conditions=1;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestMachineCheckAPIError(t *testing.T) {
+	ch, err := NewMachineCheck()
+	if err != nil {
+		t.Fatalf("NewMachineCheck: %v", err)
+	}
+
+	if _, err := ch.Run(context.Background(), &mockMachineClient{err: fmt.Errorf("permission denied")}); err == nil {
+		t.Error("expected error from GetResource, got nil")
+	}
+}
@@ -5,28 +5,80 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/DLAKE-IO/check-talos/internal/output"
 	"github.com/DLAKE-IO/check-talos/internal/threshold"
 )
 
 // MemoryCheck monitors memory utilization via the Talos Memory API.
+//
+// Warning and Critical apply to the usage percentage derived from
+// memavailable. The remaining thresholds are optional (nil = not evaluated)
+// and cover pressure that usage alone hides: swap usage and hugepage usage
+// in percent, committed_as as a percentage of commit_limit, and dirty plus
+// writeback bytes. Swap and hugepage thresholds are skipped on nodes where
+// the respective total is zero.
 type MemoryCheck struct {
-	Warning  threshold.Threshold
-	Critical threshold.Threshold
+	Warning           threshold.Threshold
+	Critical          threshold.Threshold
+	SwapWarning       *threshold.Threshold // swap usage %
+	SwapCritical      *threshold.Threshold // swap usage %
+	CommitWarning     *threshold.Threshold // committed_as % of commit_limit
+	CommitCritical    *threshold.Threshold // committed_as % of commit_limit
+	DirtyWarning      *threshold.Threshold // dirty + writeback bytes
+	DirtyCritical     *threshold.Threshold // dirty + writeback bytes
+	HugepagesWarning  *threshold.Threshold // hugepage usage %
+	HugepagesCritical *threshold.Threshold // hugepage usage %
 }
 
-// NewMemoryCheck creates a MemoryCheck from warning and critical threshold strings.
-func NewMemoryCheck(w, c string) (*MemoryCheck, error) {
-	wt, err := threshold.Parse(w)
+// MemoryOptions holds the optional memory threshold strings. An empty
+// string leaves the metric unevaluated. Dirty thresholds accept size
+// suffixes; the others are percentages.
+type MemoryOptions struct {
+	SwapWarning       string
+	SwapCritical      string
+	CommitWarning     string
+	CommitCritical    string
+	DirtyWarning      string
+	DirtyCritical     string
+	HugepagesWarning  string
+	HugepagesCritical string
+}
+
+// NewMemoryCheck creates a MemoryCheck from warning and critical threshold
+// strings for memory usage and the optional thresholds in opts.
+func NewMemoryCheck(w, c string, opts MemoryOptions) (*MemoryCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
 	if err != nil {
 		return nil, fmt.Errorf("invalid warning threshold: %w", err)
 	}
-	ct, err := threshold.Parse(c)
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
 	if err != nil {
 		return nil, fmt.Errorf("invalid critical threshold: %w", err)
 	}
-	return &MemoryCheck{Warning: wt, Critical: ct}, nil
+
+	ch := &MemoryCheck{Warning: wt, Critical: ct}
+
+	if err := parseOptionalThresholds(threshold.UnitNone, []optionalThreshold{
+		{"swap warning", opts.SwapWarning, &ch.SwapWarning},
+		{"swap critical", opts.SwapCritical, &ch.SwapCritical},
+		{"commit warning", opts.CommitWarning, &ch.CommitWarning},
+		{"commit critical", opts.CommitCritical, &ch.CommitCritical},
+		{"hugepages warning", opts.HugepagesWarning, &ch.HugepagesWarning},
+		{"hugepages critical", opts.HugepagesCritical, &ch.HugepagesCritical},
+	}); err != nil {
+		return nil, err
+	}
+	// Dirty memory is an amount, so it takes size suffixes.
+	if err := parseOptionalThresholds(threshold.UnitBytes, []optionalThreshold{
+		{"dirty warning", opts.DirtyWarning, &ch.DirtyWarning},
+		{"dirty critical", opts.DirtyCritical, &ch.DirtyCritical},
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
 }
 
 // Name returns the check identifier used in Nagios output.
@@ -87,39 +139,152 @@ func (ch *MemoryCheck) Run(ctx context.Context, client TalosClient) (*output.Res
 
 	memTotalStr := strconv.FormatUint(memTotal, 10)
 
+	perfData := []output.PerfDatum{
+		{
+			Label: "memory_usage",
+			Value: usagePct,
+			UOM:   "",
+			Warn:  ch.Warning.String(),
+			Crit:  ch.Critical.String(),
+			Min:   "0",
+			Max:   "100",
+		},
+		{
+			Label: "memory_used",
+			Value: float64(usedBytes),
+			UOM:   "B",
+			Warn:  "",
+			Crit:  "",
+			Min:   "0",
+			Max:   memTotalStr,
+		},
+		{
+			Label: "memory_total",
+			Value: float64(memTotal),
+			UOM:   "B",
+			Warn:  "",
+			Crit:  "",
+			Min:   "0",
+			Max:   "",
+		},
+	}
+
+	// Breakdown of where the memory went, in bytes.
+	anon := meminfo.GetAnonpages() * 1024
+	cached := meminfo.GetCached() * 1024
+	buffers := meminfo.GetBuffers() * 1024
+	slab := meminfo.GetSlab() * 1024
+	shmem := meminfo.GetShmem() * 1024
+	for _, b := range []struct {
+		label string
+		value uint64
+	}{
+		{"memory_anon", anon},
+		{"memory_cached", cached},
+		{"memory_buffers", buffers},
+		{"memory_slab", slab},
+		{"memory_shmem", shmem},
+	} {
+		perfData = append(perfData, output.PerfDatum{
+			Label: b.label, Value: float64(b.value), UOM: "B", Min: "0", Max: memTotalStr,
+		})
+	}
+
+	var notes []string
+
+	// Dirty and writeback pages have not reached disk yet.
+	dirty := (meminfo.GetDirty() + meminfo.GetWriteback()) * 1024
+	dirtyStatus := evaluateCounter(float64(dirty), ch.DirtyWarning, ch.DirtyCritical)
+	status = max(status, dirtyStatus)
+	if dirtyStatus != output.OK {
+		notes = append(notes, "dirty+writeback "+output.HumanBytes(dirty))
+	}
+	perfData = append(perfData, output.PerfDatum{
+		Label: "memory_dirty", Value: float64(dirty), UOM: "B",
+		Warn: optionalString(ch.DirtyWarning), Crit: optionalString(ch.DirtyCritical),
+		Min: "0", Max: memTotalStr,
+	})
+
+	if commitLimit := meminfo.GetCommitlimit(); commitLimit > 0 {
+		commitPct := roundPct(float64(meminfo.GetCommittedas()) / float64(commitLimit) * 100)
+		commitStatus := evaluateCounter(commitPct, ch.CommitWarning, ch.CommitCritical)
+		status = max(status, commitStatus)
+		if commitStatus != output.OK {
+			notes = append(notes, fmt.Sprintf("committed %.1f%% of commit limit (%s / %s)",
+				commitPct, output.HumanBytes(meminfo.GetCommittedas()*1024), output.HumanBytes(commitLimit*1024)))
+		}
+		perfData = append(perfData, output.PerfDatum{
+			Label: "commit_usage", Value: commitPct,
+			Warn: optionalString(ch.CommitWarning), Crit: optionalString(ch.CommitCritical),
+			Min: "0",
+		})
+	}
+
+	if swapTotal := meminfo.GetSwaptotal() * 1024; swapTotal > 0 {
+		swapUsed := swapTotal - min(meminfo.GetSwapfree()*1024, swapTotal)
+		swapPct := roundPct(float64(swapUsed) / float64(swapTotal) * 100)
+		swapStatus := evaluateCounter(swapPct, ch.SwapWarning, ch.SwapCritical)
+		status = max(status, swapStatus)
+		if swapStatus != output.OK {
+			notes = append(notes, fmt.Sprintf("swap %.1f%% (%s / %s)",
+				swapPct, output.HumanBytes(swapUsed), output.HumanBytes(swapTotal)))
+		}
+		swapTotalStr := strconv.FormatUint(swapTotal, 10)
+		perfData = append(perfData,
+			output.PerfDatum{
+				Label: "swap_usage", Value: swapPct,
+				Warn: optionalString(ch.SwapWarning), Crit: optionalString(ch.SwapCritical),
+				Min: "0", Max: "100",
+			},
+			output.PerfDatum{Label: "swap_used", Value: float64(swapUsed), UOM: "B", Min: "0", Max: swapTotalStr},
+			output.PerfDatum{Label: "swap_total", Value: float64(swapTotal), UOM: "B", Min: "0"},
+		)
+	}
+
+	if hpTotal := meminfo.GetHugepagestotal(); hpTotal > 0 {
+		hpUsed := hpTotal - min(meminfo.GetHugepagesfree(), hpTotal)
+		hpPct := roundPct(float64(hpUsed) / float64(hpTotal) * 100)
+		hpStatus := evaluateCounter(hpPct, ch.HugepagesWarning, ch.HugepagesCritical)
+		status = max(status, hpStatus)
+		if hpStatus != output.OK {
+			notes = append(notes, fmt.Sprintf("hugepages %.1f%% used (%d/%d)", hpPct, hpUsed, hpTotal))
+		}
+		perfData = append(perfData, output.PerfDatum{
+			Label: "hugepages_usage", Value: hpPct,
+			Warn: optionalString(ch.HugepagesWarning), Crit: optionalString(ch.HugepagesCritical),
+			Min: "0", Max: "100",
+		})
+	}
+
+	summary := fmt.Sprintf("Memory usage %.1f%% (%s / %s)",
+		usagePct, output.HumanBytes(usedBytes), output.HumanBytes(memTotal))
+	if len(notes) > 0 {
+		summary += ", " + strings.Join(notes, ", ")
+	}
+
+	// On alert, show whether the memory is anonymous or reclaimable cache.
+	var details string
+	if status != output.OK {
+		details = fmt.Sprintf("anon %s, cached %s, buffers %s, slab %s (reclaimable %s), shmem %s",
+			output.HumanBytes(anon), output.HumanBytes(cached), output.HumanBytes(buffers),
+			output.HumanBytes(slab), output.HumanBytes(meminfo.GetSreclaimable()*1024),
+			output.HumanBytes(shmem))
+	}
+
 	return &output.Result{
 		Status:    status,
 		CheckName: ch.Name(),
-		Summary: fmt.Sprintf("Memory usage %.1f%% (%s / %s)",
-			usagePct, output.HumanBytes(usedBytes), output.HumanBytes(memTotal)),
-		PerfData: []output.PerfDatum{
-			{
-				Label: "memory_usage",
-				Value: usagePct,
-				UOM:   "",
-				Warn:  ch.Warning.String(),
-				Crit:  ch.Critical.String(),
-				Min:   "0",
-				Max:   "100",
-			},
-			{
-				Label: "memory_used",
-				Value: float64(usedBytes),
-				UOM:   "B",
-				Warn:  "",
-				Crit:  "",
-				Min:   "0",
-				Max:   memTotalStr,
-			},
-			{
-				Label: "memory_total",
-				Value: float64(memTotal),
-				UOM:   "B",
-				Warn:  "",
-				Crit:  "",
-				Min:   "0",
-				Max:   "",
-			},
-		},
+		Summary:   summary,
+		Details:   details,
+		PerfData:  perfData,
 	}, nil
 }
+
+// optionalString returns the perfdata form of an optional threshold, or ""
+// when it is not set.
+func optionalString(t *threshold.Threshold) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
@@ -11,42 +11,16 @@ import (
 
 // mockMemoryClient implements TalosClient for Memory check testing.
 type mockMemoryClient struct {
+	stubClient
+
 	resp *machine.MemoryResponse
 	err  error
 }
 
-func (m *mockMemoryClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
-	return nil, nil
-}
-
 func (m *mockMemoryClient) Memory(_ context.Context) (*machine.MemoryResponse, error) {
 	return m.resp, m.err
 }
 
-func (m *mockMemoryClient) Mounts(context.Context) (*machine.MountsResponse, error) {
-	return nil, nil
-}
-
-func (m *mockMemoryClient) ServiceList(context.Context) (*machine.ServiceListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockMemoryClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
-	return nil, nil
-}
-
-func (m *mockMemoryClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockMemoryClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockMemoryClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
-	return nil, nil
-}
-
 func TestNewMemoryCheck(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,11 +32,12 @@ func TestNewMemoryCheck(t *testing.T) {
 		{name: "valid ranges", warn: "~:75", crit: "~:95", wantErr: false},
 		{name: "invalid warning", warn: "abc", crit: "90", wantErr: true},
 		{name: "invalid critical", warn: "80", crit: "xyz", wantErr: true},
+		{name: "size suffix rejected", warn: "80", crit: "90GB", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewMemoryCheck(tt.warn, tt.crit)
+			ch, err := NewMemoryCheck(tt.warn, tt.crit, MemoryOptions{})
 			if tt.wantErr {
 				if err == nil {
 					t.Fatal("expected error, got nil")
@@ -207,7 +182,7 @@ func TestMemoryCheckRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewMemoryCheck(tt.warn, tt.crit)
+			ch, err := NewMemoryCheck(tt.warn, tt.crit, MemoryOptions{})
 			if err != nil {
 				t.Fatalf("NewMemoryCheck: %v", err)
 			}
@@ -244,7 +219,7 @@ func TestMemoryCheckRun(t *testing.T) {
 }
 
 func TestMemoryCheckPerfData(t *testing.T) {
-	ch, err := NewMemoryCheck("80", "90")
+	ch, err := NewMemoryCheck("80", "90", MemoryOptions{})
 	if err != nil {
 		t.Fatalf("NewMemoryCheck: %v", err)
 	}
@@ -259,8 +234,10 @@ func TestMemoryCheckPerfData(t *testing.T) {
 		t.Fatalf("Run: %v", err)
 	}
 
-	if len(result.PerfData) != 3 {
-		t.Fatalf("PerfData length = %d, want 3", len(result.PerfData))
+	// usage, used, total, five breakdown entries and dirty; no swap,
+	// commit or hugepage entries without the corresponding totals.
+	if len(result.PerfData) != 9 {
+		t.Fatalf("PerfData length = %d, want 9", len(result.PerfData))
 	}
 
 	// memory_usage perfdata
@@ -341,7 +318,7 @@ func TestMemoryCheckOutputFormat(t *testing.T) {
 				// After conversion: used = (8388608-3165928)*1024 = 5348024320 B
 				resp: makeMemoryResponse(8388608, 3165928),
 			},
-			want: "TALOS MEMORY OK - Memory usage 62.3% (4.98 GB / 8.00 GB) | memory_usage=62.3;80;90;0;100 memory_used=5348024320B;;;0;8589934592 memory_total=8589934592B;;;0;",
+			want: "TALOS MEMORY OK - Memory usage 62.3% (4.98 GB / 8.00 GB) | memory_usage=62.3;80;90;0;100 memory_used=5348024320B;;;0;8589934592 memory_total=8589934592B;;;0; memory_anon=0B;;;0;8589934592 memory_cached=0B;;;0;8589934592 memory_buffers=0B;;;0;8589934592 memory_slab=0B;;;0;8589934592 memory_shmem=0B;;;0;8589934592 memory_dirty=0B;;;0;8589934592",
 		},
 		{
 			name: "WARNING output matches DESIGN.md format",
@@ -351,7 +328,7 @@ func TestMemoryCheckOutputFormat(t *testing.T) {
 				// After conversion: used = (8388608-1363472)*1024 = 7193739264 B
 				resp: makeMemoryResponse(8388608, 1363472),
 			},
-			want: "TALOS MEMORY WARNING - Memory usage 83.7% (6.70 GB / 8.00 GB) | memory_usage=83.7;80;90;0;100 memory_used=7193739264B;;;0;8589934592 memory_total=8589934592B;;;0;",
+			want: "TALOS MEMORY WARNING - Memory usage 83.7% (6.70 GB / 8.00 GB) | memory_usage=83.7;80;90;0;100 memory_used=7193739264B;;;0;8589934592 memory_total=8589934592B;;;0; memory_anon=0B;;;0;8589934592 memory_cached=0B;;;0;8589934592 memory_buffers=0B;;;0;8589934592 memory_slab=0B;;;0;8589934592 memory_shmem=0B;;;0;8589934592 memory_dirty=0B;;;0;8589934592\nanon 0 B, cached 0 B, buffers 0 B, slab 0 B (reclaimable 0 B), shmem 0 B",
 		},
 		{
 			name: "CRITICAL output matches DESIGN.md format",
@@ -361,13 +338,13 @@ func TestMemoryCheckOutputFormat(t *testing.T) {
 				// After conversion: used = (8388608-494188)*1024 = 8083886080 B
 				resp: makeMemoryResponse(8388608, 494188),
 			},
-			want: "TALOS MEMORY CRITICAL - Memory usage 94.1% (7.53 GB / 8.00 GB) | memory_usage=94.1;80;90;0;100 memory_used=8083886080B;;;0;8589934592 memory_total=8589934592B;;;0;",
+			want: "TALOS MEMORY CRITICAL - Memory usage 94.1% (7.53 GB / 8.00 GB) | memory_usage=94.1;80;90;0;100 memory_used=8083886080B;;;0;8589934592 memory_total=8589934592B;;;0; memory_anon=0B;;;0;8589934592 memory_cached=0B;;;0;8589934592 memory_buffers=0B;;;0;8589934592 memory_slab=0B;;;0;8589934592 memory_shmem=0B;;;0;8589934592 memory_dirty=0B;;;0;8589934592\nanon 0 B, cached 0 B, buffers 0 B, slab 0 B (reclaimable 0 B), shmem 0 B",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch, err := NewMemoryCheck(tt.warn, tt.crit)
+			ch, err := NewMemoryCheck(tt.warn, tt.crit, MemoryOptions{})
 			if err != nil {
 				t.Fatalf("NewMemoryCheck: %v", err)
 			}
@@ -383,6 +360,192 @@ func TestMemoryCheckOutputFormat(t *testing.T) {
 	}
 }
 
+func TestNewMemoryCheckOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    MemoryOptions
+		wantErr bool
+	}{
+		{name: "no optional thresholds", opts: MemoryOptions{}, wantErr: false},
+		{name: "swap and commit", opts: MemoryOptions{SwapWarning: "50", SwapCritical: "80", CommitWarning: "100", CommitCritical: "150"}, wantErr: false},
+		{name: "dirty with size suffix", opts: MemoryOptions{DirtyWarning: "512MiB", DirtyCritical: "2GiB"}, wantErr: false},
+		{name: "hugepages", opts: MemoryOptions{HugepagesWarning: "90", HugepagesCritical: "98"}, wantErr: false},
+		{name: "invalid swap warning", opts: MemoryOptions{SwapWarning: "abc"}, wantErr: true},
+		{name: "size suffix on percentage", opts: MemoryOptions{CommitCritical: "1GB"}, wantErr: true},
+		{name: "duration suffix on dirty", opts: MemoryOptions{DirtyWarning: "10m"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewMemoryCheck("80", "90", tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (tt.opts.SwapWarning != "") != (ch.SwapWarning != nil) {
+				t.Errorf("SwapWarning = %v, want set=%v", ch.SwapWarning, tt.opts.SwapWarning != "")
+			}
+			if (tt.opts.DirtyCritical != "") != (ch.DirtyCritical != nil) {
+				t.Errorf("DirtyCritical = %v, want set=%v", ch.DirtyCritical, tt.opts.DirtyCritical != "")
+			}
+		})
+	}
+}
+
+func TestMemoryCheckOptionalThresholds(t *testing.T) {
+	// 8 GiB total, 4 GiB available (kB) → 50% usage, OK on its own.
+	base := func() *machine.MemInfo {
+		return &machine.MemInfo{
+			Memtotal:     8388608,
+			Memavailable: 4194304,
+			Anonpages:    3145728,
+			Cached:       1048576,
+			Buffers:      102400,
+			Slab:         204800,
+			Sreclaimable: 153600,
+			Shmem:        10240,
+			Commitlimit:  4194304,
+			Committedas:  2097152,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		opts        MemoryOptions
+		mutate      func(mi *machine.MemInfo)
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - no optional thresholds set",
+			opts:        MemoryOptions{},
+			mutate:      func(mi *machine.MemInfo) { mi.Swaptotal, mi.Swapfree = 1048576, 0 },
+			wantStatus:  output.OK,
+			wantSummary: "Memory usage 50.0% (4.00 GB / 8.00 GB)",
+		},
+		{
+			name:        "WARNING - swap usage",
+			opts:        MemoryOptions{SwapWarning: "50", SwapCritical: "90"},
+			mutate:      func(mi *machine.MemInfo) { mi.Swaptotal, mi.Swapfree = 2097152, 786432 },
+			wantStatus:  output.Warning,
+			wantSummary: "Memory usage 50.0% (4.00 GB / 8.00 GB), swap 62.5% (1.25 GB / 2.00 GB)",
+			wantDetails: "anon 3.00 GB, cached 1.00 GB, buffers 100.00 MB, slab 200.00 MB (reclaimable 150.00 MB), shmem 10.00 MB",
+		},
+		{
+			name:        "OK - swap thresholds ignored without swap",
+			opts:        MemoryOptions{SwapWarning: "50", SwapCritical: "90"},
+			mutate:      func(*machine.MemInfo) {},
+			wantStatus:  output.OK,
+			wantSummary: "Memory usage 50.0% (4.00 GB / 8.00 GB)",
+		},
+		{
+			name:        "CRITICAL - overcommitted",
+			opts:        MemoryOptions{CommitWarning: "100", CommitCritical: "150"},
+			mutate:      func(mi *machine.MemInfo) { mi.Committedas = 6710886 },
+			wantStatus:  output.Critical,
+			wantSummary: "committed 160.0% of commit limit (6.40 GB / 4.00 GB)",
+			wantDetails: "anon 3.00 GB, cached 1.00 GB, buffers 100.00 MB, slab 200.00 MB (reclaimable 150.00 MB), shmem 10.00 MB",
+		},
+		{
+			name:        "WARNING - dirty plus writeback",
+			opts:        MemoryOptions{DirtyWarning: "512MiB", DirtyCritical: "2GiB"},
+			mutate:      func(mi *machine.MemInfo) { mi.Dirty, mi.Writeback = 409600, 307200 },
+			wantStatus:  output.Warning,
+			wantSummary: "dirty+writeback 700.00 MB",
+			wantDetails: "anon 3.00 GB, cached 1.00 GB, buffers 100.00 MB, slab 200.00 MB (reclaimable 150.00 MB), shmem 10.00 MB",
+		},
+		{
+			name:        "CRITICAL - hugepages exhausted",
+			opts:        MemoryOptions{HugepagesWarning: "90", HugepagesCritical: "98"},
+			mutate:      func(mi *machine.MemInfo) { mi.Hugepagestotal, mi.Hugepagesfree = 512, 0 },
+			wantStatus:  output.Critical,
+			wantSummary: "hugepages 100.0% used (512/512)",
+			wantDetails: "anon 3.00 GB, cached 1.00 GB, buffers 100.00 MB, slab 200.00 MB (reclaimable 150.00 MB), shmem 10.00 MB",
+		},
+		{
+			name: "CRITICAL - worst of usage and optional thresholds",
+			opts: MemoryOptions{SwapWarning: "10", SwapCritical: "90"},
+			mutate: func(mi *machine.MemInfo) {
+				mi.Memavailable = 419430
+				mi.Swaptotal, mi.Swapfree = 1048576, 524288
+			},
+			wantStatus:  output.Critical,
+			wantSummary: "Memory usage 95.0% (7.60 GB / 8.00 GB), swap 50.0% (512.00 MB / 1.00 GB)",
+			wantDetails: "anon 3.00 GB, cached 1.00 GB, buffers 100.00 MB, slab 200.00 MB (reclaimable 150.00 MB), shmem 10.00 MB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewMemoryCheck("80", "90", tt.opts)
+			if err != nil {
+				t.Fatalf("NewMemoryCheck: %v", err)
+			}
+
+			mi := base()
+			tt.mutate(mi)
+			result, err := ch.Run(context.Background(), &mockMemoryClient{
+				resp: &machine.MemoryResponse{Messages: []*machine.Memory{{Meminfo: mi}}},
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if !contains(result.Summary, tt.wantSummary) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestMemoryCheckBreakdownPerfData(t *testing.T) {
+	ch, err := NewMemoryCheck("80", "90", MemoryOptions{
+		SwapWarning: "50", SwapCritical: "80",
+		CommitCritical:   "150",
+		DirtyWarning:     "1GiB",
+		HugepagesWarning: "90", HugepagesCritical: "98",
+	})
+	if err != nil {
+		t.Fatalf("NewMemoryCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockMemoryClient{
+		resp: &machine.MemoryResponse{Messages: []*machine.Memory{{Meminfo: &machine.MemInfo{
+			Memtotal: 1000, Memavailable: 600,
+			Anonpages: 200, Cached: 100, Buffers: 10, Slab: 50, Shmem: 5,
+			Dirty: 3, Writeback: 1,
+			Commitlimit: 800, Committedas: 400,
+			Swaptotal: 100, Swapfree: 75,
+			Hugepagestotal: 10, Hugepagesfree: 5,
+		}}}},
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "memory_usage=40;80;90;0;100 memory_used=409600B;;;0;1024000 memory_total=1024000B;;;0; " +
+		"memory_anon=204800B;;;0;1024000 memory_cached=102400B;;;0;1024000 memory_buffers=10240B;;;0;1024000 " +
+		"memory_slab=51200B;;;0;1024000 memory_shmem=5120B;;;0;1024000 " +
+		"memory_dirty=4096B;1073741824;;0;1024000 commit_usage=50;;150;0; " +
+		"swap_usage=25;50;80;0;100 swap_used=25600B;;;0;102400 swap_total=102400B;;;0; " +
+		"hugepages_usage=50;90;98;0;100"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
 // makeMemoryResponse builds a MemoryResponse with the given memtotal and
 // memavailable values in kB (matching the Talos API / /proc/meminfo units).
 func makeMemoryResponse(memTotal, memAvailable uint64) *machine.MemoryResponse {
@@ -0,0 +1,202 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+)
+
+// procMountsPath is the kernel mount table read through the Talos Read API.
+const procMountsPath = "/proc/mounts"
+
+// MountsCheck verifies that required mount points are present and mounted
+// read-write. Presence comes from the Talos Mounts API; MountStat carries no
+// mount options, so the options are read from /proc/mounts via the Read API
+// and joined on the mount point.
+//
+// A missing or read-only mount is CRITICAL. The kernel remounts a filesystem
+// read-only after I/O errors, which leaves capacity unchanged and is
+// therefore invisible to the disk check.
+type MountsCheck struct {
+	Required []string
+}
+
+// NewMountsCheck creates a MountsCheck for the given required mount points.
+func NewMountsCheck(required []string) (*MountsCheck, error) {
+	if len(required) == 0 {
+		return nil, fmt.Errorf("no required mount points")
+	}
+	for _, m := range required {
+		if !strings.HasPrefix(m, "/") {
+			return nil, fmt.Errorf("invalid mount point %q: must be an absolute path", m)
+		}
+	}
+	return &MountsCheck{Required: required}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *MountsCheck) Name() string { return "MOUNTS" }
+
+// Run executes the mounts check against the Talos API.
+func (ch *MountsCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	resp, err := client.Mounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	data, err := client.Read(ctx, procMountsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	table := parseProcMounts(data)
+	if len(table) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("No entries in %s", procMountsPath),
+		}, nil
+	}
+
+	present := make(map[string]struct{})
+	for _, ms := range resp.GetMessages()[0].GetStats() {
+		present[ms.GetMountedOn()] = struct{}{}
+	}
+
+	status := output.OK
+	var missing, readOnly int
+	var problems []string
+	var details strings.Builder
+
+	for _, mount := range ch.Required {
+		entry, inTable := table[mount]
+		_, inStats := present[mount]
+
+		var problem, detail string
+		mountStatus := output.OK
+		switch {
+		case !inTable && !inStats:
+			missing++
+			mountStatus = output.Critical
+			problem = mount + " not mounted"
+			detail = "not mounted"
+		case !inTable:
+			mountStatus = output.Unknown
+			problem = mount + " options unavailable"
+			detail = fmt.Sprintf("listed by Mounts but not in %s", procMountsPath)
+		case entry.readOnly():
+			readOnly++
+			mountStatus = output.Critical
+			problem = mount + " read-only"
+			detail = "read-only " + entry.describe()
+		}
+
+		if mountStatus == output.OK {
+			continue
+		}
+		status = max(status, mountStatus)
+		problems = append(problems, problem)
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: %s, status=%s", mount, detail, mountStatus)
+	}
+
+	total := len(ch.Required)
+	maxStr := strconv.Itoa(total)
+	perfData := []output.PerfDatum{
+		{Label: "mounts_required", Value: float64(total), Min: "0"},
+		{Label: "mounts_missing", Value: float64(missing), Min: "0", Max: maxStr},
+		{Label: "mounts_readonly", Value: float64(readOnly), Min: "0", Max: maxStr},
+	}
+
+	if len(problems) == 0 {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("%d/%d required mounts present and read-write", total, total),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d/%d required mounts failing: %s",
+			len(problems), total, strings.Join(problems, ", ")),
+		Details:  details.String(),
+		PerfData: perfData,
+	}, nil
+}
+
+// procMount is one line of /proc/mounts.
+type procMount struct {
+	source  string
+	fstype  string
+	options []string
+}
+
+// readOnly reports whether the mount options contain "ro".
+func (e procMount) readOnly() bool {
+	for _, o := range e.options {
+		if o == "ro" {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns "(<fstype> on <source>, options <opts>)".
+func (e procMount) describe() string {
+	return fmt.Sprintf("(%s on %s, options %s)", e.fstype, e.source, strings.Join(e.options, ","))
+}
+
+// parseProcMounts parses /proc/mounts content into entries keyed by mount
+// point. When a mount point is listed more than once, the last entry wins
+// because it is the one visible at that path. Malformed lines are skipped.
+func parseProcMounts(data []byte) map[string]procMount {
+	table := make(map[string]procMount)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		table[unescapeMountField(fields[1])] = procMount{
+			source:  unescapeMountField(fields[0]),
+			fstype:  fields[2],
+			options: strings.Split(fields[3], ","),
+		}
+	}
+	return table
+}
+
+// unescapeMountField decodes the octal escapes (\040 for space, \011 for
+// tab, \012 for newline, \134 for backslash) the kernel uses in /proc/mounts.
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,263 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockMountsClient implements TalosClient for Mounts check testing.
+type mockMountsClient struct {
+	stubClient
+
+	resp     *machine.MountsResponse
+	err      error
+	table    string
+	readErr  error
+	readPath string
+}
+
+func (m *mockMountsClient) Mounts(context.Context) (*machine.MountsResponse, error) {
+	return m.resp, m.err
+}
+
+func (m *mockMountsClient) Read(_ context.Context, path string) ([]byte, error) {
+	m.readPath = path
+	return []byte(m.table), m.readErr
+}
+
+// mountsTable is a /proc/mounts excerpt from a healthy Talos node.
+const mountsTable = `/dev/loop0 / squashfs ro,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda5 /system/state xfs rw,nosuid,nodev,noexec,relatime,attr2,inode64 0 0
+/dev/sda6 /var xfs rw,relatime,attr2,inode64,logbufs=8,logbsize=32k,noquota 0 0
+`
+
+func TestNewMountsCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		required []string
+		wantErr  bool
+	}{
+		{name: "valid defaults", required: []string{"/var", "/system/state"}, wantErr: false},
+		{name: "no mounts", required: nil, wantErr: true},
+		{name: "relative path", required: []string{"var"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewMountsCheck(tt.required)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "MOUNTS" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "MOUNTS")
+			}
+		})
+	}
+}
+
+func TestMountsCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		required    []string
+		client      *mockMountsClient
+		wantStatus  output.Status
+		wantSubstr  string
+		wantDetails string
+	}{
+		{
+			name:       "OK - required mounts read-write",
+			required:   []string{"/var", "/system/state"},
+			client:     &mockMountsClient{resp: makeMountPointsResponse("/", "/system/state", "/var"), table: mountsTable},
+			wantStatus: output.OK,
+			wantSubstr: "2/2 required mounts present and read-write",
+		},
+		{
+			name:     "CRITICAL - remounted read-only",
+			required: []string{"/var", "/system/state"},
+			client: &mockMountsClient{
+				resp: makeMountPointsResponse("/", "/system/state", "/var"),
+				table: mountsTable +
+					"/dev/sda6 /var xfs ro,relatime,attr2,inode64 0 0\n",
+			},
+			wantStatus:  output.Critical,
+			wantSubstr:  "1/2 required mounts failing: /var read-only",
+			wantDetails: "/var: read-only (xfs on /dev/sda6, options ro,relatime,attr2,inode64), status=CRITICAL",
+		},
+		{
+			name:        "CRITICAL - required mount missing",
+			required:    []string{"/var", "/var/lib/etcd"},
+			client:      &mockMountsClient{resp: makeMountPointsResponse("/", "/system/state", "/var"), table: mountsTable},
+			wantStatus:  output.Critical,
+			wantSubstr:  "1/2 required mounts failing: /var/lib/etcd not mounted",
+			wantDetails: "/var/lib/etcd: not mounted, status=CRITICAL",
+		},
+		{
+			name:     "CRITICAL - missing and read-only reported together",
+			required: []string{"/var", "/var/lib/etcd", "/system/state"},
+			client: &mockMountsClient{
+				resp:  makeMountPointsResponse("/", "/system/state", "/var"),
+				table: "/dev/sda5 /system/state xfs ro,relatime 0 0\n/dev/sda6 /var xfs rw,relatime 0 0\n",
+			},
+			wantStatus: output.Critical,
+			wantSubstr: "2/3 required mounts failing: /var/lib/etcd not mounted, /system/state read-only",
+			wantDetails: "/var/lib/etcd: not mounted, status=CRITICAL\n" +
+				"/system/state: read-only (xfs on /dev/sda5, options ro,relatime), status=CRITICAL",
+		},
+		{
+			name:        "UNKNOWN - mount without options",
+			required:    []string{"/var/mnt/data"},
+			client:      &mockMountsClient{resp: makeMountPointsResponse("/var", "/var/mnt/data"), table: mountsTable},
+			wantStatus:  output.Unknown,
+			wantSubstr:  "1/1 required mounts failing: /var/mnt/data options unavailable",
+			wantDetails: "/var/mnt/data: listed by Mounts but not in /proc/mounts, status=UNKNOWN",
+		},
+		{
+			name:       "OK - escaped mount point",
+			required:   []string{"/var/mnt/my data"},
+			client:     &mockMountsClient{resp: makeMountPointsResponse(), table: `/dev/sdb1 /var/mnt/my\040data ext4 rw 0 0`},
+			wantStatus: output.OK,
+			wantSubstr: "1/1 required mounts present and read-write",
+		},
+		{
+			name:       "UNKNOWN - empty mount table",
+			required:   []string{"/var"},
+			client:     &mockMountsClient{resp: makeMountPointsResponse("/var"), table: ""},
+			wantStatus: output.Unknown,
+			wantSubstr: "No entries in /proc/mounts",
+		},
+		{
+			name:       "UNKNOWN - nil response",
+			required:   []string{"/var"},
+			client:     &mockMountsClient{resp: nil},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name:       "error from Mounts",
+			required:   []string{"/var"},
+			client:     &mockMountsClient{err: fmt.Errorf("connection refused")},
+			wantStatus: -1, // not checked; error path
+		},
+		{
+			name:       "error from Read",
+			required:   []string{"/var"},
+			client:     &mockMountsClient{resp: makeMountPointsResponse("/var"), readErr: fmt.Errorf("permission denied")},
+			wantStatus: -1, // not checked; error path
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewMountsCheck(tt.required)
+			if err != nil {
+				t.Fatalf("NewMountsCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), tt.client)
+
+			// Error path: client returns error.
+			if tt.client.err != nil || tt.client.readErr != nil {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if result.CheckName != "MOUNTS" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "MOUNTS")
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestMountsCheckReadsProcMounts(t *testing.T) {
+	ch, err := NewMountsCheck([]string{"/var"})
+	if err != nil {
+		t.Fatalf("NewMountsCheck: %v", err)
+	}
+
+	client := &mockMountsClient{resp: makeMountPointsResponse("/var"), table: mountsTable}
+	if _, err := ch.Run(context.Background(), client); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if client.readPath != "/proc/mounts" {
+		t.Errorf("Read path = %q, want %q", client.readPath, "/proc/mounts")
+	}
+}
+
+func TestMountsCheckPerfData(t *testing.T) {
+	ch, err := NewMountsCheck([]string{"/var", "/system/state", "/var/lib/etcd"})
+	if err != nil {
+		t.Fatalf("NewMountsCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockMountsClient{
+		resp:  makeMountPointsResponse("/system/state", "/var"),
+		table: "/dev/sda5 /system/state xfs rw 0 0\n/dev/sda6 /var xfs ro 0 0\n",
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "mounts_required=3;;;0; mounts_missing=1;;;0;3 mounts_readonly=1;;;0;3"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestParseProcMounts(t *testing.T) {
+	table := parseProcMounts([]byte("overlay /var/mnt/a\\040b overlay rw 0 0\n" +
+		"short line\n" +
+		"/dev/sda6 /var xfs rw 0 0\n" +
+		"/dev/sda6 /var xfs ro,relatime 0 0\n"))
+
+	if len(table) != 2 {
+		t.Fatalf("len(table) = %d, want 2", len(table))
+	}
+	if _, ok := table["/var/mnt/a b"]; !ok {
+		t.Errorf("escaped mount point not decoded: %v", table)
+	}
+	if !table["/var"].readOnly() {
+		t.Errorf("over-mount: last /var entry should win, got %+v", table["/var"])
+	}
+}
+
+// makeMountPointsResponse builds a MountsResponse listing the given mount points.
+func makeMountPointsResponse(mounts ...string) *machine.MountsResponse {
+	stats := make([]*machine.MountStat, len(mounts))
+	for i, m := range mounts {
+		stats[i] = &machine.MountStat{MountedOn: m, Size: 1 << 30, Available: 1 << 29}
+	}
+	return &machine.MountsResponse{
+		Messages: []*machine.Mounts{
+			{Stats: stats},
+		},
+	}
+}
@@ -0,0 +1,349 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// NetworkCheck monitors per-interface error and drop counters via the Talos
+// NetworkDeviceStats API.
+//
+// The counters are cumulative since boot, so a single sample says nothing
+// about current link health. With a zero SampleDuration, the check reports
+// the counters as perfdata only and the result is always OK. With a positive
+// SampleDuration, two samples are taken SampleDuration apart and the
+// thresholds apply to the errors and drops counted in between. rx and tx are
+// evaluated separately and an interface takes the worst status of its
+// counters. Drop thresholds are optional because some drops (e.g. unknown
+// protocols) are normal on busy links.
+type NetworkCheck struct {
+	Warning        threshold.Threshold  // applied to new rx_errors and tx_errors
+	Critical       threshold.Threshold  // applied to new rx_errors and tx_errors
+	DropsWarning   *threshold.Threshold // applied to new rx_dropped and tx_dropped; nil = not evaluated
+	DropsCritical  *threshold.Threshold // applied to new rx_dropped and tx_dropped; nil = not evaluated
+	Include        []string
+	Exclude        []string
+	SampleDuration time.Duration
+}
+
+// NewNetworkCheck creates a NetworkCheck from error threshold strings,
+// optional drop threshold strings, interface include/exclude filters and an
+// optional sample duration (zero selects counters-only mode). Include and
+// exclude are mutually exclusive (validated in CLI parsing).
+func NewNetworkCheck(w, c, dropsW, dropsC string, include, exclude []string, sampleDuration time.Duration) (*NetworkCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid critical threshold: %w", err)
+	}
+
+	if sampleDuration < 0 {
+		return nil, fmt.Errorf("invalid sample duration %s: must not be negative", sampleDuration)
+	}
+
+	ch := &NetworkCheck{Warning: wt, Critical: ct, Include: include, Exclude: exclude, SampleDuration: sampleDuration}
+
+	if err := parseOptionalThresholds(threshold.UnitNone, []optionalThreshold{
+		{"drops warning", dropsW, &ch.DropsWarning},
+		{"drops critical", dropsC, &ch.DropsCritical},
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *NetworkCheck) Name() string { return "NETWORK" }
+
+// Run executes the network check against the Talos API.
+func (ch *NetworkCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	first, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	if ch.SampleDuration == 0 {
+		return ch.counters(first), nil
+	}
+
+	// The second sample must complete before the --timeout deadline.
+	waited, err := waitSampleInterval(ctx, ch.SampleDuration)
+	if err != nil {
+		return nil, err
+	}
+	if !waited {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Sample duration %s exceeds remaining timeout", ch.SampleDuration),
+		}, nil
+	}
+
+	second, result, err := ch.sample(ctx, client)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	return ch.deltas(first, second), nil
+}
+
+// sample fetches one NetworkDeviceStats response and returns the interfaces
+// selected by the include/exclude filters, sorted by name. A non-nil Result
+// is returned when the response is unusable.
+func (ch *NetworkCheck) sample(ctx context.Context, client TalosClient) ([]*machine.NetDev, *output.Result, error) {
+	resp, err := client.NetworkDeviceStats(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return nil, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	all := resp.GetMessages()[0].GetDevices()
+	if len(all) == 0 {
+		return nil, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No network interfaces in response",
+		}, nil
+	}
+
+	includeSet := toSet(ch.Include)
+	excludeSet := toSet(ch.Exclude)
+
+	var devices []*machine.NetDev
+	for _, dev := range all {
+		name := dev.GetName()
+
+		// Apply include filter: if set, skip interfaces not in the list.
+		if len(includeSet) > 0 {
+			if _, ok := includeSet[name]; !ok {
+				continue
+			}
+		}
+
+		// Apply exclude filter: skip interfaces in the exclude list.
+		if len(excludeSet) > 0 {
+			if _, ok := excludeSet[name]; ok {
+				continue
+			}
+		}
+
+		devices = append(devices, dev)
+	}
+
+	if len(devices) == 0 {
+		return nil, &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No network interfaces matched the filters",
+		}, nil
+	}
+
+	// Sort interfaces by name for deterministic perfdata and details.
+	sort.SliceStable(devices, func(i, j int) bool {
+		return devices[i].GetName() < devices[j].GetName()
+	})
+
+	return devices, nil, nil
+}
+
+// counterPerfData returns the cumulative counters of dev as perfdata.
+func counterPerfData(dev *machine.NetDev) []output.PerfDatum {
+	name := dev.GetName()
+	return []output.PerfDatum{
+		{Label: name + "_rx_bytes", Value: float64(dev.GetRxBytes()), UOM: "c", Min: "0"},
+		{Label: name + "_tx_bytes", Value: float64(dev.GetTxBytes()), UOM: "c", Min: "0"},
+		{Label: name + "_rx_packets", Value: float64(dev.GetRxPackets()), UOM: "c", Min: "0"},
+		{Label: name + "_tx_packets", Value: float64(dev.GetTxPackets()), UOM: "c", Min: "0"},
+		{Label: name + "_rx_errors", Value: float64(dev.GetRxErrors()), UOM: "c", Min: "0"},
+		{Label: name + "_tx_errors", Value: float64(dev.GetTxErrors()), UOM: "c", Min: "0"},
+		{Label: name + "_rx_dropped", Value: float64(dev.GetRxDropped()), UOM: "c", Min: "0"},
+		{Label: name + "_tx_dropped", Value: float64(dev.GetTxDropped()), UOM: "c", Min: "0"},
+	}
+}
+
+// counters builds the single-sample result: cumulative counters as perfdata
+// and no threshold evaluation.
+func (ch *NetworkCheck) counters(devices []*machine.NetDev) *output.Result {
+	perfData := make([]output.PerfDatum, 0, 8*len(devices))
+	for _, dev := range devices {
+		perfData = append(perfData, counterPerfData(dev)...)
+	}
+
+	return &output.Result{
+		Status:    output.OK,
+		CheckName: ch.Name(),
+		Summary:   fmt.Sprintf("%d interfaces, cumulative counters only (set --sample-duration to evaluate errors and drops)", len(devices)),
+		PerfData:  perfData,
+	}
+}
+
+// netDeltas holds the errors and drops one interface counted between the
+// two samples.
+type netDeltas struct {
+	name      string
+	rxErrors  uint64
+	txErrors  uint64
+	rxDropped uint64
+	txDropped uint64
+	status    output.Status
+}
+
+// deltas builds the two-sample result from the error and drop counter
+// deltas between first and second.
+func (ch *NetworkCheck) deltas(first, second []*machine.NetDev) *output.Result {
+	prev := make(map[string]*machine.NetDev, len(first))
+	for _, dev := range first {
+		prev[dev.GetName()] = dev
+	}
+
+	warnStr := ch.Warning.String()
+	critStr := ch.Critical.String()
+	dropsWarnStr := optionalString(ch.DropsWarning)
+	dropsCritStr := optionalString(ch.DropsCritical)
+
+	status := output.OK
+	var total int
+	var violations []netDeltas
+	var perfData []output.PerfDatum
+
+	for _, cur := range second {
+		old, ok := prev[cur.GetName()]
+		if !ok {
+			// Created between samples; no baseline to compute deltas from.
+			continue
+		}
+
+		if cur.GetRxErrors() < old.GetRxErrors() || cur.GetTxErrors() < old.GetTxErrors() ||
+			cur.GetRxDropped() < old.GetRxDropped() || cur.GetTxDropped() < old.GetTxDropped() {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   "Invalid data: network counters decreased between samples",
+			}
+		}
+
+		d := netDeltas{
+			name:      cur.GetName(),
+			rxErrors:  cur.GetRxErrors() - old.GetRxErrors(),
+			txErrors:  cur.GetTxErrors() - old.GetTxErrors(),
+			rxDropped: cur.GetRxDropped() - old.GetRxDropped(),
+			txDropped: cur.GetTxDropped() - old.GetTxDropped(),
+			status:    output.OK,
+		}
+		total++
+
+		for _, v := range []uint64{d.rxErrors, d.txErrors} {
+			d.status = max(d.status, evaluateCounter(float64(v), &ch.Warning, &ch.Critical))
+		}
+		for _, v := range []uint64{d.rxDropped, d.txDropped} {
+			d.status = max(d.status, evaluateCounter(float64(v), ch.DropsWarning, ch.DropsCritical))
+		}
+
+		if d.status != output.OK {
+			violations = append(violations, d)
+			status = max(status, d.status)
+		}
+
+		perfData = append(perfData, counterPerfData(cur)...)
+		perfData = append(perfData,
+			output.PerfDatum{Label: d.name + "_rx_errors_delta", Value: float64(d.rxErrors), Warn: warnStr, Crit: critStr, Min: "0"},
+			output.PerfDatum{Label: d.name + "_tx_errors_delta", Value: float64(d.txErrors), Warn: warnStr, Crit: critStr, Min: "0"},
+			output.PerfDatum{Label: d.name + "_rx_dropped_delta", Value: float64(d.rxDropped), Warn: dropsWarnStr, Crit: dropsCritStr, Min: "0"},
+			output.PerfDatum{Label: d.name + "_tx_dropped_delta", Value: float64(d.txDropped), Warn: dropsWarnStr, Crit: dropsCritStr, Min: "0"},
+		)
+	}
+
+	if total == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Invalid data: no network interface present in both samples",
+		}
+	}
+
+	over := fmt.Sprintf(" over %s", ch.SampleDuration)
+
+	if len(violations) == 0 {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("%d/%d interfaces OK%s", total, total, over),
+			PerfData:  perfData,
+		}
+	}
+
+	names := make([]string, len(violations))
+	var details strings.Builder
+	for i, v := range violations {
+		names[i] = v.name
+		if i > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: rx_errors=+%d, tx_errors=+%d, rx_dropped=+%d, tx_dropped=+%d, status=%s",
+			v.name, v.rxErrors, v.txErrors, v.rxDropped, v.txDropped, v.status)
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d/%d interfaces with errors or drops: %s%s",
+			len(violations), total, strings.Join(names, ", "), over),
+		Details:  details.String(),
+		PerfData: perfData,
+	}
+}
+
+// optionalThreshold is a threshold flag that may be left empty: its name
+// for error messages, the flag value and where to store the parsed result.
+type optionalThreshold struct {
+	name string
+	s    string
+	dst  **threshold.Threshold
+}
+
+// parseOptionalThresholds parses each non-empty value in unit and stores it
+// in its destination; empty values leave the destination nil.
+func parseOptionalThresholds(unit threshold.Unit, opts []optionalThreshold) error {
+	for _, o := range opts {
+		if o.s == "" {
+			continue
+		}
+		t, err := threshold.ParseUnit(o.s, unit)
+		if err != nil {
+			return fmt.Errorf("invalid %s threshold: %w", o.name, err)
+		}
+		*o.dst = &t
+	}
+	return nil
+}
+
+// evaluateCounter returns the status of a counter value against optional
+// warning and critical thresholds. A nil threshold is never violated.
+func evaluateCounter(v float64, warn, crit *threshold.Threshold) output.Status {
+	if crit != nil && crit.Violated(v) {
+		return output.Critical
+	}
+	if warn != nil && warn.Violated(v) {
+		return output.Warning
+	}
+	return output.OK
+}
@@ -0,0 +1,429 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockNetworkClient implements TalosClient for Network check testing.
+// When samples is set, successive NetworkDeviceStats calls return successive
+// entries.
+type mockNetworkClient struct {
+	stubClient
+
+	resp    *machine.NetworkDeviceStatsResponse
+	samples []*machine.NetworkDeviceStatsResponse
+	calls   int
+	err     error
+}
+
+func (m *mockNetworkClient) NetworkDeviceStats(_ context.Context) (*machine.NetworkDeviceStatsResponse, error) {
+	if len(m.samples) > 0 {
+		resp := m.samples[m.calls%len(m.samples)]
+		m.calls++
+		return resp, m.err
+	}
+	return m.resp, m.err
+}
+
+func TestNewNetworkCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		warn    string
+		crit    string
+		dropsW  string
+		dropsC  string
+		sample  time.Duration
+		wantErr bool
+	}{
+		{name: "valid defaults", warn: "10", crit: "100", wantErr: false},
+		{name: "valid with drops", warn: "10", crit: "100", dropsW: "1000", dropsC: "10000", wantErr: false},
+		{name: "invalid warning", warn: "abc", crit: "100", wantErr: true},
+		{name: "invalid critical", warn: "10", crit: "xyz", wantErr: true},
+		{name: "invalid drops warning", warn: "10", crit: "100", dropsW: "abc", wantErr: true},
+		{name: "invalid drops critical", warn: "10", crit: "100", dropsC: "xyz", wantErr: true},
+		{name: "size suffix rejected", warn: "10", crit: "1kB", wantErr: true},
+		{name: "drops duration suffix rejected", warn: "10", crit: "100", dropsW: "1m", wantErr: true},
+		{name: "valid sample duration", warn: "10", crit: "100", sample: 5 * time.Second, wantErr: false},
+		{name: "negative sample duration", warn: "10", crit: "100", sample: -time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewNetworkCheck(tt.warn, tt.crit, tt.dropsW, tt.dropsC, nil, nil, tt.sample)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "NETWORK" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "NETWORK")
+			}
+			if (tt.dropsW != "") != (ch.DropsWarning != nil) {
+				t.Errorf("DropsWarning = %v, want set=%v", ch.DropsWarning, tt.dropsW != "")
+			}
+		})
+	}
+}
+
+func TestNetworkCheckRunCountersOnly(t *testing.T) {
+	ch, err := NewNetworkCheck("10", "100", "", "", nil, nil, 0)
+	if err != nil {
+		t.Fatalf("NewNetworkCheck: %v", err)
+	}
+
+	// Cumulative counters far above the thresholds must not be evaluated.
+	client := &mockNetworkClient{resp: makeNetworkResponse(
+		&machine.NetDev{Name: "eth0", RxErrors: 5000, TxDropped: 90000},
+		&machine.NetDev{Name: "lo"},
+	)}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.Status != output.OK {
+		t.Errorf("status = %v, want OK", result.Status)
+	}
+	want := "2 interfaces, cumulative counters only (set --sample-duration to evaluate errors and drops)"
+	if result.Summary != want {
+		t.Errorf("summary = %q, want %q", result.Summary, want)
+	}
+	if len(result.PerfData) != 16 {
+		t.Errorf("perfdata count = %d, want 16", len(result.PerfData))
+	}
+}
+
+func TestNetworkCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		dropsW      string
+		dropsC      string
+		include     []string
+		exclude     []string
+		first       *machine.NetworkDeviceStatsResponse // nil = second with zeroed counters
+		second      *machine.NetworkDeviceStatsResponse
+		wantStatus  output.Status
+		wantSubstr  string
+		wantDetails string
+	}{
+		{
+			name: "OK - clean interfaces",
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", RxBytes: 1000, TxBytes: 2000},
+				&machine.NetDev{Name: "lo", RxBytes: 50, TxBytes: 50},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "2/2 interfaces OK over 10ms",
+		},
+		{
+			name: "OK - old errors are not counted",
+			first: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", RxErrors: 5000, TxErrors: 700},
+			),
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", RxErrors: 5003, TxErrors: 700},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "1/1 interfaces OK",
+		},
+		{
+			name: "OK - drops ignored without drop thresholds",
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", RxDropped: 50000},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "1/1 interfaces OK",
+		},
+		{
+			name: "WARNING - rx errors above warning",
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", RxErrors: 42},
+				&machine.NetDev{Name: "eth1"},
+			),
+			wantStatus:  output.Warning,
+			wantSubstr:  "1/2 interfaces with errors or drops: eth0 over 10ms",
+			wantDetails: "eth0: rx_errors=+42, tx_errors=+0, rx_dropped=+0, tx_dropped=+0, status=WARNING",
+		},
+		{
+			name: "CRITICAL - tx errors above critical",
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth1", TxErrors: 150},
+				&machine.NetDev{Name: "eth0", RxErrors: 20},
+			),
+			wantStatus: output.Critical,
+			wantSubstr: "2/2 interfaces with errors or drops: eth0, eth1",
+			wantDetails: "eth0: rx_errors=+20, tx_errors=+0, rx_dropped=+0, tx_dropped=+0, status=WARNING\n" +
+				"eth1: rx_errors=+0, tx_errors=+150, rx_dropped=+0, tx_dropped=+0, status=CRITICAL",
+		},
+		{
+			name:   "CRITICAL - drops above drop critical",
+			dropsW: "1000", dropsC: "10000",
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0", TxDropped: 20000},
+			),
+			wantStatus:  output.Critical,
+			wantSubstr:  "1/1 interfaces with errors or drops: eth0",
+			wantDetails: "eth0: rx_errors=+0, tx_errors=+0, rx_dropped=+0, tx_dropped=+20000, status=CRITICAL",
+		},
+		{
+			name:    "OK - include filter skips bad interface",
+			include: []string{"eth0"},
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0"},
+				&machine.NetDev{Name: "eth1", RxErrors: 500},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "1/1 interfaces OK",
+		},
+		{
+			name:    "OK - exclude filter skips bad interface",
+			exclude: []string{"eth1"},
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0"},
+				&machine.NetDev{Name: "eth1", RxErrors: 500},
+				&machine.NetDev{Name: "lo"},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "2/2 interfaces OK",
+		},
+		{
+			name:  "OK - interface created between samples is skipped",
+			first: makeNetworkResponse(&machine.NetDev{Name: "eth0"}),
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0"},
+				&machine.NetDev{Name: "veth1", RxErrors: 500},
+			),
+			wantStatus: output.OK,
+			wantSubstr: "1/1 interfaces OK",
+		},
+		{
+			name:       "UNKNOWN - counters decreased",
+			first:      makeNetworkResponse(&machine.NetDev{Name: "eth0", RxErrors: 10}),
+			second:     makeNetworkResponse(&machine.NetDev{Name: "eth0", RxErrors: 5}),
+			wantStatus: output.Unknown,
+			wantSubstr: "network counters decreased between samples",
+		},
+		{
+			name:       "UNKNOWN - no interface in both samples",
+			first:      makeNetworkResponse(&machine.NetDev{Name: "eth0"}),
+			second:     makeNetworkResponse(&machine.NetDev{Name: "eth1"}),
+			wantStatus: output.Unknown,
+			wantSubstr: "no network interface present in both samples",
+		},
+		{
+			name:    "UNKNOWN - no interfaces matched",
+			include: []string{"bond0"},
+			second: makeNetworkResponse(
+				&machine.NetDev{Name: "eth0"},
+			),
+			wantStatus: output.Unknown,
+			wantSubstr: "No network interfaces matched the filters",
+		},
+		{
+			name:       "UNKNOWN - no interfaces in response",
+			second:     makeNetworkResponse(),
+			wantStatus: output.Unknown,
+			wantSubstr: "No network interfaces in response",
+		},
+		{
+			name:       "UNKNOWN - empty messages",
+			second:     &machine.NetworkDeviceStatsResponse{},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewNetworkCheck("10", "100", tt.dropsW, tt.dropsC, tt.include, tt.exclude, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("NewNetworkCheck: %v", err)
+			}
+
+			first := tt.first
+			if first == nil {
+				first = zeroedNetworkResponse(tt.second)
+			}
+			client := &mockNetworkClient{samples: []*machine.NetworkDeviceStatsResponse{first, tt.second}}
+
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if result.CheckName != "NETWORK" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "NETWORK")
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestNetworkCheckRunErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     *mockNetworkClient
+		wantSubstr string
+	}{
+		{
+			name:       "UNKNOWN - nil response",
+			client:     &mockNetworkClient{resp: nil},
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name:   "error from client",
+			client: &mockNetworkClient{err: fmt.Errorf("connection refused")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewNetworkCheck("10", "100", "", "", nil, nil, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("NewNetworkCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), tt.client)
+
+			// Error path: client returns error.
+			if tt.client.err != nil {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Status != output.Unknown {
+				t.Errorf("status = %v, want UNKNOWN", result.Status)
+			}
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestNetworkCheckSampleExceedsTimeout(t *testing.T) {
+	ch, err := NewNetworkCheck("10", "100", "", "", nil, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("NewNetworkCheck: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client := &mockNetworkClient{resp: makeNetworkResponse(&machine.NetDev{Name: "eth0"})}
+	result, err := ch.Run(ctx, client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != output.Unknown {
+		t.Errorf("status = %v, want UNKNOWN", result.Status)
+	}
+	if !contains(result.Summary, "exceeds remaining timeout") {
+		t.Errorf("summary %q does not mention the timeout", result.Summary)
+	}
+}
+
+func TestNetworkCheckPerfData(t *testing.T) {
+	dev := func(rxErrors, txErrors, rxDropped, txDropped uint64) *machine.NetworkDeviceStatsResponse {
+		return makeNetworkResponse(&machine.NetDev{
+			Name:    "eth0",
+			RxBytes: 123456, TxBytes: 654321,
+			RxPackets: 1000, TxPackets: 900,
+			RxErrors: rxErrors, TxErrors: txErrors,
+			RxDropped: rxDropped, TxDropped: txDropped,
+		})
+	}
+	counters := "eth0_rx_bytes=123456c;;;0; eth0_tx_bytes=654321c;;;0; " +
+		"eth0_rx_packets=1000c;;;0; eth0_tx_packets=900c;;;0; " +
+		"eth0_rx_errors=11c;;;0; eth0_tx_errors=12c;;;0; " +
+		"eth0_rx_dropped=13c;;;0; eth0_tx_dropped=14c;;;0;"
+
+	tests := []struct {
+		name   string
+		sample time.Duration
+		want   string
+	}{
+		{
+			name: "counters only",
+			want: counters,
+		},
+		{
+			name:   "sampled",
+			sample: 10 * time.Millisecond,
+			want: counters + " eth0_rx_errors_delta=1;10;100;0; eth0_tx_errors_delta=2;10;100;0; " +
+				"eth0_rx_dropped_delta=3;;5000;0; eth0_tx_dropped_delta=4;;5000;0;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewNetworkCheck("10", "100", "", "5000", nil, nil, tt.sample)
+			if err != nil {
+				t.Fatalf("NewNetworkCheck: %v", err)
+			}
+
+			client := &mockNetworkClient{samples: []*machine.NetworkDeviceStatsResponse{dev(10, 10, 10, 10), dev(11, 12, 13, 14)}}
+			if tt.sample == 0 {
+				client = &mockNetworkClient{resp: dev(11, 12, 13, 14)}
+			}
+
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if got := output.FormatPerfData(result.PerfData); got != tt.want {
+				t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// makeNetworkResponse builds a NetworkDeviceStatsResponse with the given devices.
+func makeNetworkResponse(devices ...*machine.NetDev) *machine.NetworkDeviceStatsResponse {
+	return &machine.NetworkDeviceStatsResponse{
+		Messages: []*machine.NetworkDeviceStats{
+			{Devices: devices},
+		},
+	}
+}
+
+// zeroedNetworkResponse returns a copy of resp with the same interfaces and
+// all error and drop counters at zero, used as the first sample.
+func zeroedNetworkResponse(resp *machine.NetworkDeviceStatsResponse) *machine.NetworkDeviceStatsResponse {
+	var devices []*machine.NetDev
+	for _, msg := range resp.GetMessages() {
+		for _, dev := range msg.GetDevices() {
+			devices = append(devices, &machine.NetDev{Name: dev.GetName()})
+		}
+	}
+	if len(resp.GetMessages()) == 0 {
+		return resp
+	}
+	return makeNetworkResponse(devices...)
+}
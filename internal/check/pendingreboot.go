@@ -0,0 +1,232 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/talos/pkg/machinery/meta"
+	"github.com/siderolabs/talos/pkg/machinery/resources/config"
+	"github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// PendingRebootCheck detects changes that only take effect on the next
+// reboot, read from the node's COSI state:
+//
+//   - a machine config applied with --mode=staged: the persistent
+//     MachineConfig (written to STATE) differs from the active one;
+//   - an upgrade staged with talosctl upgrade --stage: the
+//     StagedUpgradeImageRef META key is set.
+//
+// Warning and Critical apply to how long the oldest change has been pending,
+// in seconds. With Warning unset any pending change is WARNING.
+type PendingRebootCheck struct {
+	Warning  *threshold.Threshold // pending seconds; nil = WARNING when anything is pending
+	Critical *threshold.Threshold // pending seconds; nil = never CRITICAL
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+// NewPendingRebootCheck creates a PendingRebootCheck from optional warning
+// and critical threshold strings expressed in seconds or with duration
+// suffixes ("1d"). Empty strings leave the threshold unset.
+func NewPendingRebootCheck(w, c string) (*PendingRebootCheck, error) {
+	ch := &PendingRebootCheck{now: time.Now}
+
+	if err := parseOptionalThresholds(threshold.UnitSeconds, []optionalThreshold{
+		{"warning", w, &ch.Warning},
+		{"critical", c, &ch.Critical},
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *PendingRebootCheck) Name() string { return "PENDING-REBOOT" }
+
+// pendingChange is one change waiting for a reboot.
+type pendingChange struct {
+	summary string    // e.g. "machine config (2 keys differ)"
+	since   time.Time // when the change was staged
+	details []string  // long-text lines
+}
+
+// Run executes the pending reboot check against the Talos API.
+func (ch *PendingRebootCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	configChange, unknown, err := ch.stagedConfig(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if unknown != "" {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   unknown,
+		}, nil
+	}
+
+	upgradeChange, err := ch.stagedUpgrade(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*pendingChange
+	for _, c := range []*pendingChange{configChange, upgradeChange} {
+		if c != nil {
+			pending = append(pending, c)
+		}
+	}
+
+	if len(pending) == 0 {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   "No staged config or upgrade pending reboot",
+			PerfData:  ch.perfData(0, 0),
+		}, nil
+	}
+
+	oldest := pending[0].since
+	for _, c := range pending[1:] {
+		if c.since.Before(oldest) {
+			oldest = c.since
+		}
+	}
+	// Timestamps come from the node's clock; a node ahead of this host
+	// yields a negative age, which still means "just staged".
+	age := max(ch.now().Sub(oldest), 0)
+	seconds := float64(age / time.Second)
+
+	status := evaluateCounter(seconds, ch.Warning, ch.Critical)
+	if ch.Warning == nil {
+		status = max(status, output.Warning)
+	}
+
+	parts := make([]string, len(pending))
+	var details []string
+	for i, c := range pending {
+		parts[i] = c.summary
+		details = append(details, c.details...)
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("Reboot pending for %s: %s",
+			output.HumanDuration(age), strings.Join(parts, ", ")),
+		Details:  strings.Join(details, "\n"),
+		PerfData: ch.perfData(seconds, len(pending)),
+	}, nil
+}
+
+// stagedConfig compares the persistent machine config with the active one.
+// It returns a non-empty unknown message when the active config is missing
+// or cannot be encoded.
+func (ch *PendingRebootCheck) stagedConfig(ctx context.Context, client TalosClient) (*pendingChange, string, error) {
+	active, err := getMachineConfig(ctx, client, config.ActiveID)
+	if err != nil {
+		return nil, "", err
+	}
+	if active == nil {
+		return nil, "No active machine config (node in maintenance mode?)", nil
+	}
+
+	persistent, err := getMachineConfig(ctx, client, config.PersistentID)
+	if err != nil || persistent == nil {
+		return nil, "", err
+	}
+
+	activeBytes, err := active.Provider().Bytes()
+	if err != nil {
+		return nil, fmt.Sprintf("Cannot encode active machine config: %s", err), nil
+	}
+	persistentBytes, err := persistent.Provider().Bytes()
+	if err != nil {
+		return nil, fmt.Sprintf("Cannot encode persistent machine config: %s", err), nil
+	}
+	if string(activeBytes) == string(persistentBytes) {
+		return nil, "", nil
+	}
+
+	since := persistent.Metadata().Updated()
+	change := &pendingChange{
+		summary: "machine config",
+		since:   since,
+		details: []string{fmt.Sprintf("machine config: staged %s", since.UTC().Format(time.RFC3339))},
+	}
+
+	// Name the keys that change on reboot. Both configs were accepted by
+	// the node, so a parse failure here only loses the key list.
+	activeKeys, err1 := flattenMachineConfig(activeBytes)
+	persistentKeys, err2 := flattenMachineConfig(persistentBytes)
+	if err1 != nil || err2 != nil {
+		return change, "", nil
+	}
+
+	var changed int
+	for _, k := range configKeyUnion(activeKeys, persistentKeys) {
+		activeVal, inActive := activeKeys[k]
+		stagedVal, inStaged := persistentKeys[k]
+
+		var what string
+		switch {
+		case !inActive:
+			what = "added"
+		case !inStaged:
+			what = "removed"
+		case activeVal != stagedVal:
+			what = "changed"
+		default:
+			continue
+		}
+		changed++
+		change.details = append(change.details, fmt.Sprintf("%s: %s", k, what))
+	}
+	if changed > 0 {
+		change.summary = fmt.Sprintf("machine config (%d keys differ)", changed)
+	}
+
+	return change, "", nil
+}
+
+// stagedUpgrade reads the staged upgrade image from META.
+func (ch *PendingRebootCheck) stagedUpgrade(ctx context.Context, client TalosClient) (*pendingChange, error) {
+	res, err := client.GetResource(ctx, resource.NewMetadata(
+		runtime.NamespaceName, runtime.MetaKeyType, runtime.MetaKeyTagToID(meta.StagedUpgradeImageRef),
+		resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	key, ok := res.(*runtime.MetaKey)
+	if !ok || key.TypedSpec().Value == "" {
+		return nil, nil
+	}
+
+	image := key.TypedSpec().Value
+	since := key.Metadata().Updated()
+	return &pendingChange{
+		summary: "upgrade to " + image,
+		since:   since,
+		details: []string{fmt.Sprintf("upgrade: %s staged %s", image, since.UTC().Format(time.RFC3339))},
+	}, nil
+}
+
+// perfData returns the pending age and the number of pending changes.
+func (ch *PendingRebootCheck) perfData(seconds float64, count int) []output.PerfDatum {
+	return []output.PerfDatum{
+		{Label: "pending_reboot_age", Value: seconds, UOM: "s", Warn: optionalString(ch.Warning), Crit: optionalString(ch.Critical), Min: "0"},
+		{Label: "pending_reboot_changes", Value: float64(count), Min: "0"},
+	}
+}
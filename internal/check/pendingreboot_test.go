@@ -0,0 +1,328 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/siderolabs/talos/pkg/machinery/meta"
+	"github.com/siderolabs/talos/pkg/machinery/resources/config"
+	"github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// mockPendingRebootClient implements TalosClient for Pending reboot check testing.
+type mockPendingRebootClient struct {
+	stubClient
+
+	resources map[string]resource.Resource // keyed by "<type>/<id>"
+	err       error
+}
+
+func (m *mockPendingRebootClient) GetResource(_ context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	r, ok := m.resources[ptr.Type()+"/"+ptr.ID()]
+	if !ok {
+		return nil, inmem.ErrNotFound(ptr)
+	}
+	return r, nil
+}
+
+// pendingRebootNow is the fixed "current" time used by pending reboot tests.
+var pendingRebootNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// appliedConfig is the machine config the node is running.
+const appliedConfig = `version: v1alpha1
+machine:
+  type: worker
+  token: abcdef.0123456789abcdef
+  install:
+    disk: /dev/sda
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.1
+cluster:
+  controlPlane:
+    endpoint: https://10.0.0.10:6443
+`
+
+// stagedConfigYAML is appliedConfig with a new kubelet image and an extra
+// sysctl, as written to STATE by apply-config --mode=staged.
+const stagedConfigYAML = `version: v1alpha1
+machine:
+  type: worker
+  token: abcdef.0123456789abcdef
+  install:
+    disk: /dev/sda
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.2
+  sysctls:
+    vm.swappiness: "10"
+cluster:
+  controlPlane:
+    endpoint: https://10.0.0.10:6443
+`
+
+// machineConfigResource returns a MachineConfig resource with the given ID
+// and update time.
+func machineConfigResource(t *testing.T, id resource.ID, cfg string, updated time.Time) *config.MachineConfig {
+	t.Helper()
+	p, err := configloader.NewFromBytes([]byte(cfg))
+	if err != nil {
+		t.Fatalf("configloader.NewFromBytes: %v", err)
+	}
+	r := config.NewMachineConfigWithID(p, id)
+	r.Metadata().SetUpdated(updated)
+	return r
+}
+
+// stagedUpgradeKey returns the StagedUpgradeImageRef META key resource.
+func stagedUpgradeKey(image string, updated time.Time) *runtime.MetaKey {
+	r := runtime.NewMetaKey(runtime.NamespaceName, runtime.MetaKeyTagToID(meta.StagedUpgradeImageRef))
+	r.TypedSpec().Value = image
+	r.Metadata().SetUpdated(updated)
+	return r
+}
+
+// pendingRebootResources keys resources the way mockPendingRebootClient
+// looks them up.
+func pendingRebootResources(rs ...resource.Resource) map[string]resource.Resource {
+	m := make(map[string]resource.Resource, len(rs))
+	for _, r := range rs {
+		m[r.Metadata().Type()+"/"+r.Metadata().ID()] = r
+	}
+	return m
+}
+
+func TestNewPendingRebootCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		w, c    string
+		wantErr bool
+	}{
+		{name: "no thresholds", wantErr: false},
+		{name: "duration thresholds", w: "1h", c: "1d", wantErr: false},
+		{name: "seconds", w: "3600", c: "86400", wantErr: false},
+		{name: "invalid warning", w: "abc", wantErr: true},
+		{name: "invalid critical", c: "1x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewPendingRebootCheck(tt.w, tt.c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "PENDING-REBOOT" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "PENDING-REBOOT")
+			}
+			if (tt.w == "") != (ch.Warning == nil) {
+				t.Errorf("Warning = %v, want set = %v", ch.Warning, tt.w != "")
+			}
+			if (tt.c == "") != (ch.Critical == nil) {
+				t.Errorf("Critical = %v, want set = %v", ch.Critical, tt.c != "")
+			}
+		})
+	}
+}
+
+func TestPendingRebootCheckRun(t *testing.T) {
+	booted := pendingRebootNow.Add(-30 * 24 * time.Hour)
+	active := func(t *testing.T) resource.Resource {
+		return machineConfigResource(t, config.ActiveID, appliedConfig, booted)
+	}
+	persistent := func(t *testing.T, cfg string, age time.Duration) resource.Resource {
+		return machineConfigResource(t, config.PersistentID, cfg, pendingRebootNow.Add(-age))
+	}
+	upgrade := func(age time.Duration) resource.Resource {
+		return stagedUpgradeKey("ghcr.io/siderolabs/installer:v1.12.0", pendingRebootNow.Add(-age))
+	}
+
+	tests := []struct {
+		name        string
+		w, c        string
+		resources   func(t *testing.T) []resource.Resource
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name: "OK - persistent matches active",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), persistent(t, appliedConfig, 30*24*time.Hour)}
+			},
+			wantStatus:  output.OK,
+			wantSummary: "No staged config or upgrade pending reboot",
+		},
+		{
+			name: "OK - no persistent config or META key",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t)}
+			},
+			wantStatus:  output.OK,
+			wantSummary: "No staged config or upgrade pending reboot",
+		},
+		{
+			name: "OK - empty staged upgrade key",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), stagedUpgradeKey("", pendingRebootNow)}
+			},
+			wantStatus:  output.OK,
+			wantSummary: "No staged config or upgrade pending reboot",
+		},
+		{
+			name: "WARNING - staged config",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), persistent(t, stagedConfigYAML, 3*time.Hour+12*time.Minute)}
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "Reboot pending for 3h 12m: machine config (2 keys differ)",
+			wantDetails: "machine config: staged 2026-10-16T08:48:00Z\n" +
+				"machine.kubelet.image: changed\n" +
+				"machine.sysctls.vm.swappiness: added",
+		},
+		{
+			name: "WARNING - staged upgrade",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), upgrade(20 * time.Minute)}
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "Reboot pending for 20m 0s: upgrade to ghcr.io/siderolabs/installer:v1.12.0",
+			wantDetails: "upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-16T11:40:00Z",
+		},
+		{
+			name: "WARNING - both pending, age of the oldest",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), persistent(t, stagedConfigYAML, 2*time.Hour), upgrade(20 * time.Minute)}
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "Reboot pending for 2h 0m: machine config (2 keys differ), upgrade to ghcr.io/siderolabs/installer:v1.12.0",
+			wantDetails: "machine config: staged 2026-10-16T10:00:00Z\n" +
+				"machine.kubelet.image: changed\n" +
+				"machine.sysctls.vm.swappiness: added\n" +
+				"upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-16T11:40:00Z",
+		},
+		{
+			name: "OK - pending within warning grace period",
+			w:    "1h",
+			c:    "1d",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), upgrade(10 * time.Minute)}
+			},
+			wantStatus:  output.OK,
+			wantSummary: "Reboot pending for 10m 0s: upgrade to ghcr.io/siderolabs/installer:v1.12.0",
+			wantDetails: "upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-16T11:50:00Z",
+		},
+		{
+			name: "WARNING - past warning grace period",
+			w:    "1h",
+			c:    "1d",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), upgrade(90 * time.Minute)}
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "Reboot pending for 1h 30m",
+			wantDetails: "upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-16T10:30:00Z",
+		},
+		{
+			name: "CRITICAL - pending longer than critical",
+			c:    "1d",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), upgrade(50 * time.Hour)}
+			},
+			wantStatus:  output.Critical,
+			wantSummary: "Reboot pending for 2d 2h",
+			wantDetails: "upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-14T10:00:00Z",
+		},
+		{
+			name: "WARNING - node clock ahead clamps age to zero",
+			resources: func(t *testing.T) []resource.Resource {
+				return []resource.Resource{active(t), upgrade(-5 * time.Minute)}
+			},
+			wantStatus:  output.Warning,
+			wantSummary: "Reboot pending for 0s",
+			wantDetails: "upgrade: ghcr.io/siderolabs/installer:v1.12.0 staged 2026-10-16T12:05:00Z",
+		},
+		{
+			name: "UNKNOWN - no active config",
+			resources: func(t *testing.T) []resource.Resource {
+				return nil
+			},
+			wantStatus:  output.Unknown,
+			wantSummary: "No active machine config (node in maintenance mode?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewPendingRebootCheck(tt.w, tt.c)
+			if err != nil {
+				t.Fatalf("NewPendingRebootCheck: %v", err)
+			}
+			ch.now = func() time.Time { return pendingRebootNow }
+
+			client := &mockPendingRebootClient{resources: pendingRebootResources(tt.resources(t)...)}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "PENDING-REBOOT" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "PENDING-REBOOT")
+			}
+			if !contains(result.Summary, tt.wantSummary) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestPendingRebootCheckPerfData(t *testing.T) {
+	ch, err := NewPendingRebootCheck("1h", "1d")
+	if err != nil {
+		t.Fatalf("NewPendingRebootCheck: %v", err)
+	}
+	ch.now = func() time.Time { return pendingRebootNow }
+
+	client := &mockPendingRebootClient{resources: pendingRebootResources(
+		machineConfigResource(t, config.ActiveID, appliedConfig, pendingRebootNow),
+		stagedUpgradeKey("ghcr.io/siderolabs/installer:v1.12.0", pendingRebootNow.Add(-2*time.Hour)),
+	)}
+	result, err := ch.Run(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "pending_reboot_age=7200s;3600;86400;0; pending_reboot_changes=1;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestPendingRebootCheckAPIError(t *testing.T) {
+	ch, err := NewPendingRebootCheck("", "")
+	if err != nil {
+		t.Fatalf("NewPendingRebootCheck: %v", err)
+	}
+
+	if _, err := ch.Run(context.Background(), &mockPendingRebootClient{err: fmt.Errorf("permission denied")}); err == nil {
+		t.Error("expected error from GetResource, got nil")
+	}
+}
@@ -0,0 +1,244 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+)
+
+// PSI resources, lines and averaging windows accepted by PressureCheck.
+var (
+	PressureResources = []string{"cpu", "memory", "io"}
+	PressureKinds     = []string{"some", "full"}
+	PressureWindows   = []string{"avg10", "avg60", "avg300"}
+)
+
+// PressureCheck monitors Pressure Stall Information (PSI) by reading
+// /proc/pressure/<resource> through the Talos Read API.
+//
+// Each file has a "some" line (share of time at least one task was stalled
+// on the resource) and a "full" line (share of time all non-idle tasks were
+// stalled), each with 10s, 60s and 300s averages in percent. Every value is
+// emitted as perfdata; the thresholds apply to the Kind line and Window
+// average of every selected resource, and the status is the worst resource.
+type PressureCheck struct {
+	Warning   threshold.Threshold
+	Critical  threshold.Threshold
+	Resources []string
+	Kind      string
+	Window    string
+}
+
+// NewPressureCheck creates a PressureCheck from warning and critical
+// threshold strings, the resources to read, and the line and averaging
+// window the thresholds apply to.
+func NewPressureCheck(w, c string, resources []string, kind, window string) (*PressureCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid critical threshold: %w", err)
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no resources selected")
+	}
+	for _, r := range resources {
+		if !containsString(PressureResources, r) {
+			return nil, fmt.Errorf("invalid resource %q: must be one of %s", r, strings.Join(PressureResources, ", "))
+		}
+	}
+	if !containsString(PressureKinds, kind) {
+		return nil, fmt.Errorf("invalid kind %q: must be one of %s", kind, strings.Join(PressureKinds, ", "))
+	}
+	if !containsString(PressureWindows, window) {
+		return nil, fmt.Errorf("invalid window %q: must be one of %s", window, strings.Join(PressureWindows, ", "))
+	}
+	return &PressureCheck{
+		Warning:   wt,
+		Critical:  ct,
+		Resources: resources,
+		Kind:      kind,
+		Window:    window,
+	}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *PressureCheck) Name() string { return "PRESSURE" }
+
+// Run executes the pressure check against the Talos API.
+func (ch *PressureCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	type resourcePressure struct {
+		name   string
+		value  float64
+		lines  map[string]psiLine
+		status output.Status
+	}
+
+	warnStr := ch.Warning.String()
+	critStr := ch.Critical.String()
+
+	status := output.OK
+	var pressures []resourcePressure
+	var perfData []output.PerfDatum
+
+	for _, res := range ch.Resources {
+		data, err := client.Read(ctx, "/proc/pressure/"+res)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := parsePSI(data)
+		selected, ok := lines[ch.Kind]
+		if !ok {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("No %q line in /proc/pressure/%s", ch.Kind, res),
+				PerfData:  perfData,
+			}, nil
+		}
+
+		value := selected.avg(ch.Window)
+		resStatus := evaluateCounter(value, &ch.Warning, &ch.Critical)
+		status = max(status, resStatus)
+		pressures = append(pressures, resourcePressure{name: res, value: value, lines: lines, status: resStatus})
+
+		for _, kind := range PressureKinds {
+			line, ok := lines[kind]
+			if !ok {
+				continue
+			}
+			for _, window := range PressureWindows {
+				pd := output.PerfDatum{
+					Label: res + "_" + kind + "_" + window,
+					Value: line.avg(window),
+					Min:   "0",
+					Max:   "100",
+				}
+				if kind == ch.Kind && window == ch.Window {
+					pd.Warn, pd.Crit = warnStr, critStr
+				}
+				perfData = append(perfData, pd)
+			}
+		}
+	}
+
+	var values []string
+	var offenders []string
+	var details strings.Builder
+	for _, p := range pressures {
+		values = append(values, fmt.Sprintf("%s %.2f%%", p.name, p.value))
+		if p.status == output.OK {
+			continue
+		}
+		offenders = append(offenders, fmt.Sprintf("%s %.2f%%", p.name, p.value))
+		if details.Len() > 0 {
+			details.WriteByte('\n')
+		}
+		fmt.Fprintf(&details, "%s: %s, status=%s", p.name, describePSI(p.lines), p.status)
+	}
+
+	if len(offenders) == 0 {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("PSI %s %s: %s", ch.Kind, ch.Window, strings.Join(values, ", ")),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("%d/%d resources under pressure (%s %s): %s",
+			len(offenders), len(pressures), ch.Kind, ch.Window, strings.Join(offenders, ", ")),
+		Details:  details.String(),
+		PerfData: perfData,
+	}, nil
+}
+
+// psiLine holds the averages of one PSI line, in percent.
+type psiLine struct {
+	avg10  float64
+	avg60  float64
+	avg300 float64
+}
+
+// avg returns the average for window ("avg10", "avg60" or "avg300").
+func (l psiLine) avg(window string) float64 {
+	switch window {
+	case "avg10":
+		return l.avg10
+	case "avg300":
+		return l.avg300
+	default:
+		return l.avg60
+	}
+}
+
+// parsePSI parses a /proc/pressure file into lines keyed by "some" and
+// "full". Lines look like
+//
+//	some avg10=0.00 avg60=1.25 avg300=0.80 total=123456
+//
+// Unknown keys (total) and malformed values are ignored.
+func parsePSI(data []byte) map[string]psiLine {
+	lines := make(map[string]psiLine)
+	for _, raw := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(raw)
+		if len(fields) == 0 || !containsString(PressureKinds, fields[0]) {
+			continue
+		}
+		var l psiLine
+		for _, f := range fields[1:] {
+			key, val, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "avg10":
+				l.avg10 = v
+			case "avg60":
+				l.avg60 = v
+			case "avg300":
+				l.avg300 = v
+			}
+		}
+		lines[fields[0]] = l
+	}
+	return lines
+}
+
+// describePSI returns "some avg10=<v> avg60=<v> avg300=<v>, full ..." for
+// the lines present.
+func describePSI(lines map[string]psiLine) string {
+	var parts []string
+	for _, kind := range PressureKinds {
+		l, ok := lines[kind]
+		if !ok {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s avg10=%.2f avg60=%.2f avg300=%.2f", kind, l.avg10, l.avg60, l.avg300))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// containsString reports whether items contains s.
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+)
+
+// mockPressureClient implements TalosClient for Pressure check testing.
+type mockPressureClient struct {
+	stubClient
+
+	files map[string]string
+	err   error
+}
+
+func (m *mockPressureClient) Read(_ context.Context, path string) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	data, ok := m.files[path]
+	if !ok {
+		return nil, fmt.Errorf("open %s: no such file or directory", path)
+	}
+	return []byte(data), nil
+}
+
+// psiFile builds /proc/pressure content with the given some and full
+// averages (avg10, avg60, avg300).
+func psiFile(some, full [3]float64) string {
+	return fmt.Sprintf("some avg10=%.2f avg60=%.2f avg300=%.2f total=123456\n"+
+		"full avg10=%.2f avg60=%.2f avg300=%.2f total=65432\n",
+		some[0], some[1], some[2], full[0], full[1], full[2])
+}
+
+// calmPSI returns /proc/pressure content for all three resources with low
+// pressure.
+func calmPSI() map[string]string {
+	return map[string]string{
+		"/proc/pressure/cpu":    psiFile([3]float64{1.5, 1.2, 0.9}, [3]float64{0, 0, 0}),
+		"/proc/pressure/memory": psiFile([3]float64{0, 0, 0}, [3]float64{0, 0, 0}),
+		"/proc/pressure/io":     psiFile([3]float64{4, 3.4, 2}, [3]float64{1, 0.5, 0.2}),
+	}
+}
+
+func TestNewPressureCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []string
+		kind      string
+		window    string
+		wantErr   bool
+	}{
+		{name: "valid defaults", resources: PressureResources, kind: "some", window: "avg60", wantErr: false},
+		{name: "valid full avg10 io", resources: []string{"io"}, kind: "full", window: "avg10", wantErr: false},
+		{name: "unknown resource", resources: []string{"irq"}, kind: "some", window: "avg60", wantErr: true},
+		{name: "no resources", resources: nil, kind: "some", window: "avg60", wantErr: true},
+		{name: "unknown kind", resources: PressureResources, kind: "all", window: "avg60", wantErr: true},
+		{name: "unknown window", resources: PressureResources, kind: "some", window: "avg5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewPressureCheck("10", "25", tt.resources, tt.kind, tt.window)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "PRESSURE" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "PRESSURE")
+			}
+		})
+	}
+
+	if _, err := NewPressureCheck("abc", "25", PressureResources, "some", "avg60"); err == nil {
+		t.Error("expected error for invalid warning threshold, got nil")
+	}
+	if _, err := NewPressureCheck("10", "25m", PressureResources, "some", "avg60"); err == nil {
+		t.Error("expected error for duration suffix on percentage threshold, got nil")
+	}
+}
+
+func TestPressureCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		resources   []string
+		kind        string
+		window      string
+		files       map[string]string
+		wantStatus  output.Status
+		wantSubstr  string
+		wantDetails string
+	}{
+		{
+			name:       "OK - low pressure",
+			files:      calmPSI(),
+			wantStatus: output.OK,
+			wantSubstr: "PSI some avg60: cpu 1.20%, memory 0.00%, io 3.40%",
+		},
+		{
+			name: "WARNING - io pressure",
+			files: func() map[string]string {
+				f := calmPSI()
+				f["/proc/pressure/io"] = psiFile([3]float64{20, 12.5, 6}, [3]float64{8, 5, 2})
+				return f
+			}(),
+			wantStatus:  output.Warning,
+			wantSubstr:  "1/3 resources under pressure (some avg60): io 12.50%",
+			wantDetails: "io: some avg10=20.00 avg60=12.50 avg300=6.00, full avg10=8.00 avg60=5.00 avg300=2.00, status=WARNING",
+		},
+		{
+			name: "CRITICAL - memory full avg10",
+			kind: "full", window: "avg10",
+			files: func() map[string]string {
+				f := calmPSI()
+				f["/proc/pressure/memory"] = psiFile([3]float64{45, 20, 5}, [3]float64{30, 10, 2})
+				f["/proc/pressure/cpu"] = psiFile([3]float64{80, 60, 40}, [3]float64{12, 0, 0})
+				return f
+			}(),
+			wantStatus: output.Critical,
+			wantSubstr: "2/3 resources under pressure (full avg10): cpu 12.00%, memory 30.00%",
+			wantDetails: "cpu: some avg10=80.00 avg60=60.00 avg300=40.00, full avg10=12.00 avg60=0.00 avg300=0.00, status=WARNING\n" +
+				"memory: some avg10=45.00 avg60=20.00 avg300=5.00, full avg10=30.00 avg60=10.00 avg300=2.00, status=CRITICAL",
+		},
+		{
+			name:      "OK - resource selector skips hot resource",
+			resources: []string{"memory"},
+			files: func() map[string]string {
+				f := calmPSI()
+				f["/proc/pressure/cpu"] = psiFile([3]float64{90, 90, 90}, [3]float64{0, 0, 0})
+				return f
+			}(),
+			wantStatus: output.OK,
+			wantSubstr: "PSI some avg60: memory 0.00%",
+		},
+		{
+			name:      "UNKNOWN - no full line on older kernels",
+			resources: []string{"cpu"},
+			kind:      "full",
+			files: map[string]string{
+				"/proc/pressure/cpu": "some avg10=1.00 avg60=1.00 avg300=1.00 total=10\n",
+			},
+			wantStatus: output.Unknown,
+			wantSubstr: `No "full" line in /proc/pressure/cpu`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, kind, window := tt.resources, tt.kind, tt.window
+			if resources == nil {
+				resources = PressureResources
+			}
+			if kind == "" {
+				kind = "some"
+			}
+			if window == "" {
+				window = "avg60"
+			}
+			ch, err := NewPressureCheck("10", "25", resources, kind, window)
+			if err != nil {
+				t.Fatalf("NewPressureCheck: %v", err)
+			}
+
+			result, err := ch.Run(context.Background(), &mockPressureClient{files: tt.files})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if result.CheckName != "PRESSURE" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "PRESSURE")
+			}
+
+			if !contains(result.Summary, tt.wantSubstr) {
+				t.Errorf("summary %q does not contain %q", result.Summary, tt.wantSubstr)
+			}
+
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestPressureCheckErrors(t *testing.T) {
+	ch, err := NewPressureCheck("10", "25", PressureResources, "some", "avg60")
+	if err != nil {
+		t.Fatalf("NewPressureCheck: %v", err)
+	}
+
+	// PSI disabled in the kernel: the files do not exist.
+	if _, err := ch.Run(context.Background(), &mockPressureClient{files: map[string]string{}}); err == nil {
+		t.Error("expected error for missing PSI file, got nil")
+	}
+
+	if _, err := ch.Run(context.Background(), &mockPressureClient{err: fmt.Errorf("connection refused")}); err == nil {
+		t.Error("expected error from client, got nil")
+	}
+}
+
+func TestPressureCheckPerfData(t *testing.T) {
+	ch, err := NewPressureCheck("10", "25", []string{"io"}, "full", "avg300")
+	if err != nil {
+		t.Fatalf("NewPressureCheck: %v", err)
+	}
+
+	result, err := ch.Run(context.Background(), &mockPressureClient{files: calmPSI()})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "io_some_avg10=4;;;0;100 io_some_avg60=3.4;;;0;100 io_some_avg300=2;;;0;100 " +
+		"io_full_avg10=1;;;0;100 io_full_avg60=0.5;;;0;100 io_full_avg300=0.2;10;25;0;100"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
@@ -0,0 +1,244 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+)
+
+// processTopN is the number of commands listed per process state in the
+// summary and long text.
+const processTopN = 5
+
+// ProcessesCheck counts processes via the Talos Processes API and reports
+// the SystemStat process counters alongside.
+//
+// Warning and Critical apply to the number of zombie (Z) processes. The
+// D-state (uninterruptible sleep) and total process thresholds are optional
+// (nil = not evaluated): a few tasks in D state are normal under I/O load,
+// and a sensible total depends on the node's workload. Offending processes
+// are grouped by command name, largest group first.
+type ProcessesCheck struct {
+	Warning        threshold.Threshold  // zombie count
+	Critical       threshold.Threshold  // zombie count
+	DStateWarning  *threshold.Threshold // D-state count; nil = not evaluated
+	DStateCritical *threshold.Threshold // D-state count; nil = not evaluated
+	TotalWarning   *threshold.Threshold // total count; nil = not evaluated
+	TotalCritical  *threshold.Threshold // total count; nil = not evaluated
+}
+
+// ProcessesOptions holds the optional process threshold strings. An empty
+// string leaves the metric unevaluated.
+type ProcessesOptions struct {
+	DStateWarning  string
+	DStateCritical string
+	TotalWarning   string
+	TotalCritical  string
+}
+
+// NewProcessesCheck creates a ProcessesCheck from warning and critical
+// threshold strings for the zombie count and the optional thresholds in opts.
+func NewProcessesCheck(w, c string, opts ProcessesOptions) (*ProcessesCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+	ct, err := threshold.ParseUnit(c, threshold.UnitNone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid critical threshold: %w", err)
+	}
+
+	ch := &ProcessesCheck{Warning: wt, Critical: ct}
+
+	if err := parseOptionalThresholds(threshold.UnitNone, []optionalThreshold{
+		{"D-state warning", opts.DStateWarning, &ch.DStateWarning},
+		{"D-state critical", opts.DStateCritical, &ch.DStateCritical},
+		{"total warning", opts.TotalWarning, &ch.TotalWarning},
+		{"total critical", opts.TotalCritical, &ch.TotalCritical},
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *ProcessesCheck) Name() string { return "PROCESSES" }
+
+// Run executes the processes check against the Talos API.
+func (ch *ProcessesCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	statResp, err := client.SystemStat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if statResp == nil || len(statResp.GetMessages()) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	procResp, err := client.Processes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if procResp == nil || len(procResp.GetMessages()) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	procs := procResp.GetMessages()[0].GetProcesses()
+	if len(procs) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No processes in response",
+		}, nil
+	}
+
+	commands := make(map[int32]string, len(procs))
+	for _, p := range procs {
+		commands[p.GetPid()] = p.GetCommand()
+	}
+
+	zombies := make(map[string]*processGroup)
+	dstate := make(map[string]*processGroup)
+	var zombieCount, dstateCount int
+	for _, p := range procs {
+		switch {
+		case strings.HasPrefix(p.GetState(), "Z"):
+			zombieCount++
+			addProcess(zombies, p.GetCommand(), commands[p.GetPpid()])
+		case strings.HasPrefix(p.GetState(), "D"):
+			dstateCount++
+			addProcess(dstate, p.GetCommand(), "")
+		}
+	}
+	total := len(procs)
+
+	zombieStatus := evaluateCounter(float64(zombieCount), &ch.Warning, &ch.Critical)
+	dstateStatus := evaluateCounter(float64(dstateCount), ch.DStateWarning, ch.DStateCritical)
+	totalStatus := evaluateCounter(float64(total), ch.TotalWarning, ch.TotalCritical)
+	status := max(zombieStatus, dstateStatus, totalStatus)
+
+	stat := statResp.GetMessages()[0]
+	perfData := []output.PerfDatum{
+		{Label: "processes_total", Value: float64(total), Warn: optionalString(ch.TotalWarning), Crit: optionalString(ch.TotalCritical), Min: "0"},
+		{Label: "processes_zombie", Value: float64(zombieCount), Warn: ch.Warning.String(), Crit: ch.Critical.String(), Min: "0"},
+		{Label: "processes_dstate", Value: float64(dstateCount), Warn: optionalString(ch.DStateWarning), Crit: optionalString(ch.DStateCritical), Min: "0"},
+		{Label: "processes_running", Value: float64(stat.GetProcessRunning()), Min: "0"},
+		{Label: "processes_blocked", Value: float64(stat.GetProcessBlocked()), Min: "0"},
+		{Label: "processes_created", Value: float64(stat.GetProcessCreated()), UOM: "c", Min: "0"},
+	}
+
+	if status == output.OK {
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("%d processes, %d zombie, %d in D state", total, zombieCount, dstateCount),
+			PerfData:  perfData,
+		}, nil
+	}
+
+	var problems []string
+	var details strings.Builder
+	writeGroups := func(kind string, groups map[string]*processGroup, st output.Status) {
+		for _, g := range topProcessGroups(groups) {
+			if details.Len() > 0 {
+				details.WriteByte('\n')
+			}
+			fmt.Fprintf(&details, "%s %s: %d processes", kind, g.command, g.count)
+			if len(g.parents) > 0 {
+				fmt.Fprintf(&details, ", parents %s", strings.Join(g.parents, ", "))
+			}
+			fmt.Fprintf(&details, ", status=%s", st)
+		}
+	}
+	if zombieStatus != output.OK {
+		problems = append(problems, fmt.Sprintf("%d zombie (%s)", zombieCount, describeProcessGroups(zombies)))
+		writeGroups("zombie", zombies, zombieStatus)
+	}
+	if dstateStatus != output.OK {
+		problems = append(problems, fmt.Sprintf("%d in D state (%s)", dstateCount, describeProcessGroups(dstate)))
+		writeGroups("D state", dstate, dstateStatus)
+	}
+	if totalStatus != output.OK {
+		problems = append(problems, fmt.Sprintf("%d processes", total))
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary:   "Process counts above threshold: " + strings.Join(problems, ", "),
+		Details:   details.String(),
+		PerfData:  perfData,
+	}, nil
+}
+
+// processGroup counts processes that share a command name.
+type processGroup struct {
+	command string
+	count   int
+	parents []string // distinct parent command names, sorted
+}
+
+// addProcess adds one process with the given command and parent command to
+// groups. An empty parent is not recorded.
+func addProcess(groups map[string]*processGroup, command, parent string) {
+	if command == "" {
+		command = "<unknown>"
+	}
+	g, ok := groups[command]
+	if !ok {
+		g = &processGroup{command: command}
+		groups[command] = g
+	}
+	g.count++
+	if parent != "" && !containsString(g.parents, parent) {
+		g.parents = append(g.parents, parent)
+		sort.Strings(g.parents)
+	}
+}
+
+// topProcessGroups returns up to processTopN groups, largest first, ties
+// broken by command name.
+func topProcessGroups(groups map[string]*processGroup) []*processGroup {
+	sorted := make([]*processGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].command < sorted[j].command
+	})
+	if len(sorted) > processTopN {
+		sorted = sorted[:processTopN]
+	}
+	return sorted
+}
+
+// describeProcessGroups returns "<command> <count>, ..." for the top groups,
+// or "none" when there are no processes in the state.
+func describeProcessGroups(groups map[string]*processGroup) string {
+	top := topProcessGroups(groups)
+	if len(top) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(top))
+	for i, g := range top {
+		parts[i] = fmt.Sprintf("%s %d", g.command, g.count)
+	}
+	return strings.Join(parts, ", ")
+}
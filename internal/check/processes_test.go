@@ -0,0 +1,308 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockProcessesClient implements TalosClient for Processes check testing.
+type mockProcessesClient struct {
+	stubClient
+
+	statResp *machine.SystemStatResponse
+	statErr  error
+	procResp *machine.ProcessesResponse
+	procErr  error
+}
+
+func (m *mockProcessesClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
+	return m.statResp, m.statErr
+}
+
+func (m *mockProcessesClient) Processes(context.Context) (*machine.ProcessesResponse, error) {
+	return m.procResp, m.procErr
+}
+
+// makeProcessStatResponse builds a SystemStatResponse with process counters.
+func makeProcessStatResponse(running, blocked, created uint64) *machine.SystemStatResponse {
+	return &machine.SystemStatResponse{
+		Messages: []*machine.SystemStat{{
+			ProcessRunning: running,
+			ProcessBlocked: blocked,
+			ProcessCreated: created,
+		}},
+	}
+}
+
+// makeProcessesResponse builds a ProcessesResponse from the given processes.
+func makeProcessesResponse(procs ...*machine.ProcessInfo) *machine.ProcessesResponse {
+	return &machine.ProcessesResponse{
+		Messages: []*machine.Process{{Processes: procs}},
+	}
+}
+
+// baseProcesses returns a small healthy process tree: init, containerd,
+// a shim and kubelet, all sleeping.
+func baseProcesses() []*machine.ProcessInfo {
+	return []*machine.ProcessInfo{
+		{Pid: 1, Ppid: 0, State: "S", Command: "init"},
+		{Pid: 100, Ppid: 1, State: "S", Command: "containerd"},
+		{Pid: 200, Ppid: 1, State: "S", Command: "containerd-shim"},
+		{Pid: 300, Ppid: 1, State: "R", Command: "kubelet"},
+	}
+}
+
+// withState appends n processes in state with the given command and parent.
+func withState(procs []*machine.ProcessInfo, n int, state, command string, ppid int32) []*machine.ProcessInfo {
+	next := int32(1000 + len(procs))
+	for i := 0; i < n; i++ {
+		procs = append(procs, &machine.ProcessInfo{Pid: next + int32(i), Ppid: ppid, State: state, Command: command})
+	}
+	return procs
+}
+
+func TestNewProcessesCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		w, c    string
+		opts    ProcessesOptions
+		wantErr bool
+	}{
+		{name: "valid defaults", w: "5", c: "20", wantErr: false},
+		{name: "valid with optional", w: "5", c: "20", opts: ProcessesOptions{DStateWarning: "10", TotalCritical: "2000"}, wantErr: false},
+		{name: "invalid warning", w: "abc", c: "20", wantErr: true},
+		{name: "invalid critical", w: "5", c: "abc", wantErr: true},
+		{name: "invalid D-state threshold", w: "5", c: "20", opts: ProcessesOptions{DStateCritical: "x"}, wantErr: true},
+		{name: "invalid total threshold", w: "5", c: "20", opts: ProcessesOptions{TotalWarning: "10:5"}, wantErr: true},
+		{name: "size suffix rejected", w: "1kB", c: "20", wantErr: true},
+		{name: "D-state duration suffix rejected", w: "5", c: "20", opts: ProcessesOptions{DStateWarning: "1m"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewProcessesCheck(tt.w, tt.c, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "PROCESSES" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "PROCESSES")
+			}
+			if (tt.opts.DStateWarning != "") != (ch.DStateWarning != nil) {
+				t.Errorf("DStateWarning set = %v, want %v", ch.DStateWarning != nil, tt.opts.DStateWarning != "")
+			}
+			if ch.TotalWarning != nil {
+				t.Error("TotalWarning should be nil when not provided")
+			}
+		})
+	}
+}
+
+func TestProcessesCheckRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        ProcessesOptions
+		procs       []*machine.ProcessInfo
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - no zombies",
+			procs:       withState(baseProcesses(), 1, "D", "jbd2", 1),
+			wantStatus:  output.OK,
+			wantSummary: "5 processes, 0 zombie, 1 in D state",
+		},
+		{
+			name:        "WARNING - zombies from one parent",
+			procs:       withState(baseProcesses(), 6, "Z", "runc", 200),
+			wantStatus:  output.Warning,
+			wantSummary: "Process counts above threshold: 6 zombie (runc 6)",
+			wantDetails: "zombie runc: 6 processes, parents containerd-shim, status=WARNING",
+		},
+		{
+			name: "CRITICAL - zombies grouped by command",
+			procs: withState(withState(withState(baseProcesses(),
+				15, "Z", "runc", 200), 7, "Z", "sh", 300), 1, "Z", "sh", 100),
+			wantStatus:  output.Critical,
+			wantSummary: "Process counts above threshold: 23 zombie (runc 15, sh 8)",
+			wantDetails: "zombie runc: 15 processes, parents containerd-shim, status=CRITICAL\n" +
+				"zombie sh: 8 processes, parents containerd, kubelet, status=CRITICAL",
+		},
+		{
+			name:        "OK - D state not evaluated by default",
+			procs:       withState(baseProcesses(), 50, "D", "nfsd", 1),
+			wantStatus:  output.OK,
+			wantSummary: "54 processes, 0 zombie, 50 in D state",
+		},
+		{
+			name:        "WARNING - D state threshold",
+			opts:        ProcessesOptions{DStateWarning: "10", DStateCritical: "100"},
+			procs:       withState(baseProcesses(), 12, "D", "nfsd", 1),
+			wantStatus:  output.Warning,
+			wantSummary: "Process counts above threshold: 12 in D state (nfsd 12)",
+			wantDetails: "D state nfsd: 12 processes, status=WARNING",
+		},
+		{
+			name:        "CRITICAL - total threshold",
+			opts:        ProcessesOptions{TotalWarning: "2", TotalCritical: "3"},
+			procs:       baseProcesses(),
+			wantStatus:  output.Critical,
+			wantSummary: "Process counts above threshold: 4 processes",
+		},
+		{
+			name:        "UNKNOWN - empty process list",
+			procs:       nil,
+			wantStatus:  output.Unknown,
+			wantSummary: "No processes in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewProcessesCheck("5", "20", tt.opts)
+			if err != nil {
+				t.Fatalf("NewProcessesCheck: %v", err)
+			}
+
+			client := &mockProcessesClient{
+				statResp: makeProcessStatResponse(2, 0, 12345),
+				procResp: makeProcessesResponse(tt.procs...),
+			}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "PROCESSES" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "PROCESSES")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestProcessesCheckTopN(t *testing.T) {
+	procs := baseProcesses()
+	for i := 0; i < processTopN+2; i++ {
+		procs = withState(procs, i+1, "Z", fmt.Sprintf("cmd%d", i), 1)
+	}
+
+	ch, err := NewProcessesCheck("5", "20", ProcessesOptions{})
+	if err != nil {
+		t.Fatalf("NewProcessesCheck: %v", err)
+	}
+	result, err := ch.Run(context.Background(), &mockProcessesClient{
+		statResp: makeProcessStatResponse(1, 0, 1),
+		procResp: makeProcessesResponse(procs...),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "Process counts above threshold: 28 zombie (cmd6 7, cmd5 6, cmd4 5, cmd3 4, cmd2 3)"
+	if result.Summary != want {
+		t.Errorf("summary = %q, want %q", result.Summary, want)
+	}
+	if contains(result.Details, "cmd1") {
+		t.Errorf("details should list only the top %d commands: %q", processTopN, result.Details)
+	}
+}
+
+func TestProcessesCheckPerfData(t *testing.T) {
+	ch, err := NewProcessesCheck("5", "20", ProcessesOptions{TotalWarning: "1500", TotalCritical: "3000"})
+	if err != nil {
+		t.Fatalf("NewProcessesCheck: %v", err)
+	}
+
+	procs := withState(withState(baseProcesses(), 2, "Z", "runc", 200), 1, "D", "jbd2", 1)
+	result, err := ch.Run(context.Background(), &mockProcessesClient{
+		statResp: makeProcessStatResponse(3, 1, 98765),
+		procResp: makeProcessesResponse(procs...),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "processes_total=7;1500;3000;0; processes_zombie=2;5;20;0; processes_dstate=1;;;0; " +
+		"processes_running=3;;;0; processes_blocked=1;;;0; processes_created=98765c;;;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata:\n  got:  %q\n  want: %q", got, want)
+	}
+}
+
+func TestProcessesCheckErrors(t *testing.T) {
+	ch, err := NewProcessesCheck("5", "20", ProcessesOptions{})
+	if err != nil {
+		t.Fatalf("NewProcessesCheck: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		client     *mockProcessesClient
+		wantErr    bool
+		wantStatus output.Status
+	}{
+		{
+			name:    "SystemStat error",
+			client:  &mockProcessesClient{statErr: fmt.Errorf("connection refused")},
+			wantErr: true,
+		},
+		{
+			name: "Processes error",
+			client: &mockProcessesClient{
+				statResp: makeProcessStatResponse(1, 0, 1),
+				procErr:  fmt.Errorf("connection refused"),
+			},
+			wantErr: true,
+		},
+		{
+			name:       "empty SystemStat response",
+			client:     &mockProcessesClient{statResp: &machine.SystemStatResponse{}},
+			wantStatus: output.Unknown,
+		},
+		{
+			name: "empty Processes response",
+			client: &mockProcessesClient{
+				statResp: makeProcessStatResponse(1, 0, 1),
+				procResp: &machine.ProcessesResponse{},
+			},
+			wantStatus: output.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ch.Run(context.Background(), tt.client)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package check
+
+import (
+	"context"
+	"time"
+)
+
+// waitSampleInterval blocks for d between the two samples of a delta-based
+// check. It returns false without waiting when the context deadline leaves
+// no room for d plus the second API call, and returns the context error if
+// the context ends while waiting.
+func waitSampleInterval(ctx context.Context, d time.Duration) (bool, error) {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		return false, nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-timer.C:
+		return true, nil
+	}
+}
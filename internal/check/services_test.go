@@ -11,42 +11,16 @@ import (
 
 // mockServicesClient implements TalosClient for Services check testing.
 type mockServicesClient struct {
+	stubClient
+
 	resp *machine.ServiceListResponse
 	err  error
 }
 
-func (m *mockServicesClient) SystemStat(context.Context) (*machine.SystemStatResponse, error) {
-	return nil, nil
-}
-
-func (m *mockServicesClient) Memory(context.Context) (*machine.MemoryResponse, error) {
-	return nil, nil
-}
-
-func (m *mockServicesClient) Mounts(context.Context) (*machine.MountsResponse, error) {
-	return nil, nil
-}
-
 func (m *mockServicesClient) ServiceList(_ context.Context) (*machine.ServiceListResponse, error) {
 	return m.resp, m.err
 }
 
-func (m *mockServicesClient) EtcdStatus(context.Context) (*machine.EtcdStatusResponse, error) {
-	return nil, nil
-}
-
-func (m *mockServicesClient) EtcdMemberList(context.Context) (*machine.EtcdMemberListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockServicesClient) EtcdAlarmList(context.Context) (*machine.EtcdAlarmListResponse, error) {
-	return nil, nil
-}
-
-func (m *mockServicesClient) LoadAvg(context.Context) (*machine.LoadAvgResponse, error) {
-	return nil, nil
-}
-
 func TestNewServicesCheck(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,104 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/threshold"
+)
+
+// UptimeCheck detects recent (possibly unexpected) reboots by comparing the
+// node's boot time from the Talos SystemStat API with the current time.
+// Thresholds are typically inverted ranges such as "@0:600", which alert
+// while the uptime is still inside the window after a reboot.
+type UptimeCheck struct {
+	Warning  threshold.Threshold
+	Critical threshold.Threshold
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+// NewUptimeCheck creates an UptimeCheck from warning and critical threshold
+// strings expressed in seconds of uptime or with duration suffixes ("@0:10m").
+func NewUptimeCheck(w, c string) (*UptimeCheck, error) {
+	wt, err := threshold.ParseUnit(w, threshold.UnitSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warning threshold: %w", err)
+	}
+
+	ct, err := threshold.ParseUnit(c, threshold.UnitSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid critical threshold: %w", err)
+	}
+
+	return &UptimeCheck{Warning: wt, Critical: ct, now: time.Now}, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *UptimeCheck) Name() string { return "UPTIME" }
+
+// Run executes the uptime check against the Talos API.
+func (ch *UptimeCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	resp, err := client.SystemStat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	bootTime := resp.GetMessages()[0].GetBootTime()
+	if bootTime == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Invalid data: boot time is zero",
+		}, nil
+	}
+
+	booted := time.Unix(int64(bootTime), 0)
+	uptime := ch.now().Sub(booted)
+	if uptime < 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   fmt.Sprintf("Invalid data: boot time %s is in the future (clock skew?)", booted.UTC().Format(time.RFC3339)),
+		}, nil
+	}
+
+	seconds := float64(uptime / time.Second)
+
+	status := output.OK
+	if ch.Critical.Violated(seconds) {
+		status = output.Critical
+	} else if ch.Warning.Violated(seconds) {
+		status = output.Warning
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary: fmt.Sprintf("Uptime %s (booted %s)%s",
+			output.HumanDuration(uptime), booted.UTC().Format(time.RFC3339),
+			thresholdNote(status, ch.Warning, ch.Critical)),
+		PerfData: []output.PerfDatum{
+			{
+				Label: "uptime",
+				Value: seconds,
+				UOM:   "s",
+				Warn:  ch.Warning.String(),
+				Crit:  ch.Critical.String(),
+				Min:   "0",
+				Max:   "",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,197 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+// mockUptimeClient implements TalosClient for Uptime check testing.
+type mockUptimeClient struct {
+	stubClient
+
+	resp *machine.SystemStatResponse
+	err  error
+}
+
+func (m *mockUptimeClient) SystemStat(_ context.Context) (*machine.SystemStatResponse, error) {
+	return m.resp, m.err
+}
+
+// uptimeNow is the fixed "current time" used by uptime tests.
+var uptimeNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestNewUptimeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		warn    string
+		crit    string
+		wantErr bool
+	}{
+		{name: "valid defaults", warn: "@0:3600", crit: "@0:600", wantErr: false},
+		{name: "valid plain ranges", warn: "3600:", crit: "600:", wantErr: false},
+		{name: "valid duration suffixes", warn: "@0:1h", crit: "@0:10m", wantErr: false},
+		{name: "size suffix rejected", warn: "@0:1GB", crit: "@0:10m", wantErr: true},
+		{name: "invalid warning", warn: "abc", crit: "@0:600", wantErr: true},
+		{name: "invalid critical", warn: "@0:3600", crit: "xyz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewUptimeCheck(tt.warn, tt.crit)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "UPTIME" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "UPTIME")
+			}
+		})
+	}
+}
+
+func TestUptimeCheckRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     *mockUptimeClient
+		wantStatus output.Status
+		wantSubstr string
+	}{
+		{
+			name:       "OK - long uptime",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(76*time.Hour + 59*time.Minute)},
+			wantStatus: output.OK,
+			wantSubstr: "Uptime 3d 4h (booted 2026-02-26T07:01:00Z)",
+		},
+		{
+			name:       "WARNING - rebooted within the hour",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(42 * time.Minute)},
+			wantStatus: output.Warning,
+			wantSubstr: "Uptime 42m 0s (booted 2026-03-01T11:18:00Z), warning threshold @0:1h",
+		},
+		{
+			name:       "CRITICAL - rebooted within ten minutes",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(95 * time.Second)},
+			wantStatus: output.Critical,
+			wantSubstr: "Uptime 1m 35s (booted 2026-03-01T11:58:25Z), critical threshold @0:10m",
+		},
+		{
+			name:       "CRITICAL - boundary at 600s is inside the range",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(600 * time.Second)},
+			wantStatus: output.Critical,
+			wantSubstr: "Uptime 10m 0s",
+		},
+		{
+			name:       "WARNING - just past critical window",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(601 * time.Second)},
+			wantStatus: output.Warning,
+			wantSubstr: "Uptime 10m 1s",
+		},
+		{
+			name:       "UNKNOWN - nil response",
+			client:     &mockUptimeClient{resp: nil},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name:       "UNKNOWN - empty messages",
+			client:     &mockUptimeClient{resp: &machine.SystemStatResponse{}},
+			wantStatus: output.Unknown,
+			wantSubstr: "Empty response from Talos API",
+		},
+		{
+			name: "UNKNOWN - zero boot time",
+			client: &mockUptimeClient{resp: &machine.SystemStatResponse{
+				Messages: []*machine.SystemStat{{}},
+			}},
+			wantStatus: output.Unknown,
+			wantSubstr: "boot time is zero",
+		},
+		{
+			name:       "UNKNOWN - boot time in the future",
+			client:     &mockUptimeClient{resp: makeUptimeResponse(-time.Minute)},
+			wantStatus: output.Unknown,
+			wantSubstr: "is in the future",
+		},
+		{
+			name:       "error from client",
+			client:     &mockUptimeClient{err: fmt.Errorf("connection refused")},
+			wantStatus: -1, // not checked; error path
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewUptimeCheck("@0:3600", "@0:600")
+			if err != nil {
+				t.Fatalf("NewUptimeCheck: %v", err)
+			}
+			ch.now = func() time.Time { return uptimeNow }
+
+			result, err := ch.Run(context.Background(), tt.client)
+
+			// Error path: client returns error.
+			if tt.client.err != nil {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+
+			if result.CheckName != "UPTIME" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "UPTIME")
+			}
+
+			if !contains(result.String(), tt.wantSubstr) {
+				t.Errorf("output %q does not contain %q", result.String(), tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestUptimeCheckPerfData(t *testing.T) {
+	ch, err := NewUptimeCheck("@0:3600", "@0:600")
+	if err != nil {
+		t.Fatalf("NewUptimeCheck: %v", err)
+	}
+	ch.now = func() time.Time { return uptimeNow }
+
+	result, err := ch.Run(context.Background(), &mockUptimeClient{
+		resp: makeUptimeResponse(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "uptime=7200s;@0:3600;@0:600;0;"
+	if got := output.FormatPerfData(result.PerfData); got != want {
+		t.Errorf("perfdata = %q, want %q", got, want)
+	}
+}
+
+// makeUptimeResponse builds a SystemStatResponse whose boot time lies the
+// given uptime before uptimeNow.
+func makeUptimeResponse(uptime time.Duration) *machine.SystemStatResponse {
+	return &machine.SystemStatResponse{
+		Messages: []*machine.SystemStat{
+			{BootTime: uint64(uptimeNow.Add(-uptime).Unix())},
+		},
+	}
+}
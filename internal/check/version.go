@@ -0,0 +1,326 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/DLAKE-IO/check-talos/internal/semver"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/talos/pkg/machinery/resources/k8s"
+)
+
+// kernelReleasePath is the running kernel release read through the Talos
+// Read API; the Version API does not report the kernel.
+const kernelReleasePath = "/proc/sys/kernel/osrelease"
+
+// controlPlaneStaticPods are the static pods Talos runs on control plane
+// nodes, in reporting order. Workers have none of them.
+var controlPlaneStaticPods = []string{k8s.APIServerID, k8s.ControllerManagerID, k8s.SchedulerID}
+
+// VersionCheck compares the node's Talos version, and optionally its kernel
+// release and Kubernetes components, against expected versions via the
+// Talos Version API and COSI resources.
+//
+// A Talos tag that does not satisfy Expect is WARNING (drift, e.g. a node
+// skipped during a rolling upgrade); a tag lower than Min is CRITICAL. A
+// kernel release that does not satisfy ExpectKernel is WARNING, as is a
+// kubelet or control plane static pod whose image tag does not satisfy
+// ExpectKubernetes. All are optional; with none set the check only reports
+// the Talos version.
+type VersionCheck struct {
+	Expect           *semver.Constraint // nil = not evaluated
+	Min              *semver.Version    // nil = not evaluated
+	ExpectKernel     *semver.Constraint // nil = kernel not read
+	ExpectKubernetes *semver.Constraint // nil = Kubernetes components not read
+}
+
+// NewVersionCheck creates a VersionCheck from an expected Talos version
+// constraint, a minimum Talos version, an expected kernel constraint and an
+// expected Kubernetes constraint. Empty strings leave the respective
+// comparison unevaluated.
+func NewVersionCheck(expect, minimum, expectKernel, expectKubernetes string) (*VersionCheck, error) {
+	ch := &VersionCheck{}
+
+	if expect != "" {
+		c, err := semver.ParseConstraint(expect)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected version: %w", err)
+		}
+		ch.Expect = &c
+	}
+
+	if minimum != "" {
+		v, err := semver.Parse(minimum)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum version: %w", err)
+		}
+		ch.Min = &v
+	}
+
+	if expectKernel != "" {
+		c, err := semver.ParseConstraint(expectKernel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected kernel version: %w", err)
+		}
+		ch.ExpectKernel = &c
+	}
+
+	if expectKubernetes != "" {
+		c, err := semver.ParseConstraint(expectKubernetes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected Kubernetes version: %w", err)
+		}
+		ch.ExpectKubernetes = &c
+	}
+
+	return ch, nil
+}
+
+// Name returns the check identifier used in Nagios output.
+func (ch *VersionCheck) Name() string { return "VERSION" }
+
+// Run executes the version check against the Talos API.
+func (ch *VersionCheck) Run(ctx context.Context, client TalosClient) (*output.Result, error) {
+	resp, err := client.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || len(resp.GetMessages()) == 0 {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "Empty response from Talos API",
+		}, nil
+	}
+
+	msg := resp.GetMessages()[0]
+	info := msg.GetVersion()
+	tag := info.GetTag()
+	if tag == "" {
+		return &output.Result{
+			Status:    output.Unknown,
+			CheckName: ch.Name(),
+			Summary:   "No version tag in response",
+		}, nil
+	}
+
+	status := output.OK
+	var problems []string
+	var expected []string
+
+	if ch.Expect != nil || ch.Min != nil {
+		v, err := semver.Parse(tag)
+		if err != nil {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("Cannot parse Talos version %q", tag),
+			}, nil
+		}
+
+		switch {
+		case ch.Min != nil && v.Compare(*ch.Min) < 0:
+			status = output.Critical
+			problems = append(problems, fmt.Sprintf("Talos %s below minimum %s", tag, ch.Min))
+		case ch.Expect != nil && !ch.Expect.Check(v):
+			status = output.Warning
+			problems = append(problems, fmt.Sprintf("Talos %s does not satisfy %s", tag, ch.Expect))
+		}
+
+		if ch.Expect != nil {
+			expected = append(expected, "expect "+ch.Expect.String())
+		}
+		if ch.Min != nil {
+			expected = append(expected, "min "+ch.Min.String())
+		}
+	}
+
+	current := "Talos " + tag
+	if ch.ExpectKernel != nil {
+		data, err := client.Read(ctx, kernelReleasePath)
+		if err != nil {
+			return nil, err
+		}
+
+		release := strings.TrimSpace(string(data))
+		kv, err := parseKernelRelease(release)
+		if err != nil {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   fmt.Sprintf("Cannot parse kernel release %q", release),
+			}, nil
+		}
+
+		if !ch.ExpectKernel.Check(kv) {
+			status = max(status, output.Warning)
+			problems = append(problems, fmt.Sprintf("kernel %s does not satisfy %s", release, ch.ExpectKernel))
+		}
+		current += ", kernel " + release
+		expected = append(expected, "kernel "+ch.ExpectKernel.String())
+	}
+
+	var componentDetails []string
+	if ch.ExpectKubernetes != nil {
+		components, unknown, err := kubernetesComponents(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		if unknown != "" {
+			return &output.Result{
+				Status:    output.Unknown,
+				CheckName: ch.Name(),
+				Summary:   unknown,
+			}, nil
+		}
+
+		tags := make([]string, len(components))
+		for i, c := range components {
+			tags[i] = c.name + " " + c.tag
+			componentDetails = append(componentDetails, fmt.Sprintf("%s: %s", c.name, c.image))
+			if !ch.ExpectKubernetes.Check(c.version) {
+				status = max(status, output.Warning)
+				problems = append(problems, fmt.Sprintf("%s %s does not satisfy %s", c.name, c.tag, ch.ExpectKubernetes))
+			}
+		}
+		if sameComponentTag(components) {
+			current += ", Kubernetes " + components[0].tag
+		} else {
+			current += ", Kubernetes " + strings.Join(tags, ", ")
+		}
+		expected = append(expected, "kubernetes "+ch.ExpectKubernetes.String())
+	}
+
+	if status == output.OK {
+		summary := current
+		if len(expected) > 0 {
+			summary += " (" + strings.Join(expected, ", ") + ")"
+		}
+		return &output.Result{
+			Status:    output.OK,
+			CheckName: ch.Name(),
+			Summary:   summary,
+		}, nil
+	}
+
+	details := fmt.Sprintf("%s: sha %s, built %s, %s, %s/%s, platform %s (mode %s)",
+		tag, info.GetSha(), info.GetBuilt(), info.GetGoVersion(), info.GetOs(), info.GetArch(),
+		msg.GetPlatform().GetName(), msg.GetPlatform().GetMode())
+	if len(componentDetails) > 0 {
+		details += "\n" + strings.Join(componentDetails, "\n")
+	}
+
+	return &output.Result{
+		Status:    status,
+		CheckName: ch.Name(),
+		Summary:   strings.Join(problems, ", "),
+		Details:   details,
+	}, nil
+}
+
+// kubernetesComponent is one Kubernetes component running on the node.
+type kubernetesComponent struct {
+	name    string // kubelet, kube-apiserver, ...
+	image   string
+	tag     string // image tag, e.g. "v1.34.1"
+	version semver.Version
+}
+
+// kubernetesComponents reads the kubelet spec and, on control plane nodes,
+// the kube-apiserver, kube-controller-manager and kube-scheduler static pod
+// specs, and parses the version from each image tag. It returns a non-empty
+// unknown message when the kubelet is not configured or an image has no
+// version tag.
+func kubernetesComponents(ctx context.Context, client TalosClient) ([]kubernetesComponent, string, error) {
+	res, err := client.GetResource(ctx, resource.NewMetadata(
+		k8s.NamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, "No kubelet spec (kubelet not configured?)", nil
+		}
+		return nil, "", err
+	}
+	kubelet, ok := res.(*k8s.KubeletSpec)
+	if !ok {
+		return nil, fmt.Sprintf("Unexpected resource type %T for kubelet spec", res), nil
+	}
+
+	images := [][2]string{{"kubelet", kubelet.TypedSpec().Image}}
+	for _, id := range controlPlaneStaticPods {
+		res, err := client.GetResource(ctx, resource.NewMetadata(
+			k8s.NamespaceName, k8s.StaticPodType, id, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+			return nil, "", err
+		}
+		pod, ok := res.(*k8s.StaticPod)
+		if !ok {
+			return nil, fmt.Sprintf("Unexpected resource type %T for static pod %s", res, id), nil
+		}
+		images = append(images, [2]string{id, staticPodImage(pod.TypedSpec().Pod)})
+	}
+
+	components := make([]kubernetesComponent, 0, len(images))
+	for _, img := range images {
+		tag := imageTag(img[1])
+		v, err := semver.Parse(tag)
+		if tag == "" || err != nil {
+			return nil, fmt.Sprintf("Cannot parse %s version from image %q", img[0], img[1]), nil
+		}
+		components = append(components, kubernetesComponent{name: img[0], image: img[1], tag: tag, version: v})
+	}
+	return components, "", nil
+}
+
+// staticPodImage returns the image of the first container in an
+// unstructured pod manifest, or "" when there is none.
+func staticPodImage(pod map[string]any) string {
+	spec, _ := pod["spec"].(map[string]any)
+	containers, _ := spec["containers"].([]any)
+	if len(containers) == 0 {
+		return ""
+	}
+	container, _ := containers[0].(map[string]any)
+	image, _ := container["image"].(string)
+	return image
+}
+
+// imageTag returns the tag of an image reference such as
+// "registry.k8s.io/kube-apiserver:v1.34.1@sha256:...", or "" when the
+// reference has none. A registry port ("host:5000/kubelet") is not a tag.
+func imageTag(image string) string {
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndexByte(image, ':')
+	if i < 0 || strings.ContainsRune(image[i:], '/') {
+		return ""
+	}
+	return image[i+1:]
+}
+
+// sameComponentTag reports whether all components run the same image tag.
+func sameComponentTag(components []kubernetesComponent) bool {
+	for _, c := range components[1:] {
+		if c.tag != components[0].tag {
+			return false
+		}
+	}
+	return true
+}
+
+// parseKernelRelease parses the numeric part of a kernel release such as
+// "6.12.57-talos". The local version after "-" or "+" names the build, not
+// a pre-release, so it is dropped before comparison.
+func parseKernelRelease(release string) (semver.Version, error) {
+	if i := strings.IndexAny(release, "-+"); i >= 0 {
+		release = release[:i]
+	}
+	return semver.Parse(release)
+}
@@ -0,0 +1,425 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DLAKE-IO/check-talos/internal/output"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/resources/k8s"
+)
+
+// mockVersionClient implements TalosClient for Version check testing.
+type mockVersionClient struct {
+	stubClient
+
+	resp      *machine.VersionResponse
+	err       error
+	kernel    string
+	readErr   error
+	readPath  string
+	resources map[string]resource.Resource // keyed by "<type>/<id>"
+}
+
+func (m *mockVersionClient) Read(_ context.Context, path string) ([]byte, error) {
+	m.readPath = path
+	return []byte(m.kernel), m.readErr
+}
+
+func (m *mockVersionClient) Version(context.Context) (*machine.VersionResponse, error) {
+	return m.resp, m.err
+}
+
+func (m *mockVersionClient) GetResource(_ context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	r, ok := m.resources[ptr.Type()+"/"+ptr.ID()]
+	if !ok {
+		return nil, inmem.ErrNotFound(ptr)
+	}
+	return r, nil
+}
+
+// kubeletSpec returns a KubeletSpec resource running image.
+func kubeletSpec(image string) *k8s.KubeletSpec {
+	r := k8s.NewKubeletSpec(k8s.NamespaceName, k8s.KubeletID)
+	r.TypedSpec().Image = image
+	return r
+}
+
+// staticPod returns a StaticPod resource whose single container runs image.
+func staticPod(id, image string) *k8s.StaticPod {
+	r := k8s.NewStaticPod(k8s.NamespaceName, id)
+	r.TypedSpec().Pod = map[string]any{
+		"spec": map[string]any{
+			"containers": []any{map[string]any{"name": id, "image": image}},
+		},
+	}
+	return r
+}
+
+// controlPlaneComponents returns the kubelet and control plane static pods
+// at version, with overrides replacing the image of individual components.
+func controlPlaneComponents(version string, overrides map[string]string) []resource.Resource {
+	image := func(id, def string) string {
+		if img, ok := overrides[id]; ok {
+			return img
+		}
+		return def
+	}
+	rs := []resource.Resource{kubeletSpec(image("kubelet", "ghcr.io/siderolabs/kubelet:"+version))}
+	for _, id := range controlPlaneStaticPods {
+		rs = append(rs, staticPod(id, image(id, "registry.k8s.io/"+id+":"+version)))
+	}
+	return rs
+}
+
+// makeVersionResponse builds a VersionResponse for the given Talos tag.
+func makeVersionResponse(tag string) *machine.VersionResponse {
+	return &machine.VersionResponse{
+		Messages: []*machine.Version{{
+			Version: &machine.VersionInfo{
+				Tag:       tag,
+				Sha:       "4f6d4b2",
+				Built:     "2025-11-20T10:00:00Z",
+				GoVersion: "go1.24.9",
+				Os:        "linux",
+				Arch:      "amd64",
+			},
+			Platform: &machine.PlatformInfo{Name: "metal", Mode: "metal"},
+		}},
+	}
+}
+
+func TestNewVersionCheck(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expect, min, kernel  string
+		wantErr              bool
+		wantExpect, wantMin  bool
+		wantKernelConstraint bool
+	}{
+		{name: "nothing set", wantErr: false},
+		{name: "all set", expect: ">=1.11.0 <1.12", min: "1.10.0", kernel: ">=6.12", wantExpect: true, wantMin: true, wantKernelConstraint: true},
+		{name: "min only", min: "v1.10", wantMin: true},
+		{name: "invalid expect", expect: ">=abc", wantErr: true},
+		{name: "invalid min", min: ">=1.10", wantErr: true},
+		{name: "invalid kernel", kernel: "~6", wantErr: true},
+	}
+
+	if _, err := NewVersionCheck("", "", "", ">=1.x"); err == nil {
+		t.Error("expected error for invalid Kubernetes constraint, got nil")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewVersionCheck(tt.expect, tt.min, tt.kernel, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ch.Name() != "VERSION" {
+				t.Errorf("Name() = %q, want %q", ch.Name(), "VERSION")
+			}
+			if (ch.Expect != nil) != tt.wantExpect {
+				t.Errorf("Expect set = %v, want %v", ch.Expect != nil, tt.wantExpect)
+			}
+			if (ch.Min != nil) != tt.wantMin {
+				t.Errorf("Min set = %v, want %v", ch.Min != nil, tt.wantMin)
+			}
+			if (ch.ExpectKernel != nil) != tt.wantKernelConstraint {
+				t.Errorf("ExpectKernel set = %v, want %v", ch.ExpectKernel != nil, tt.wantKernelConstraint)
+			}
+		})
+	}
+}
+
+func TestVersionCheckRun(t *testing.T) {
+	const details = "%s: sha 4f6d4b2, built 2025-11-20T10:00:00Z, go1.24.9, linux/amd64, platform metal (mode metal)"
+
+	tests := []struct {
+		name                string
+		expect, min, kernel string
+		tag                 string
+		release             string
+		wantStatus          output.Status
+		wantSummary         string
+		wantDetails         string
+	}{
+		{
+			name:        "OK - report only",
+			tag:         "v1.11.6",
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6",
+		},
+		{
+			name:        "OK - satisfies constraint and floor",
+			expect:      ">=1.11.0 <1.12",
+			min:         "1.10.0",
+			tag:         "v1.11.6",
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6 (expect >=1.11.0 <1.12, min 1.10.0)",
+		},
+		{
+			name:        "WARNING - skipped during upgrade",
+			expect:      ">=1.11.0 <1.12",
+			min:         "1.10.0",
+			tag:         "v1.10.7",
+			wantStatus:  output.Warning,
+			wantSummary: "Talos v1.10.7 does not satisfy >=1.11.0 <1.12",
+			wantDetails: fmt.Sprintf(details, "v1.10.7"),
+		},
+		{
+			name:        "WARNING - ahead of expected pin",
+			expect:      "1.11.5",
+			tag:         "v1.11.6",
+			wantStatus:  output.Warning,
+			wantSummary: "Talos v1.11.6 does not satisfy 1.11.5",
+			wantDetails: fmt.Sprintf(details, "v1.11.6"),
+		},
+		{
+			name:        "CRITICAL - below floor",
+			expect:      ">=1.11.0 <1.12",
+			min:         "1.10.0",
+			tag:         "v1.9.5",
+			wantStatus:  output.Critical,
+			wantSummary: "Talos v1.9.5 below minimum 1.10.0",
+			wantDetails: fmt.Sprintf(details, "v1.9.5"),
+		},
+		{
+			name:        "CRITICAL - pre-release of the floor",
+			min:         "1.12.0",
+			tag:         "v1.12.0-beta.1",
+			wantStatus:  output.Critical,
+			wantSummary: "Talos v1.12.0-beta.1 below minimum 1.12.0",
+			wantDetails: fmt.Sprintf(details, "v1.12.0-beta.1"),
+		},
+		{
+			name:        "OK - kernel matches",
+			kernel:      ">=6.12",
+			tag:         "v1.11.6",
+			release:     "6.12.57-talos\n",
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6, kernel 6.12.57-talos (kernel >=6.12)",
+		},
+		{
+			name:        "WARNING - kernel drift",
+			expect:      ">=1.11.0 <1.12",
+			kernel:      ">=6.12",
+			tag:         "v1.11.6",
+			release:     "6.6.58-talos\n",
+			wantStatus:  output.Warning,
+			wantSummary: "kernel 6.6.58-talos does not satisfy >=6.12",
+			wantDetails: fmt.Sprintf(details, "v1.11.6"),
+		},
+		{
+			name:        "CRITICAL - floor and kernel",
+			min:         "1.10.0",
+			kernel:      ">=6.12",
+			tag:         "v1.9.5",
+			release:     "6.6.58-talos",
+			wantStatus:  output.Critical,
+			wantSummary: "Talos v1.9.5 below minimum 1.10.0, kernel 6.6.58-talos does not satisfy >=6.12",
+			wantDetails: fmt.Sprintf(details, "v1.9.5"),
+		},
+		{
+			name:        "UNKNOWN - unparseable tag",
+			expect:      ">=1.11",
+			tag:         "main-dirty",
+			wantStatus:  output.Unknown,
+			wantSummary: `Cannot parse Talos version "main-dirty"`,
+		},
+		{
+			name:        "OK - unparseable tag without constraints",
+			tag:         "main-dirty",
+			wantStatus:  output.OK,
+			wantSummary: "Talos main-dirty",
+		},
+		{
+			name:        "UNKNOWN - unparseable kernel release",
+			kernel:      ">=6.12",
+			tag:         "v1.11.6",
+			release:     "",
+			wantStatus:  output.Unknown,
+			wantSummary: `Cannot parse kernel release ""`,
+		},
+		{
+			name:        "UNKNOWN - empty tag",
+			tag:         "",
+			wantStatus:  output.Unknown,
+			wantSummary: "No version tag in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewVersionCheck(tt.expect, tt.min, tt.kernel, "")
+			if err != nil {
+				t.Fatalf("NewVersionCheck: %v", err)
+			}
+
+			client := &mockVersionClient{resp: makeVersionResponse(tt.tag), kernel: tt.release}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.CheckName != "VERSION" {
+				t.Errorf("CheckName = %q, want %q", result.CheckName, "VERSION")
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+
+			if tt.kernel != "" && tt.tag != "" && client.readPath != kernelReleasePath {
+				t.Errorf("read path = %q, want %q", client.readPath, kernelReleasePath)
+			}
+			if tt.kernel == "" && client.readPath != "" {
+				t.Errorf("kernel release read without --expect-kernel: %q", client.readPath)
+			}
+		})
+	}
+}
+
+func TestVersionCheckErrors(t *testing.T) {
+	ch, err := NewVersionCheck("", "", ">=6.12", "")
+	if err != nil {
+		t.Fatalf("NewVersionCheck: %v", err)
+	}
+
+	if _, err := ch.Run(context.Background(), &mockVersionClient{err: fmt.Errorf("connection refused")}); err == nil {
+		t.Error("expected error from Version, got nil")
+	}
+
+	client := &mockVersionClient{resp: makeVersionResponse("v1.11.6"), readErr: fmt.Errorf("permission denied")}
+	if _, err := ch.Run(context.Background(), client); err == nil {
+		t.Error("expected error from Read, got nil")
+	}
+
+	result, err := ch.Run(context.Background(), &mockVersionClient{resp: &machine.VersionResponse{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != output.Unknown {
+		t.Errorf("status = %v, want UNKNOWN for empty response", result.Status)
+	}
+}
+
+func TestVersionCheckKubernetes(t *testing.T) {
+	const talosDetails = "v1.11.6: sha 4f6d4b2, built 2025-11-20T10:00:00Z, go1.24.9, linux/amd64, platform metal (mode metal)"
+
+	tests := []struct {
+		name        string
+		resources   []resource.Resource
+		wantStatus  output.Status
+		wantSummary string
+		wantDetails string
+	}{
+		{
+			name:        "OK - worker runs only the kubelet",
+			resources:   []resource.Resource{kubeletSpec("ghcr.io/siderolabs/kubelet:v1.34.1")},
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6, Kubernetes v1.34.1 (kubernetes >=1.34.0 <1.35)",
+		},
+		{
+			name:        "OK - control plane on one version",
+			resources:   controlPlaneComponents("v1.34.1", nil),
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6, Kubernetes v1.34.1 (kubernetes >=1.34.0 <1.35)",
+		},
+		{
+			name: "OK - mixed patch releases are listed",
+			resources: controlPlaneComponents("v1.34.1", map[string]string{
+				k8s.SchedulerID: "registry.k8s.io/kube-scheduler:v1.34.0@sha256:0123abcd",
+			}),
+			wantStatus:  output.OK,
+			wantSummary: "Talos v1.11.6, Kubernetes kubelet v1.34.1, kube-apiserver v1.34.1, kube-controller-manager v1.34.1, kube-scheduler v1.34.0 (kubernetes >=1.34.0 <1.35)",
+		},
+		{
+			name: "WARNING - API server not upgraded",
+			resources: controlPlaneComponents("v1.34.1", map[string]string{
+				k8s.APIServerID: "registry.k8s.io/kube-apiserver:v1.33.4",
+			}),
+			wantStatus:  output.Warning,
+			wantSummary: "kube-apiserver v1.33.4 does not satisfy >=1.34.0 <1.35",
+			wantDetails: talosDetails + "\n" +
+				"kubelet: ghcr.io/siderolabs/kubelet:v1.34.1\n" +
+				"kube-apiserver: registry.k8s.io/kube-apiserver:v1.33.4\n" +
+				"kube-controller-manager: registry.k8s.io/kube-controller-manager:v1.34.1\n" +
+				"kube-scheduler: registry.k8s.io/kube-scheduler:v1.34.1",
+		},
+		{
+			name:        "WARNING - kubelet release candidate",
+			resources:   []resource.Resource{kubeletSpec("ghcr.io/siderolabs/kubelet:v1.35.0-rc.1")},
+			wantStatus:  output.Warning,
+			wantSummary: "kubelet v1.35.0-rc.1 does not satisfy >=1.34.0 <1.35",
+			wantDetails: talosDetails + "\nkubelet: ghcr.io/siderolabs/kubelet:v1.35.0-rc.1",
+		},
+		{
+			name:        "UNKNOWN - no kubelet spec",
+			wantStatus:  output.Unknown,
+			wantSummary: "No kubelet spec (kubelet not configured?)",
+		},
+		{
+			name:        "UNKNOWN - image without tag",
+			resources:   []resource.Resource{kubeletSpec("registry.local:5000/kubelet")},
+			wantStatus:  output.Unknown,
+			wantSummary: `Cannot parse kubelet version from image "registry.local:5000/kubelet"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := NewVersionCheck("", "", "", ">=1.34.0 <1.35")
+			if err != nil {
+				t.Fatalf("NewVersionCheck: %v", err)
+			}
+
+			client := &mockVersionClient{resp: makeVersionResponse("v1.11.6"), resources: map[string]resource.Resource{}}
+			for _, r := range tt.resources {
+				client.resources[r.Metadata().Type()+"/"+r.Metadata().ID()] = r
+			}
+			result, err := ch.Run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", result.Summary, tt.wantSummary)
+			}
+			if result.Details != tt.wantDetails {
+				t.Errorf("details:\n  got:  %q\n  want: %q", result.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"ghcr.io/siderolabs/kubelet:v1.34.1":                     "v1.34.1",
+		"registry.k8s.io/kube-apiserver:v1.34.1@sha256:0123abcd": "v1.34.1",
+		"registry.local:5000/kubelet:v1.34.1":                    "v1.34.1",
+		"registry.local:5000/kubelet":                            "",
+		"kubelet":                                                "",
+	}
+	for image, want := range tests {
+		if got := imageTag(image); got != want {
+			t.Errorf("imageTag(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
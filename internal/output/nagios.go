@@ -8,6 +8,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	nagios "github.com/atc0005/go-nagios"
 )
@@ -72,7 +73,7 @@ func (pd PerfDatum) String() string {
 // Result represents the structured output of a check execution.
 type Result struct {
 	Status    Status      // Nagios status (OK, Warning, Critical, Unknown)
-	CheckName string      // Uppercase check name: CPU, MEMORY, DISK, SERVICES, ETCD, LOAD
+	CheckName string      // Uppercase check name, as returned by Check.Name (CPU, DISK-IO, ...)
 	Summary   string      // One-line human-readable summary
 	Details   string      // Optional multi-line long text (visible in extended detail view)
 	PerfData  []PerfDatum // Performance data metrics
@@ -139,6 +140,31 @@ func HumanBytes(bytes uint64) string {
 	}
 }
 
+// HumanDuration formats a duration with its two most significant units
+// (e.g., "3d 4h", "4h 12m", "9m 30s", "45s"). Sub-second precision is dropped.
+func HumanDuration(d time.Duration) string {
+	secs := int64(d / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+
+	days := secs / 86400
+	hours := secs % 86400 / 3600
+	mins := secs % 3600 / 60
+	s := secs % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	case mins > 0:
+		return fmt.Sprintf("%dm %ds", mins, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
 // ApplyToPlugin populates a go-nagios Plugin from this Result.
 // This bridges the output.Result type to go-nagios for exit code
 // handling and panic recovery via Plugin.ReturnCheckResults().
@@ -2,6 +2,7 @@ package output
 
 import (
 	"testing"
+	"time"
 
 	nagios "github.com/atc0005/go-nagios"
 )
@@ -599,6 +600,33 @@ func TestHumanBytes(t *testing.T) {
 	}
 }
 
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{-5 * time.Second, "0s"},
+		{1500 * time.Millisecond, "1s"},
+		{45 * time.Second, "45s"},
+		{60 * time.Second, "1m 0s"},
+		{9*time.Minute + 30*time.Second, "9m 30s"},
+		{time.Hour, "1h 0m"},
+		{4*time.Hour + 12*time.Minute + 5*time.Second, "4h 12m"},
+		{24 * time.Hour, "1d 0h"},
+		{76*time.Hour + 59*time.Minute, "3d 4h"},
+		{400 * 24 * time.Hour, "400d 0h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := HumanDuration(tt.d)
+			if got != tt.want {
+				t.Errorf("HumanDuration(%s) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplyToPlugin(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,257 @@
+// Package semver parses semantic versions and version constraints such as
+// ">=1.11.0 <1.12".
+//
+// A constraint is one or more comparators separated by spaces or commas, all
+// of which must hold. Alternatives are separated by "||":
+//
+//	1.11.6            exactly 1.11.6 (same as "=1.11.6")
+//	>=1.11.0 <1.12    1.11.x and later patch releases, not 1.12
+//	!=1.11.3          anything except 1.11.3
+//	<1.10 || >=1.11   anything outside 1.10.x
+//
+// Versions may carry a leading "v" and may omit minor and patch, which
+// default to zero ("1.12" is 1.12.0). Pre-release versions order before
+// their release (1.12.0-alpha.1 < 1.12.0), following SemVer 2.0.0; build
+// metadata after "+" is ignored.
+//
+// A pre-release only satisfies a constraint when a comparator in the
+// matching alternative names a pre-release of the same major.minor.patch,
+// as in npm and Cargo. Otherwise "<1.12" would admit 1.12.0-beta.1, which
+// sorts below 1.12.0 but is not a 1.11 release:
+//
+//	>=1.11.0 <1.12     rejects 1.12.0-beta.1 and 1.11.7-rc.1
+//	>=1.12.0-beta.0    accepts 1.12.0-beta.1, rejects 1.13.0-alpha.1
+//
+// This package has zero external dependencies.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+	Pre   []string // Dot-separated pre-release identifiers; nil for a release.
+}
+
+// Parse parses a version such as "v1.11.6", "1.12" or "1.12.0-beta.1".
+func Parse(s string) (Version, error) {
+	if s == "" {
+		return Version{}, fmt.Errorf("version must not be empty")
+	}
+
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest = rest[:i]
+	}
+
+	var v Version
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre := rest[i+1:]
+		rest = rest[:i]
+		if pre == "" {
+			return Version{}, fmt.Errorf("invalid version %q: empty pre-release", s)
+		}
+		v.Pre = strings.Split(pre, ".")
+		for _, id := range v.Pre {
+			if id == "" {
+				return Version{}, fmt.Errorf("invalid version %q: empty pre-release identifier", s)
+			}
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q: expected major[.minor[.patch]]", s)
+	}
+	dst := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %q is not a number", s, p)
+		}
+		*dst[i] = n
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal
+// to, or higher than o.
+func (v Version) Compare(o Version) int {
+	for _, pair := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A release is higher than any of its pre-releases.
+	switch {
+	case len(v.Pre) == 0 && len(o.Pre) == 0:
+		return 0
+	case len(v.Pre) == 0:
+		return 1
+	case len(o.Pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Pre) && i < len(o.Pre); i++ {
+		if c := compareIdentifier(v.Pre[i], o.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.Pre) < len(o.Pre):
+		return -1
+	case len(v.Pre) > len(o.Pre):
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares two pre-release identifiers: numeric
+// identifiers compare numerically and order before alphanumeric ones,
+// which compare as strings.
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// String returns the version as "major.minor.patch[-pre]", without a
+// leading "v".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Pre) > 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	return s
+}
+
+// comparator is a single operator and version, e.g. ">=1.11.0".
+type comparator struct {
+	op      string
+	version Version
+}
+
+// operators accepted in a comparator, longest first so that ">=" is not
+// read as ">".
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// matches reports whether v satisfies the comparator.
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Constraint is a parsed version constraint.
+type Constraint struct {
+	raw  string
+	alts [][]comparator // OR of ANDs
+}
+
+// ParseConstraint parses a constraint such as ">=1.11.0 <1.12". See the
+// package documentation for the syntax.
+func ParseConstraint(s string) (Constraint, error) {
+	if strings.TrimSpace(s) == "" {
+		return Constraint{}, fmt.Errorf("constraint must not be empty")
+	}
+
+	c := Constraint{raw: strings.TrimSpace(s)}
+	for _, alt := range strings.Split(s, "||") {
+		fields := strings.FieldsFunc(alt, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(fields) == 0 {
+			return Constraint{}, fmt.Errorf("empty alternative in %q", s)
+		}
+
+		var and []comparator
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			op := "="
+			for _, o := range operators {
+				if strings.HasPrefix(f, o) {
+					op = o
+					f = f[len(o):]
+					break
+				}
+			}
+			// Allow a space between operator and version (">= 1.11").
+			if f == "" && i+1 < len(fields) {
+				i++
+				f = fields[i]
+			}
+			v, err := Parse(f)
+			if err != nil {
+				return Constraint{}, err
+			}
+			and = append(and, comparator{op: op, version: v})
+		}
+		c.alts = append(c.alts, and)
+	}
+
+	return c, nil
+}
+
+// Check reports whether v satisfies the constraint. A pre-release v must
+// also share major.minor.patch with a pre-release comparator of the
+// alternative it satisfies (see the package documentation).
+func (c Constraint) Check(v Version) bool {
+	for _, and := range c.alts {
+		ok := true
+		allowPre := len(v.Pre) == 0
+		for _, cmp := range and {
+			if !cmp.matches(v) {
+				ok = false
+				break
+			}
+			if len(cmp.version.Pre) > 0 && cmp.version.Major == v.Major &&
+				cmp.version.Minor == v.Minor && cmp.version.Patch == v.Patch {
+				allowPre = true
+			}
+		}
+		if ok && allowPre {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was written.
+func (c Constraint) String() string {
+	return c.raw
+}
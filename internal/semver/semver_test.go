@@ -0,0 +1,159 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{name: "full", input: "1.11.6", want: Version{Major: 1, Minor: 11, Patch: 6}},
+		{name: "leading v", input: "v1.11.6", want: Version{Major: 1, Minor: 11, Patch: 6}},
+		{name: "major.minor", input: "1.12", want: Version{Major: 1, Minor: 12}},
+		{name: "major only", input: "2", want: Version{Major: 2}},
+		{name: "pre-release", input: "v1.12.0-beta.1", want: Version{Major: 1, Minor: 12, Pre: []string{"beta", "1"}}},
+		{name: "build metadata ignored", input: "1.11.6+abc123", want: Version{Major: 1, Minor: 11, Patch: 6}},
+		{name: "empty", input: "", wantErr: true},
+		{name: "not a number", input: "1.x.0", wantErr: true},
+		{name: "too many parts", input: "1.2.3.4", wantErr: true},
+		{name: "empty pre-release", input: "1.2.3-", wantErr: true},
+		{name: "empty pre-release identifier", input: "1.2.3-alpha..1", wantErr: true},
+		{name: "just v", input: "v", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) expected error, got %+v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each version is lower than the next (SemVer 2.0.0 section 11 example).
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+		"1.0.1", "1.1.0", "1.11.0", "2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		if got := a.Compare(b); got != -1 {
+			t.Errorf("Compare(%s, %s) = %d, want -1", ordered[i], ordered[i+1], got)
+		}
+		if got := b.Compare(a); got != 1 {
+			t.Errorf("Compare(%s, %s) = %d, want 1", ordered[i+1], ordered[i], got)
+		}
+	}
+
+	a, _ := Parse("v1.12")
+	b, _ := Parse("1.12.0")
+	if got := a.Compare(b); got != 0 {
+		t.Errorf("Compare(v1.12, 1.12.0) = %d, want 0", got)
+	}
+}
+
+func TestString(t *testing.T) {
+	for input, want := range map[string]string{
+		"v1.11.6":       "1.11.6",
+		"1.12":          "1.12.0",
+		"1.12.0-beta.1": "1.12.0-beta.1",
+	} {
+		v, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		if got := v.String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">=1.11.0 <1.12", "1.11.0", true},
+		{">=1.11.0 <1.12", "v1.11.6", true},
+		{">=1.11.0 <1.12", "1.12.0", false},
+		{">=1.11.0 <1.12", "1.10.9", false},
+		{">=1.11.0, <1.12", "1.11.3", true},
+		{">= 1.11", "1.11.3", true},
+		{"1.11.6", "1.11.6", true},
+		{"1.11.6", "1.11.5", false},
+		{"=1.11.6", "v1.11.6", true},
+		{"==1.11.6", "1.11.6", true},
+		{"!=1.11.3", "1.11.3", false},
+		{"!=1.11.3", "1.11.4", true},
+		{">1.11.5", "1.11.6", true},
+		{"<=1.11.5", "1.11.6", false},
+		{"<1.10 || >=1.11", "1.10.4", false},
+		{"<1.10 || >=1.11", "1.11.0", true},
+		{"<1.10 || >=1.11", "1.9.2", true},
+		// Pre-releases of the next minor sort below its release but are
+		// excluded from a range that does not name a pre-release.
+		{"<1.12", "1.12.0-alpha.1", false},
+		{">=1.11.0 <1.12", "v1.12.0-beta.1", false},
+		{">=1.11.0 <1.12", "1.11.7-rc.1", false},
+		{">=1.12.0", "1.12.0-beta.0", false},
+		// A comparator naming a pre-release admits pre-releases of the same
+		// major.minor.patch only.
+		{">=1.12.0-beta.0", "1.12.0-beta.1", true},
+		{">=1.12.0-beta.0", "1.12.0", true},
+		{">=1.12.0-beta.0", "1.13.0-alpha.1", false},
+		{">=1.11.0 <1.12 || >=1.12.0-alpha.0 <1.12.0", "1.12.0-beta.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q): %v", tt.constraint, err)
+			}
+			v, err := Parse(tt.version)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.version, err)
+			}
+			if got := c.Check(v); got != tt.want {
+				t.Errorf("%q.Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, input := range []string{"", "   ", ">=", ">=1.x", "1.11 ||", "|| 1.11", "~1.11"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestConstraintString(t *testing.T) {
+	c, err := ParseConstraint("  >=1.11.0 <1.12 ")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if got, want := c.String(), ">=1.11.0 <1.12"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -4,17 +4,23 @@
 package talos
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -122,6 +128,13 @@ func (c *Client) EtcdStatus(ctx context.Context) (*machine.EtcdStatusResponse, e
 	return c.inner.EtcdStatus(c.nodeCtx(ctx))
 }
 
+// EtcdStatusNodes returns the etcd member status of each of nodes. The
+// response is not filtered: a node that failed keeps its message, with the
+// error in its metadata, so the caller can report it by node.
+func (c *Client) EtcdStatusNodes(ctx context.Context, nodes []string) (*machine.EtcdStatusResponse, error) {
+	return c.inner.MachineClient.EtcdStatus(talosclient.WithNodes(ctx, nodes...), &emptypb.Empty{})
+}
+
 // EtcdMemberList returns the list of etcd cluster members.
 func (c *Client) EtcdMemberList(ctx context.Context) (*machine.EtcdMemberListResponse, error) {
 	return c.inner.EtcdMemberList(c.nodeCtx(ctx), &machine.EtcdMemberListRequest{})
@@ -137,6 +150,93 @@ func (c *Client) LoadAvg(ctx context.Context) (*machine.LoadAvgResponse, error)
 	return c.inner.MachineClient.LoadAvg(c.nodeCtx(ctx), &emptypb.Empty{})
 }
 
+// NetworkDeviceStats returns cumulative per-interface network counters.
+func (c *Client) NetworkDeviceStats(ctx context.Context) (*machine.NetworkDeviceStatsResponse, error) {
+	return c.inner.MachineClient.NetworkDeviceStats(c.nodeCtx(ctx), &emptypb.Empty{})
+}
+
+// DiskStats returns cumulative per-device block I/O counters.
+func (c *Client) DiskStats(ctx context.Context) (*machine.DiskStatsResponse, error) {
+	return c.inner.MachineClient.DiskStats(c.nodeCtx(ctx), &emptypb.Empty{})
+}
+
+// Read returns the contents of a file on the node, e.g. /proc/mounts.
+// The machinery stream reader treats a deadline as end of file, so a read
+// cut short by the timeout is reported as a DeadlineExceeded error instead
+// of returning truncated data.
+func (c *Client) Read(ctx context.Context, path string) ([]byte, error) {
+	r, err := c.inner.Read(c.nodeCtx(ctx), path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return data, nil
+}
+
+// Processes returns the node's process list with state and command.
+func (c *Client) Processes(ctx context.Context) (*machine.ProcessesResponse, error) {
+	return c.inner.Processes(c.nodeCtx(ctx))
+}
+
+// Version returns the Talos version, build and platform information.
+func (c *Client) Version(ctx context.Context) (*machine.VersionResponse, error) {
+	return c.inner.Version(c.nodeCtx(ctx))
+}
+
+// Containers returns the containers in a containerd namespace, listed
+// through the given driver.
+func (c *Client) Containers(ctx context.Context, namespace string, driver common.ContainerDriver) (*machine.ContainersResponse, error) {
+	return c.inner.Containers(c.nodeCtx(ctx), namespace, driver)
+}
+
+// Dmesg returns the kernel ring buffer as formatted by Talos, one message
+// per line. Unlike Read, the stream is consumed message by message so that
+// each message ends in a newline whatever the server sends.
+func (c *Client) Dmesg(ctx context.Context) ([]byte, error) {
+	stream, err := c.inner.Dmesg(c.nodeCtx(ctx), false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for {
+		data, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if md := data.GetMetadata(); md != nil && md.GetError() != "" {
+			return nil, errors.New(md.GetError())
+		}
+		if len(data.GetBytes()) == 0 {
+			continue
+		}
+		buf.Write(data.GetBytes())
+		if !bytes.HasSuffix(data.GetBytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// GetResource returns one resource from the node's COSI state. Resources
+// are decoded through the machinery type registry, so the caller's package
+// must import the resource package (e.g. resources/config) to get typed
+// resources back.
+func (c *Client) GetResource(ctx context.Context, ptr resource.Pointer) (resource.Resource, error) {
+	return c.inner.COSI.Get(c.nodeCtx(ctx), ptr)
+}
+
 // buildTLSConfig creates a mutual TLS configuration from certificate file paths
 // or base64-encoded PEM data.
 func buildTLSConfig(caPath, certPath, keyPath string) (*tls.Config, error) {
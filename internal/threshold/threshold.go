@@ -10,6 +10,11 @@
 //	10:20   alert if value < 10 or > 20   (outside 10..20)
 //	@10:20  alert if 10 <= value <= 20    (inside 10..20)
 //
+// Range endpoints may carry a size suffix (kB, MB, GB, ... or KiB, MiB,
+// GiB, ...) or a duration suffix (s, m, h, d). Suffixed values are
+// normalized to bytes or seconds, so "~:100MB" is equivalent to
+// "~:100000000" and "@0:10m" to "@0:600".
+//
 // This package has zero external dependencies.
 package threshold
 
@@ -20,12 +25,34 @@ import (
 	"strings"
 )
 
+// Unit identifies the kind of suffix used in a threshold range.
+type Unit int
+
+const (
+	UnitNone    Unit = iota // Bare numbers, no suffix.
+	UnitBytes               // Size suffix; values are in bytes.
+	UnitSeconds             // Duration suffix; values are in seconds.
+)
+
+// String returns the kind of value the unit describes.
+func (u Unit) String() string {
+	switch u {
+	case UnitBytes:
+		return "size"
+	case UnitSeconds:
+		return "duration"
+	default:
+		return "number"
+	}
+}
+
 // Threshold represents a parsed Nagios threshold range.
 type Threshold struct {
 	Start    float64 // Lower bound of the range.
 	End      float64 // Upper bound of the range.
 	Inside   bool    // If true, alert when value is INSIDE the range (@ prefix).
 	StartInf bool    // If true, no lower bound (~ prefix means -infinity).
+	Unit     Unit    // Unit of the suffixed endpoints; UnitNone if none had a suffix.
 }
 
 // Parse parses a Nagios threshold range string into a Threshold.
@@ -38,6 +65,14 @@ type Threshold struct {
 //	"10:20"   → outside 10..20
 //	"@10:20"  → inside 10..20
 //	"@~:20"   → inside -inf..20
+//	"10GiB:"  → outside 10737418240..+inf (bytes)
+//	"@0:10m"  → inside 0..600 (seconds)
+//
+// Size suffixes are decimal (B, kB, MB, GB, TB, PB) or binary (KiB, MiB,
+// GiB, TiB, PiB); duration suffixes are s, m, h and d. Suffixes are
+// case-insensitive, so "m" is always minutes and megabytes must be written
+// "MB". Both endpoints of a range must use the same kind of suffix; a bare
+// number is allowed on either side.
 func Parse(s string) (Threshold, error) {
 	if s == "" {
 		return Threshold{}, fmt.Errorf("threshold must not be empty")
@@ -63,31 +98,39 @@ func Parse(s string) (Threshold, error) {
 		} else if startStr == "" {
 			t.Start = 0
 		} else {
-			v, err := strconv.ParseFloat(startStr, 64)
+			v, unit, err := parseValue(startStr)
 			if err != nil {
 				return Threshold{}, fmt.Errorf("invalid start value %q: %w", startStr, err)
 			}
 			t.Start = v
+			t.Unit = unit
 		}
 
 		// Parse end value.
 		if endStr == "" {
 			t.End = math.Inf(1)
 		} else {
-			v, err := strconv.ParseFloat(endStr, 64)
+			v, unit, err := parseValue(endStr)
 			if err != nil {
 				return Threshold{}, fmt.Errorf("invalid end value %q: %w", endStr, err)
 			}
+			if unit != UnitNone && t.Unit != UnitNone && unit != t.Unit {
+				return Threshold{}, fmt.Errorf("start value %q and end value %q use different units", startStr, endStr)
+			}
 			t.End = v
+			if unit != UnitNone {
+				t.Unit = unit
+			}
 		}
 	} else {
 		// No colon: simple format like "10" means 0..10.
-		v, err := strconv.ParseFloat(s, 64)
+		v, unit, err := parseValue(s)
 		if err != nil {
 			return Threshold{}, fmt.Errorf("invalid threshold value %q: %w", s, err)
 		}
 		t.Start = 0
 		t.End = v
+		t.Unit = unit
 	}
 
 	// Validate that start does not exceed end.
@@ -99,6 +142,91 @@ func Parse(s string) (Threshold, error) {
 	return t, nil
 }
 
+// ParseUnit parses s like Parse for a metric measured in unit. A suffix of
+// a different kind is rejected (e.g. "10m" for a size), and the returned
+// Threshold carries unit even when s used bare numbers, so Human renders
+// it with suffixes. UnitNone rejects every suffix.
+func ParseUnit(s string, unit Unit) (Threshold, error) {
+	t, err := Parse(s)
+	if err != nil {
+		return Threshold{}, err
+	}
+	if t.Unit != UnitNone && t.Unit != unit {
+		return Threshold{}, fmt.Errorf("%q uses a %s suffix, expected a %s", s, t.Unit, unit)
+	}
+	t.Unit = unit
+	return t, nil
+}
+
+// ParseValue parses a single value (not a range) measured in unit, with an
+// optional suffix of that kind: "2GiB" returns 2147483648 for UnitBytes.
+func ParseValue(s string, unit Unit) (float64, error) {
+	v, u, err := parseValue(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q: %w", s, err)
+	}
+	if u != UnitNone && u != unit {
+		return 0, fmt.Errorf("%q uses a %s suffix, expected a %s", s, u, unit)
+	}
+	return v, nil
+}
+
+// suffix is a unit suffix and its multiplier to the base unit.
+type suffix struct {
+	name string
+	mult float64
+}
+
+// Suffixes accepted by Parse and rendered by Human.
+var (
+	sizeSuffixes = []suffix{
+		{"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+		{"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3},
+		{"B", 1},
+	}
+	durationSuffixes = []suffix{
+		{"d", 86400}, {"h", 3600}, {"m", 60}, {"s", 1},
+	}
+)
+
+// parseValue parses a range endpoint with an optional size or duration
+// suffix and returns the value normalized to bytes or seconds.
+func parseValue(s string) (float64, Unit, error) {
+	num, unit := splitSuffix(s)
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, UnitNone, err
+	}
+	if unit == "" {
+		return v, UnitNone, nil
+	}
+	for _, sf := range sizeSuffixes {
+		if strings.EqualFold(unit, sf.name) {
+			return v * sf.mult, UnitBytes, nil
+		}
+	}
+	for _, sf := range durationSuffixes {
+		if strings.EqualFold(unit, sf.name) {
+			return v * sf.mult, UnitSeconds, nil
+		}
+	}
+	return 0, UnitNone, fmt.Errorf("unknown unit %q", unit)
+}
+
+// splitSuffix splits s into its leading numeric part and a trailing
+// alphabetic unit suffix.
+func splitSuffix(s string) (num, unit string) {
+	i := len(s)
+	for i > 0 {
+		c := s[i-1]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		i--
+	}
+	return s[:i], s[i:]
+}
+
 // Violated reports whether the given value triggers an alert according to
 // this threshold.
 //
@@ -121,11 +249,32 @@ func (t Threshold) Violated(value float64) bool {
 	return !inRange
 }
 
-// String serializes the Threshold back to Nagios range notation.
+// String serializes the Threshold back to Nagios range notation with
+// endpoints in the base unit (bytes or seconds).
 //
 // The output is suitable for perfdata and can be round-tripped through Parse
 // to produce an equivalent Threshold.
 func (t Threshold) String() string {
+	return t.format(formatFloat)
+}
+
+// Human serializes the Threshold like String, but renders endpoints with the
+// largest size or duration suffix that represents them exactly, e.g.
+// "~:100MB" or "@0:10m". It is meant for summaries and round-trips through
+// Parse. Thresholds without a unit render the same as String.
+func (t Threshold) Human() string {
+	switch t.Unit {
+	case UnitBytes:
+		return t.format(func(v float64) string { return formatSuffixed(v, sizeSuffixes) })
+	case UnitSeconds:
+		return t.format(func(v float64) string { return formatSuffixed(v, durationSuffixes) })
+	default:
+		return t.String()
+	}
+}
+
+// format serializes the Threshold using fmtValue for the endpoints.
+func (t Threshold) format(fmtValue func(float64) string) string {
 	var b strings.Builder
 
 	if t.Inside {
@@ -135,27 +284,45 @@ func (t Threshold) String() string {
 	if t.StartInf {
 		b.WriteByte('~')
 		b.WriteByte(':')
-		b.WriteString(formatFloat(t.End))
+		b.WriteString(fmtValue(t.End))
 		return b.String()
 	}
 
 	if math.IsInf(t.End, 1) {
-		b.WriteString(formatFloat(t.Start))
+		b.WriteString(fmtValue(t.Start))
 		b.WriteByte(':')
 		return b.String()
 	}
 
 	if t.Start == 0 && !t.Inside {
-		b.WriteString(formatFloat(t.End))
+		b.WriteString(fmtValue(t.End))
 		return b.String()
 	}
 
-	b.WriteString(formatFloat(t.Start))
+	b.WriteString(fmtValue(t.Start))
 	b.WriteByte(':')
-	b.WriteString(formatFloat(t.End))
+	b.WriteString(fmtValue(t.End))
 	return b.String()
 }
 
+// formatSuffixed formats v with the suffix that gives the smallest mantissa
+// of at least 1 with at most two decimals, so the value is represented
+// exactly. Zero and values no suffix fits are rendered bare.
+func formatSuffixed(v float64, suffixes []suffix) string {
+	best := ""
+	bestQ := math.Inf(1)
+	for _, sf := range suffixes {
+		q := v / sf.mult
+		if math.Abs(q) >= 1 && q*100 == math.Trunc(q*100) && math.Abs(q) < math.Abs(bestQ) {
+			best, bestQ = sf.name, q
+		}
+	}
+	if best == "" {
+		return formatFloat(v)
+	}
+	return formatFloat(bestQ) + best
+}
+
 // formatFloat formats a float64 as a compact string: integers without a
 // decimal point (e.g. "80"), and fractional values with minimal precision
 // (e.g. "1.5").
@@ -322,6 +322,241 @@ func TestStringRoundtrip(t *testing.T) {
 	}
 }
 
+func TestParseSuffixes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Threshold
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "bytes suffix",
+			input: "512B",
+			want:  Threshold{Start: 0, End: 512, Unit: UnitBytes},
+		},
+		{
+			name:  "binary open-ended",
+			input: "10GiB:",
+			want:  Threshold{Start: 10 << 30, End: math.Inf(1), Unit: UnitBytes},
+		},
+		{
+			name:  "decimal upper bound",
+			input: "~:100MB",
+			want:  Threshold{Start: 0, End: 100e6, StartInf: true, Unit: UnitBytes},
+		},
+		{
+			name:  "mixed size units range",
+			input: "512MiB:2GB",
+			want:  Threshold{Start: 512 << 20, End: 2e9, Unit: UnitBytes},
+		},
+		{
+			name:  "case-insensitive size",
+			input: "1gib:",
+			want:  Threshold{Start: 1 << 30, End: math.Inf(1), Unit: UnitBytes},
+		},
+		{
+			name:  "fractional size",
+			input: "1.5KiB",
+			want:  Threshold{Start: 0, End: 1536, Unit: UnitBytes},
+		},
+		{
+			name:  "bare start with suffixed end",
+			input: "@0:1TiB",
+			want:  Threshold{Start: 0, End: 1 << 40, Inside: true, Unit: UnitBytes},
+		},
+		{
+			name:  "minutes",
+			input: "@0:10m",
+			want:  Threshold{Start: 0, End: 600, Inside: true, Unit: UnitSeconds},
+		},
+		{
+			name:  "hours and days",
+			input: "1h:2d",
+			want:  Threshold{Start: 3600, End: 172800, Unit: UnitSeconds},
+		},
+		{
+			name:  "seconds",
+			input: "~:30s",
+			want:  Threshold{Start: 0, End: 30, StartInf: true, Unit: UnitSeconds},
+		},
+		{
+			name:    "unknown unit",
+			input:   "10XB:",
+			wantErr: true,
+			errMsg:  "unknown unit",
+		},
+		{
+			name:    "unit only",
+			input:   "GiB",
+			wantErr: true,
+			errMsg:  "invalid threshold value",
+		},
+		{
+			name:    "size and duration mixed",
+			input:   "10s:1GiB",
+			wantErr: true,
+			errMsg:  "different units",
+		},
+		{
+			name:    "start exceeds end after normalization",
+			input:   "1GiB:1GB",
+			wantErr: true,
+			errMsg:  "must not exceed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+				}
+				if !containsSubstring(err.Error(), tt.errMsg) {
+					t.Errorf("Parse(%q) error = %q, want substring %q", tt.input, err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHuman(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantString string
+		wantHuman  string
+	}{
+		{"80", "80", "80"},
+		{"~:100000000", "~:100000000", "~:100000000"},
+		{"~:100MB", "~:100000000", "~:100MB"},
+		{"10GiB:", "10737418240:", "10GiB:"},
+		{"1.5GiB", "1610612736", "1.5GiB"},
+		{"@0:1TB", "@0:1000000000000", "@0:1TB"},
+		{"512B", "512", "512B"},
+		{"@0:3600s", "@0:3600", "@0:1h"},
+		{"@0:10m", "@0:600", "@0:10m"},
+		{"90s:2d", "90:172800", "1.5m:2d"},
+		{"~:45s", "~:45", "~:45s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			th, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if got := th.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+			got := th.Human()
+			if got != tt.wantHuman {
+				t.Errorf("Human() = %q, want %q", got, tt.wantHuman)
+			}
+
+			// The human form must round-trip to the same range.
+			back, err := Parse(got)
+			if err != nil {
+				t.Fatalf("Parse(%q) [roundtrip] unexpected error: %v", got, err)
+			}
+			if back.Start != th.Start || back.End != th.End || back.Inside != th.Inside || back.StartInf != th.StartInf {
+				t.Errorf("roundtrip mismatch: Parse(%q) = %+v, want %+v", got, back, th)
+			}
+		})
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		unit      Unit
+		wantHuman string
+		wantErr   string
+	}{
+		{name: "bare bytes get size suffix", input: "~:100000000", unit: UnitBytes, wantHuman: "~:100MB"},
+		{name: "size suffix", input: "10GiB:", unit: UnitBytes, wantHuman: "10GiB:"},
+		{name: "bare seconds get duration suffix", input: "@0:3600", unit: UnitSeconds, wantHuman: "@0:1h"},
+		{name: "bare number", input: "80", unit: UnitNone, wantHuman: "80"},
+		{name: "duration for size", input: "~:10m", unit: UnitBytes, wantErr: "uses a duration suffix, expected a size"},
+		{name: "size for duration", input: "@0:1GB", unit: UnitSeconds, wantErr: "uses a size suffix, expected a duration"},
+		{name: "suffix for number", input: "10GiB", unit: UnitNone, wantErr: "uses a size suffix, expected a number"},
+		{name: "parse error", input: "abc", unit: UnitBytes, wantErr: "invalid threshold value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnit(tt.input, tt.unit)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("ParseUnit(%q) expected error, got nil", tt.input)
+				}
+				if !containsSubstring(err.Error(), tt.wantErr) {
+					t.Errorf("ParseUnit(%q) error = %q, want substring %q", tt.input, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseUnit(%q) unexpected error: %v", tt.input, err)
+			}
+			if got.Unit != tt.unit {
+				t.Errorf("Unit = %v, want %v", got.Unit, tt.unit)
+			}
+			if h := got.Human(); h != tt.wantHuman {
+				t.Errorf("Human() = %q, want %q", h, tt.wantHuman)
+			}
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		unit    Unit
+		want    float64
+		wantErr string
+	}{
+		{name: "binary size", input: "2GiB", unit: UnitBytes, want: 2147483648},
+		{name: "decimal size", input: "8GB", unit: UnitBytes, want: 8e9},
+		{name: "bare bytes", input: "1048576", unit: UnitBytes, want: 1048576},
+		{name: "duration", input: "5m", unit: UnitSeconds, want: 300},
+		{name: "duration for size", input: "10m", unit: UnitBytes, wantErr: "uses a duration suffix, expected a size"},
+		{name: "range rejected", input: "~:2GiB", unit: UnitBytes, wantErr: "invalid value"},
+		{name: "unknown suffix", input: "2GX", unit: UnitBytes, wantErr: "invalid value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseValue(tt.input, tt.unit)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("ParseValue(%q) expected error, got nil", tt.input)
+				}
+				if !containsSubstring(err.Error(), tt.wantErr) {
+					t.Errorf("ParseValue(%q) error = %q, want substring %q", tt.input, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseValue(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseValue(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func containsSubstring(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsAt(s, substr))
 }